@@ -8,13 +8,19 @@ import (
 	"dojo/internal/repository"
 	"dojo/internal/routes"
 	"dojo/internal/service"
+	"dojo/internal/service/scrapper"
 	"dojo/internal/utils"
 	"dojo/internal/websocket"
 	"dojo/pkg/database"
+	"dojo/pkg/redis"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 func main() {
@@ -24,7 +30,10 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 	// Initialize Validator
-	utils.InitValidator()
+	utils.InitValidator(func(name string) bool {
+		_, ok := scrapper.Get(name)
+		return ok
+	})
 
 	// DB ka connection and other initializations go here
 	isDebug := cfg.App.Env == "development" // Enable GORM debug mode in development
@@ -35,13 +44,31 @@ func main() {
 	database.DB = db
 	log.Println("Database connected successfully")
 
+	// Redis is optional; without it rate limiting falls back to in-memory storage
+	var rateLimitStorage fiber.Storage
+	if cfg.RedisEnabled() {
+		redisClient, err := redis.Connect(cfg.Redis.URL, cfg.GetRedisAddr(), cfg.Redis.Password, cfg.Redis.DB)
+		if err != nil {
+			log.Printf("Warning: Redis unavailable, falling back to in-memory storage: %v", err)
+		} else {
+			rateLimitStorage = redis.NewStorage(redisClient, "dojo:ratelimit:")
+		}
+	} else {
+		log.Println("Redis not configured, using in-memory storage")
+	}
+
 	// AutoMigrate models
+	if err := database.MigrateLegacyData(); err != nil {
+		log.Fatalf("Failed to migrate legacy data: %v", err)
+	}
 	if err := database.AutoMigrate(
 		&models.User{},
 		&models.AuthAccount{},
 		&models.RefreshToken{},
 		&models.UserProfile{},
 		&models.UserPlatformStat{},
+		&models.PlatformSyncLog{},
+		&models.PlatformStatSnapshot{},
 		&models.Friend{},
 		&models.FriendRequest{},
 		&models.BlockedUser{},
@@ -51,8 +78,21 @@ func main() {
 		&models.SheetProblem{},
 		&models.UserNote{},
 		&models.UserProblemProgress{},
+		&models.ProblemSubmission{},
+		&models.PendingSubmission{},
+		&models.ProblemReview{},
 		&models.Contest{},
 		&models.ContestReminder{},
+		&models.ContestScheduleChange{},
+		&models.ContestParticipation{},
+		&models.VirtualContest{},
+		&models.VirtualContestProblem{},
+		&models.VirtualContestParticipant{},
+		&models.VirtualContestSubmission{},
+		&models.PrivateContest{},
+		&models.PrivateContestProblem{},
+		&models.PrivateContestParticipant{},
+		&models.PrivateContestSubmission{},
 		&models.Room{},
 		&models.RoomParticipant{},
 		&models.CodeSession{},
@@ -61,22 +101,54 @@ func main() {
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
+	if err := database.CreateSearchIndexes(); err != nil {
+		log.Fatalf("Failed to create search indexes: %v", err)
+	}
+	// Initialize WebSocket Hub (Redis broker shares rooms across instances when available)
+	wsInstanceID := uuid.New().String()
+	var wsBroker websocket.Broker = websocket.NewMemoryBroker()
+	if redis.Client != nil {
+		wsBroker = websocket.NewRedisBroker(redis.Client, wsInstanceID)
+	}
+	wsHub := websocket.NewHub(wsBroker, wsInstanceID)
+	// Starting hub in background
+	go wsHub.Run()
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	authRepo := repository.NewAuthRepository(db)
 	problemRepo := repository.NewProblemRepository(db)
+	noteRepo := repository.NewNoteRepository(db)
+	reviewRepo := repository.NewReviewRepository(db)
+	submissionRepo := repository.NewSubmissionRepository(db)
 	contestRepo := repository.NewContestRepository(db)
 	sheetRepo := repository.NewSheetRepository(db)
 	socialRepo := repository.NewSocialRepository(db)
 	roomRepo := repository.NewRoomRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	virtualContestRepo := repository.NewVirtualContestRepository(db)
+	privateContestRepo := repository.NewPrivateContestRepository(db)
+
+	// Solves recorded before the submission log become accepted submissions
+	if backfilled, err := submissionRepo.BackfillFromProgress(); err != nil {
+		log.Printf("Warning: failed to backfill submissions: %v", err)
+	} else if backfilled > 0 {
+		log.Printf("Backfilled %d submissions from solved problems", backfilled)
+	}
 
 	// initialize Services
 	authService := service.NewAuthService(userRepo, authRepo, cfg)
-	userService := service.NewUserService(userRepo)
-	problemService := service.NewProblemService(problemRepo)
-	contestService := service.NewContestService(contestRepo)
+	userService := service.NewUserService(userRepo, contestRepo, problemRepo, submissionRepo)
+	notificationService := service.NewNotificationService(notificationRepo)
+	virtualContestService := service.NewVirtualContestService(virtualContestRepo, problemRepo, contestRepo, socialRepo, roomRepo, userRepo, notificationService, wsHub)
+	reviewService := service.NewReviewService(reviewRepo, problemRepo)
+	problemService := service.NewProblemService(problemRepo, submissionRepo, virtualContestService, reviewService)
+	noteService := service.NewNoteService(noteRepo, problemRepo)
+	recommendationService := service.NewRecommendationService(problemRepo, userRepo)
+	privateContestService := service.NewPrivateContestService(privateContestRepo, problemRepo)
+	contestService := service.NewContestService(contestRepo, userRepo, notificationService)
 	sheetService := service.NewSheetService(sheetRepo, problemRepo)
-	socialService := service.NewSocialService(socialRepo, userRepo)
+	socialService := service.NewSocialService(socialRepo, userRepo, notificationService)
 	roomService := service.NewRoomService(roomRepo, userRepo)
 
 	// Initialize contest sync service
@@ -85,19 +157,39 @@ func main() {
 	go contestSyncService.Start(6 * time.Hour)
 	log.Println("Contest sync service started (syncing every 6 hours)")
 
+	// Initialize stats sync service (STATS_SYNC_INTERVAL=0 disables it)
+	statsSyncService := service.NewStatsSyncService(userService, userRepo, cfg.StatsSync.Workers)
+	if cfg.StatsSync.Interval > 0 {
+		go statsSyncService.Start(cfg.StatsSync.Interval)
+		log.Printf("Stats sync service started (syncing every %s)", cfg.StatsSync.Interval)
+	}
+
+	// Initialize reminder dispatcher (in-app always, email and webhook when configured)
+	reminderChannels := []service.ReminderChannel{service.NewInAppReminderChannel(notificationService)}
+	if cfg.SMTP.Host != "" {
+		reminderChannels = append(reminderChannels, service.NewEmailReminderChannel(cfg.SMTP))
+	}
+	if cfg.Reminder.WebhookURL != "" {
+		reminderChannels = append(reminderChannels, service.NewWebhookReminderChannel(cfg.Reminder.WebhookURL))
+	}
+	reminderDispatchService := service.NewReminderDispatchService(contestRepo, reminderChannels...)
+	go reminderDispatchService.Start(cfg.Reminder.CheckInterval)
+	log.Printf("Reminder dispatch service started (checking every %s)", cfg.Reminder.CheckInterval)
+
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService, cfg)
 	userHandler := handler.NewUserHandler(userService)
 	problemHandler := handler.NewProblemHandler(problemService)
+	noteHandler := handler.NewNoteHandler(noteService)
+	recommendationHandler := handler.NewRecommendationHandler(recommendationService)
+	reviewHandler := handler.NewReviewHandler(reviewService)
 	contestHandler := handler.NewContestHandler(contestService)
+	virtualContestHandler := handler.NewVirtualContestHandler(virtualContestService)
+	privateContestHandler := handler.NewPrivateContestHandler(privateContestService)
 	sheetHandler := handler.NewSheetHandler(sheetService)
 	socialHandler := handler.NewSocialHandler(socialService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
 	roomHandler := handler.NewRoomHandler(roomService)
-
-	// Initialize WebSocket Hub
-	wsHub := websocket.NewHub()
-	// Starting hub in background
-	go wsHub.Run()
 	// Initialize WebSocket handler
 	roomWSHandler := websocket.NewRoomHandler(wsHub)
 
@@ -110,21 +202,38 @@ func main() {
 
 	app.Use(middleware.LoggerMiddleware())
 	app.Use(middleware.CORSMiddleware(cfg))
-	app.Use(middleware.RateLimitMiddleware(cfg))
+	app.Use(middleware.RateLimitMiddleware(cfg, rateLimitStorage))
 
 	// setup routes
 	handlers := &routes.Handlers{
-		Auth:    authHandler,
-		User:    userHandler,
-		Problem: problemHandler,
-		Contest: contestHandler,
-		Sheet:   sheetHandler,
-		Social:  socialHandler,
-		Room:    roomHandler,
-		RoomWS:  roomWSHandler,
+		Auth:           authHandler,
+		User:           userHandler,
+		Problem:        problemHandler,
+		Note:           noteHandler,
+		Recommendation: recommendationHandler,
+		Review:         reviewHandler,
+		Contest:        contestHandler,
+		VirtualContest: virtualContestHandler,
+		PrivateContest: privateContestHandler,
+		Sheet:          sheetHandler,
+		Social:         socialHandler,
+		Notification:   notificationHandler,
+		Room:           roomHandler,
+		RoomWS:         roomWSHandler,
 	}
 	routes.SetupRoutes(app, handlers, cfg)
 
+	// Graceful shutdown on SIGINT/SIGTERM
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-quit
+		log.Println("Shutting down server...")
+		if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+			log.Printf("Error during server shutdown: %v", err)
+		}
+	}()
+
 	// Start server
 	log.Printf("Starting server on port %s", cfg.Server.Port)
 	err = app.Listen("0.0.0.0:" + cfg.Server.Port)
@@ -132,4 +241,20 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 
+	// Release connections once the server has stopped
+	contestSyncService.Stop()
+	if cfg.StatsSync.Interval > 0 {
+		statsSyncService.Stop()
+	}
+	reminderDispatchService.Stop()
+	if err := wsBroker.Close(); err != nil {
+		log.Printf("Error closing WebSocket broker: %v", err)
+	}
+	if err := redis.Close(); err != nil {
+		log.Printf("Error closing Redis connection: %v", err)
+	}
+	if err := database.Close(); err != nil {
+		log.Printf("Error closing database connection: %v", err)
+	}
+	log.Println("Server stopped")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +19,9 @@ type Config struct {
 	OAuth     OAuthConfig
 	Server    ServerConfig
 	RateLimit RateLimitConfig
+	SMTP      SMTPConfig
+	Reminder  ReminderConfig
+	StatsSync StatsSyncConfig
 }
 
 // AppConfig holds application-specific configuration.
@@ -39,6 +43,7 @@ type DatabaseConfig struct {
 
 // RedisConfig holds Redis connection settings.
 type RedisConfig struct {
+	URL      string // Full redis:// URL; takes precedence over the fields below
 	Host     string
 	Port     string
 	Password string
@@ -84,6 +89,27 @@ type RateLimitConfig struct {
 	Window            time.Duration // Time window for rate limiting
 }
 
+// SMTPConfig holds outgoing email settings.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// ReminderConfig holds contest reminder dispatch settings.
+type ReminderConfig struct {
+	CheckInterval time.Duration // How often due reminders are looked up
+	WebhookURL    string        // Optional endpoint that receives every reminder as JSON
+}
+
+// StatsSyncConfig holds settings for the background platform stats sync.
+type StatsSyncConfig struct {
+	Interval time.Duration // 0 disables the scheduled sync
+	Workers  int           // Concurrent platform fetches
+}
+
 // LoadConfig function would typically load configurations from environment variables or config files.
 func LoadConfig() (*Config, error) {
 	// Try to load .env file from multiple possible locations
@@ -120,6 +146,28 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid RATE_LIMIT_WINDOW duration: %w", err)
 	}
+	//
+	redisDB, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_DB: %w", err)
+	}
+	//
+	reminderInterval, err := time.ParseDuration(getEnv("REMINDER_CHECK_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REMINDER_CHECK_INTERVAL duration: %w", err)
+	}
+	if reminderInterval <= 0 {
+		return nil, fmt.Errorf("REMINDER_CHECK_INTERVAL must be positive")
+	}
+	//
+	statsSyncInterval, err := time.ParseDuration(getEnv("STATS_SYNC_INTERVAL", "12h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STATS_SYNC_INTERVAL duration: %w", err)
+	}
+	statsSyncWorkers, err := strconv.Atoi(getEnv("STATS_SYNC_WORKERS", "4"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STATS_SYNC_WORKERS: %w", err)
+	}
 	config := &Config{
 		App: AppConfig{
 			Env:  getEnv("APP_ENV", "development"),
@@ -134,11 +182,13 @@ func LoadConfig() (*Config, error) {
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 			TimeZone: getEnv("DB_TIMEZONE", "UTC"),
 		},
+		// Redis is optional: leave REDIS_URL and REDIS_HOST unset to use in-memory fallbacks
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
+			URL:      getEnv("REDIS_URL", ""),
+			Host:     getEnv("REDIS_HOST", ""),
 			Port:     getEnv("REDIS_PORT", "6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       0,
+			DB:       redisDB,
 		},
 		JWT: JWTConfig{
 			Secret:        getEnv("JWT_SECRET", ""),
@@ -165,6 +215,21 @@ func LoadConfig() (*Config, error) {
 			RequestsPerMinute: 100,
 			Window:            rateLimitWindow,
 		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		Reminder: ReminderConfig{
+			CheckInterval: reminderInterval,
+			WebhookURL:    getEnv("REMINDER_WEBHOOK_URL", ""),
+		},
+		StatsSync: StatsSyncConfig{
+			Interval: statsSyncInterval,
+			Workers:  statsSyncWorkers,
+		},
 	}
 	return config, nil
 }
@@ -195,6 +260,11 @@ func (c *Config) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%s", c.Redis.Host, c.Redis.Port)
 }
 
+// RedisEnabled reports whether a Redis connection has been configured.
+func (c *Config) RedisEnabled() bool {
+	return c.Redis.URL != "" || c.Redis.Host != ""
+}
+
 // getEnv retrieves environment variables or returns a default value.
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -9,6 +9,7 @@ type RegisterRequest struct {
 	CodeforcesUsername string `json:"codeforces_username"`
 	CodechefUsername   string `json:"codechef_username"`
 	GFGUsername        string `json:"gfg_username"`
+	AtcoderUsername    string `json:"atcoder_username"`
 }
 
 // LoginRequest represents the user login request payload
@@ -6,28 +6,42 @@ import (
 	"github.com/google/uuid"
 )
 
+// Contest phases computed from the start time and duration
+const (
+	ContestPhaseUpcoming = "upcoming"
+	ContestPhaseOngoing  = "ongoing"
+	ContestPhaseFinished = "finished"
+)
+
 // ContestResponse represents the contest data returned in API responses
 type ContestResponse struct {
-	ID              uuid.UUID `json:"id"`
-	Platform        string    `json:"platform"`
-	Name            string    `json:"name"`
-	StartTime       time.Time `json:"start_time"`
-	DurationSeconds int       `json:"duration_seconds"`
-	ContestURL      string    `json:"contest_url"`
-	Description     string    `json:"description"`
-	TimeUntilStart  string    `json:"time_until_start"` // in seconds
-	HasReminder     bool      `json:"has_reminder"`
+	ID                uuid.UUID  `json:"id"`
+	Platform          string     `json:"platform"`
+	Name              string     `json:"name"`
+	StartTime         time.Time  `json:"start_time"`
+	EndTime           time.Time  `json:"end_time"`
+	DurationSeconds   int        `json:"duration_seconds"`
+	ContestURL        string     `json:"contest_url"`
+	Description       string     `json:"description"`
+	IsPrivate         bool       `json:"is_private"`          // Private Dojo contest, platform_contest_id is its private contest ID
+	Phase             string     `json:"phase"`               // upcoming, ongoing or finished
+	SecondsUntilStart int64      `json:"seconds_until_start"` // 0 once the contest started
+	TimeUntilStart    string     `json:"time_until_start"`    // e.g. "2d 3h 15m", empty once the contest started
+	HasReminder       bool       `json:"has_reminder"`        // only set for authenticated callers
+	ReminderID        *uuid.UUID `json:"reminder_id,omitempty"`
 }
 
 // ContestFilterRequest represents the request payload for filtering contests
 type ContestFilterRequest struct {
-	Platform  string     `json:"platform" validate:"omitempty,oneof=leetcode codeforces codechef gfg"`
-	StartDate *time.Time `json:"start_date"`
-	EndDate   *time.Time `json:"end_date"`
-	Upcoming  bool       `json:"upcoming"` // if true, fetch only upcoming contests
-	Ongoing   bool       `json:"ongoing"`  // if true, fetch only ongoing contests
-	Page      int        `json:"page"`
-	Limit     int        `json:"limit"`
+	Platforms   []string   `json:"platforms" validate:"omitempty,dive,platform|eq=dojo"`
+	StartDate   *time.Time `json:"start_date"`                              // contests starting at or after
+	EndDate     *time.Time `json:"end_date"`                                // contests starting at or before
+	MinDuration int        `json:"min_duration" validate:"omitempty,min=0"` // in minutes
+	MaxDuration int        `json:"max_duration" validate:"omitempty,min=0"` // in minutes
+	Upcoming    bool       `json:"upcoming"`                                // if true, fetch only upcoming contests
+	Ongoing     bool       `json:"ongoing"`                                 // if true, fetch only ongoing contests
+	Page        int        `json:"page"`
+	Limit       int        `json:"limit"`
 }
 
 // CreateReminderRequest represents create contest reminder request
@@ -44,3 +58,24 @@ type ReminderResponse struct {
 	IsNotified          bool            `json:"is_notified"`
 	CreatedAt           time.Time       `json:"created_at"`
 }
+
+// ContestantResponse identifies the user a contest result belongs to
+type ContestantResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	AvatarURL string    `json:"avatar_url"`
+}
+
+// ParticipationResponse represents a user's result in a contest
+type ParticipationResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	Platform       string              `json:"platform"`
+	Contest        *ContestResponse    `json:"contest,omitempty"` // set when listing a user's results
+	User           *ContestantResponse `json:"user,omitempty"`    // set when listing a contest's results
+	Rank           int                 `json:"rank"`
+	OldRating      int                 `json:"old_rating"`
+	NewRating      int                 `json:"new_rating"`
+	RatingChange   int                 `json:"rating_change"`
+	ProblemsSolved int                 `json:"problems_solved"`
+	TotalProblems  int                 `json:"total_problems"` // 0 if unknown
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreatePrivateContestRequest represents the request payload for creating a private contest
+type CreatePrivateContestRequest struct {
+	Name            string      `json:"name" validate:"required,min=3,max=255"`
+	Description     string      `json:"description" validate:"omitempty,max=2000"`
+	ProblemIDs      []uuid.UUID `json:"problem_ids" validate:"required,min=1,max=26"`
+	Points          []int       `json:"points" validate:"omitempty,dive,min=1,max=1000"` // IOI full score per problem, in order (default 100)
+	StartTime       time.Time   `json:"start_time" validate:"required"`
+	DurationMinutes int         `json:"duration_minutes" validate:"required,min=10,max=10080"`
+	FreezeMinutes   int         `json:"freeze_minutes" validate:"omitempty,min=0"` // Must be shorter than the contest
+	ScoringMode     string      `json:"scoring_mode" validate:"required,oneof=icpc ioi"`
+}
+
+// JoinPrivateContestRequest represents the request payload for joining a private contest
+type JoinPrivateContestRequest struct {
+	InviteCode string `json:"invite_code" validate:"required"`
+}
+
+// PrivateSubmissionRequest represents a verdict reported for a problem in a private contest
+type PrivateSubmissionRequest struct {
+	ProblemID uuid.UUID `json:"problem_id" validate:"required"`
+	Accepted  bool      `json:"accepted"`                         // ICPC mode
+	Score     int       `json:"score" validate:"omitempty,min=0"` // IOI mode, out of the problem's points
+}
+
+// PrivateContestResponse represents the private contest data returned in API responses
+type PrivateContestResponse struct {
+	ID               uuid.UUID                       `json:"id"`
+	ContestID        uuid.UUID                       `json:"contest_id"` // ID in /api/contests
+	Name             string                          `json:"name"`
+	Description      string                          `json:"description"`
+	CreatedBy        uuid.UUID                       `json:"created_by"`
+	IsOrganizer      bool                            `json:"is_organizer"`
+	InviteCode       string                          `json:"invite_code,omitempty"` // Only shown to the organizer
+	ScoringMode      string                          `json:"scoring_mode"`          // icpc or ioi
+	StartTime        time.Time                       `json:"start_time"`
+	EndTime          time.Time                       `json:"end_time"`
+	DurationSeconds  int                             `json:"duration_seconds"`
+	FreezeMinutes    int                             `json:"freeze_minutes"`
+	UnfrozenAt       *time.Time                      `json:"unfrozen_at"`
+	Phase            string                          `json:"phase"`              // upcoming, ongoing or finished
+	Problems         []PrivateContestProblemResponse `json:"problems,omitempty"` // Hidden from participants until the start
+	ParticipantCount int                             `json:"participant_count"`
+	CreatedAt        time.Time                       `json:"created_at"`
+}
+
+// PrivateContestProblemResponse represents a problem in a private contest
+type PrivateContestProblemResponse struct {
+	Label      string    `json:"label"` // A, B, C, ...
+	ProblemID  uuid.UUID `json:"problem_id"`
+	Platform   string    `json:"platform"`
+	Title      string    `json:"title"`
+	Difficulty string    `json:"difficulty"`
+	ProblemURL string    `json:"problem_url"`
+	Points     int       `json:"points"`
+}
+
+// PrivateSubmissionResponse represents a recorded submission
+type PrivateSubmissionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ProblemID   uuid.UUID `json:"problem_id"`
+	Label       string    `json:"label"`
+	Accepted    bool      `json:"accepted"`
+	Score       int       `json:"score"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// StandingsResponse represents the scoreboard of a private contest
+type StandingsResponse struct {
+	PrivateContestID uuid.UUID      `json:"private_contest_id"`
+	ScoringMode      string         `json:"scoring_mode"`
+	Phase            string         `json:"phase"`
+	Frozen           bool           `json:"frozen"` // Other participants' results after the freeze are pending
+	Rows             []StandingsRow `json:"rows"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// StandingsRow is one participant's standing. Penalty is in minutes: ICPC penalty time, or the
+// time of the last score improvement in IOI mode.
+type StandingsRow struct {
+	Rank     int                `json:"rank"`
+	User     ContestantResponse `json:"user"`
+	Solved   int                `json:"solved"`
+	Score    int                `json:"score"` // IOI mode
+	Penalty  int                `json:"penalty"`
+	Problems []StandingsCell    `json:"problems"`
+}
+
+// StandingsCell is a participant's result on one problem
+type StandingsCell struct {
+	Label           string    `json:"label"`
+	ProblemID       uuid.UUID `json:"problem_id"`
+	Solved          bool      `json:"solved"`
+	Score           int       `json:"score"`             // IOI mode, best score
+	Attempts        int       `json:"attempts"`          // Rejected attempts before the solve (ICPC)
+	PendingAttempts int       `json:"pending_attempts"`  // Attempts hidden by the freeze
+	SolvedAtMinutes int       `json:"solved_at_minutes"` // ICPC solve or IOI best score time, 0 if none
+}
@@ -15,6 +15,7 @@ type ProblemResponse struct {
 	Title             string          `json:"title"`
 	Slug              string          `json:"slug"`
 	Difficulty        string          `json:"difficulty"`
+	Rating            int             `json:"rating"`
 	Tags              []string        `json:"tags"`
 	AcceptanceRate    float64         `json:"acceptance_rate"`
 	ProblemURL        string          `json:"problem_url"`
@@ -26,40 +27,102 @@ type ProblemResponse struct {
 	CreatedAt         time.Time       `json:"created_at"`
 }
 type ProblemFilterRequest struct {
-	Platform   string   `query:"platform" validate:"omitempty,oneof=leetcode codeforces codechef gfg"`
+	Platform   string   `query:"platform" validate:"omitempty,platform"`
 	Difficulty string   `query:"difficulty" validate:"omitempty,oneof=easy medium hard"`
 	Tags       []string `query:"tags"`
-	Search     string   `query:"search"`
+	Search     string   `query:"search"`                                                                      // Full-text, prefix and typo tolerant
+	Sort       string   `query:"sort" validate:"omitempty,oneof=relevance difficulty acceptance_rate newest"` // Default: relevance when searching, else newest
 	Page       int      `query:"page" validate:"omitempty,min=1"`
 	Limit      int      `query:"limit" validate:"omitempty,min=1,max=100"`
 }
 
 // FetchproblemsRequest represents the request payload for fetching problems from external platforms
 type FetchProblemsRequest struct {
-	Platform string `json:"platform" validate:"required,oneof=leetcode codeforces codechef gfg"`
+	Platform string `json:"platform" validate:"required,platform"`
 	Limit    int    `json:"limit" validate:"omitempty,min=1,max=100"`
 }
+
+// CreateSubmissionRequest represents one attempt at a problem
+type CreateSubmissionRequest struct {
+	Verdict          string     `json:"verdict" validate:"required,oneof=AC WA TLE MLE RE CE OTHER"`
+	Language         string     `json:"language" validate:"omitempty,max=50"`
+	TimeSpentSeconds int        `json:"time_spent_seconds" validate:"omitempty,min=0,max=604800"`
+	Code             string     `json:"code" validate:"omitempty,max=65536"`
+	SubmittedAt      *time.Time `json:"submitted_at"` // Defaults to now
+}
+
+// SubmissionFilterRequest represents the pagination for a problem's submission history
+type SubmissionFilterRequest struct {
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// SubmissionResponse represents a submission returned in API responses
+type SubmissionResponse struct {
+	ID               uuid.UUID `json:"id"`
+	ProblemID        uuid.UUID `json:"problem_id"`
+	Verdict          string    `json:"verdict"`
+	Language         string    `json:"language"`
+	TimeSpentSeconds int       `json:"time_spent_seconds"`
+	Code             string    `json:"code,omitempty"`
+	SubmittedAt      time.Time `json:"submitted_at"`
+}
+
+// ProgressResponse represents a user's progress on a problem, summarized from their submissions
+type ProgressResponse struct {
+	ProblemID        uuid.UUID  `json:"problem_id"`
+	IsSolved         bool       `json:"is_solved"`
+	SolvedAt         *time.Time `json:"solved_at"`
+	Attempts         int        `json:"attempts"`
+	LastAttempt      *time.Time `json:"last_attempt"`
+	LastVerdict      string     `json:"last_verdict"`
+	TimeSpentSeconds int        `json:"time_spent_seconds"`
+}
+
+// CreateNoteRequest represents the request payload for adding a note to a problem
 type CreateNoteRequest struct {
-	ProblemID  uuid.UUID `json:"problem_id" validate:"required,uuid"`
-	Content    string    `json:"content" validate:"required"`
-	IsFavorite bool      `json:"is_favorite"`
+	Content    string `json:"content" validate:"required,max=20000"` // Markdown
+	IsFavorite bool   `json:"is_favorite"`
+}
+
+// UpdateNoteRequest represents the request payload for updating a note
+type UpdateNoteRequest struct {
+	Content    string `json:"content" validate:"omitempty,max=20000"`
+	IsFavorite *bool  `json:"is_favorite"`
+}
+
+// NoteFilterRequest represents the filters for listing a user's notes
+type NoteFilterRequest struct {
+	Search    string `query:"search"`    // Full-text search over note content
+	Favorites bool   `query:"favorites"` // Only favorite notes
+	Page      int    `query:"page" validate:"omitempty,min=1"`
+	Limit     int    `query:"limit" validate:"omitempty,min=1,max=100"`
 }
 
 // NoteResponse represents the note data returned in API responses
 type NoteResponse struct {
-	ID         uuid.UUID       `json:"id"`
-	Problem    ProblemResponse `json:"problem"`
-	Content    string          `json:"content"`
-	IsFavorite bool            `json:"is_favorite"`
-	CreatedAt  time.Time       `json:"created_at"`
-	UpdatedAt  time.Time       `json:"updated_at"`
+	ID          uuid.UUID       `json:"id"`
+	ProblemID   uuid.UUID       `json:"problem_id"`
+	Problem     ProblemResponse `json:"problem"`
+	Content     string          `json:"content"`      // Markdown source, for editing
+	ContentHTML string          `json:"content_html"` // Rendered and sanitized, for display
+	IsFavorite  bool            `json:"is_favorite"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
 // CreateSheetRequest represents the request payload for creating a problem sheet
 type CreateSheetRequest struct {
-	Name        string `json:"name" validate:"required,min=3,max=255"`
-	Description string `json:"description"`
-	IsPublic    bool   `json:"is_public"`
+	Name        string              `json:"name" validate:"required,min=3,max=255"`
+	Description string              `json:"description"`
+	IsPublic    bool                `json:"is_public"`
+	Problems    []SheetProblemInput `json:"problems,omitempty" validate:"omitempty,max=100,dive"` // Optional initial problems, in order
+}
+
+// SheetProblemInput represents a problem added to a sheet on creation
+type SheetProblemInput struct {
+	ProblemID uuid.UUID `json:"problem_id" validate:"required"`
+	Notes     string    `json:"notes" validate:"omitempty,max=2000"`
 }
 
 // UpdateSheetRequest represents the request payload for updating a problem sheet
@@ -104,7 +167,7 @@ type UpdateSheetProblemRequest struct {
 
 // CreateProblemRequest represents the request to create a problem
 type CreateProblemRequest struct {
-	Platform          string          `json:"platform" validate:"required,oneof=leetcode codeforces codechef gfg"`
+	Platform          string          `json:"platform" validate:"required,platform"`
 	PlatformProblemID string          `json:"platform_problem_id" validate:"required"`
 	Title             string          `json:"title" validate:"required,min=3,max=255"`
 	Slug              string          `json:"slug" validate:"required"`
@@ -0,0 +1,34 @@
+package dto
+
+// RecommendationRequest represents the query parameters for problem recommendations
+type RecommendationRequest struct {
+	Platform string `query:"platform" validate:"omitempty,platform"`
+	Limit    int    `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// RecommendationResponse represents a set of recommended problems
+type RecommendationResponse struct {
+	UserRating   int                          `json:"user_rating"`
+	RatingSource string                       `json:"rating_source"` // codeforces, solved_problems or default
+	TargetRating int                          `json:"target_rating"`
+	MinRating    int                          `json:"min_rating"`
+	MaxRating    int                          `json:"max_rating"`
+	WeakTags     []TagStatResponse            `json:"weak_tags"`
+	Problems     []RecommendedProblemResponse `json:"problems"`
+	Sheet        CreateSheetRequest           `json:"sheet"` // POST to /api/sheets to save
+}
+
+// TagStatResponse represents a user's progress on a tag
+type TagStatResponse struct {
+	Tag       string  `json:"tag"`
+	Attempted int     `json:"attempted"`
+	Solved    int     `json:"solved"`
+	SolveRate float64 `json:"solve_rate"` // 0 if never attempted
+}
+
+// RecommendedProblemResponse represents a recommended problem and why it was picked
+type RecommendedProblemResponse struct {
+	Problem ProblemResponse `json:"problem"`
+	Tag     string          `json:"tag"`    // Weak tag it was picked for
+	Reason  string          `json:"reason"` // e.g. "You've solved only 2 graphs problems"
+}
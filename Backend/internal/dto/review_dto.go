@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewRequest represents the recall rating given after re-solving a problem
+type ReviewRequest struct {
+	// SM-2 quality: 5 perfect recall, 4 after some thought, 3 with serious difficulty,
+	// 2 or less failed to re-solve
+	Quality *int `json:"quality" validate:"required,min=0,max=5"`
+}
+
+// DueReviewsRequest represents the query parameters for the review queue
+type DueReviewsRequest struct {
+	TZ    string `query:"tz"` // IANA time zone that decides when "today" ends, default UTC
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ReviewResponse represents a scheduled problem review
+type ReviewResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	Problem        ProblemResponse `json:"problem"`
+	EaseFactor     float64         `json:"ease_factor"`
+	IntervalDays   int             `json:"interval_days"`
+	Repetitions    int             `json:"repetitions"`
+	ReviewCount    int             `json:"review_count"`
+	LastQuality    *int            `json:"last_quality"`
+	LastReviewedAt *time.Time      `json:"last_reviewed_at"`
+	DueAt          time.Time       `json:"due_at"`
+	OverdueDays    int             `json:"overdue_days"`
+}
+
+// DueReviewsResponse represents the problems to re-solve today
+type DueReviewsResponse struct {
+	Reviews   []ReviewResponse `json:"reviews"`
+	Total     int64            `json:"total"`     // Due today, may exceed the returned reviews
+	Scheduled int64            `json:"scheduled"` // Due on later days
+	Until     time.Time        `json:"until"`     // End of today in the requested time zone
+}
@@ -26,6 +26,7 @@ type ProfileResponse struct {
 	CodeforcesUsername string                 `json:"codeforces_username"`
 	CodechefUsername   string                 `json:"codechef_username"`
 	GFGUsername        string                 `json:"gfg_username"`
+	AtcoderUsername    string                 `json:"atcoder_username"`
 	TotalSolved        int                    `json:"total_solved"`
 	EasySolved         int                    `json:"easy_solved"`
 	MediumSolved       int                    `json:"medium_solved"`
@@ -47,6 +48,59 @@ type PlatformStatResponse struct {
 	LastSyncedAt     *time.Time `json:"last_synced_at"`
 }
 
+// StatHistoryRequest represents the filters for a user's stat history
+type StatHistoryRequest struct {
+	Platform string     `json:"platform"`
+	From     *time.Time `json:"from"`
+	To       *time.Time `json:"to"`
+	Interval string     `json:"interval" validate:"oneof=daily weekly monthly"`
+}
+
+// StatHistoryPointResponse represents a user's stats on a platform at one point in time
+type StatHistoryPointResponse struct {
+	Date             time.Time `json:"date"`
+	Rating           int       `json:"rating"`
+	MaxRating        int       `json:"max_rating"`
+	SolvedCount      int       `json:"solved_count"`
+	EasySolved       int       `json:"easy_solved"`
+	MediumSolved     int       `json:"medium_solved"`
+	HardSolved       int       `json:"hard_solved"`
+	ContestsAttended int       `json:"contests_attended"`
+	GlobalRank       int       `json:"global_rank"`
+}
+
+// StatHistorySeriesResponse represents the stat history of one platform
+type StatHistorySeriesResponse struct {
+	Platform string                     `json:"platform"`
+	Points   []StatHistoryPointResponse `json:"points"`
+}
+
+// PlatformSyncStatusResponse summarizes the sync state of one linked platform
+type PlatformSyncStatusResponse struct {
+	Platform      string     `json:"platform"`
+	Username      string     `json:"username"`
+	LastSyncedAt  *time.Time `json:"last_synced_at"`
+	LastStatus    string     `json:"last_status,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+}
+
+// PlatformSyncLogResponse represents one recorded sync attempt
+type PlatformSyncLogResponse struct {
+	Platform   string    `json:"platform"`
+	Trigger    string    `json:"trigger"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SyncStatusResponse represents the sync status and recent history of a user's platforms
+type SyncStatusResponse struct {
+	Platforms []PlatformSyncStatusResponse `json:"platforms"`
+	History   []PlatformSyncLogResponse    `json:"history"`
+}
+
 // UpdateProfileRequest represents the request payload for updating user profile
 type UpdateProfileRequest struct {
 	Bio                string `json:"bio"`
@@ -56,6 +110,7 @@ type UpdateProfileRequest struct {
 	CodeforcesUsername string `json:"codeforces_username"`
 	CodechefUsername   string `json:"codechef_username"`
 	GFGUsername        string `json:"gfg_username"`
+	AtcoderUsername    string `json:"atcoder_username"`
 }
 
 // UpdateUserRequest represents the request payload for updating user details
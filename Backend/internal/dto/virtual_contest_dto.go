@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Virtual contest statuses computed from the start time and duration
+const (
+	VirtualContestPending  = "pending"
+	VirtualContestRunning  = "running"
+	VirtualContestFinished = "finished"
+)
+
+// CreateVirtualContestRequest represents the request payload for creating a virtual contest.
+// Either ProblemIDs or SourceContestID must be set.
+type CreateVirtualContestRequest struct {
+	Name            string      `json:"name" validate:"required,min=3,max=255"`
+	ProblemIDs      []uuid.UUID `json:"problem_ids" validate:"omitempty,max=26"`
+	SourceContestID *uuid.UUID  `json:"source_contest_id"` // Past Codeforces or AtCoder contest to re-run
+	DurationMinutes int         `json:"duration_minutes" validate:"required,min=10,max=1440"`
+	InviteUserIDs   []uuid.UUID `json:"invite_user_ids" validate:"omitempty,max=9"` // Must be friends of the creator
+}
+
+// VirtualContestResponse represents the virtual contest data returned in API responses
+type VirtualContestResponse struct {
+	ID               uuid.UUID                           `json:"id"`
+	Name             string                              `json:"name"`
+	CreatedBy        uuid.UUID                           `json:"created_by"`
+	SourceContestID  *uuid.UUID                          `json:"source_contest_id,omitempty"`
+	RoomID           uuid.UUID                           `json:"room_id"` // Connect to /api/rooms/:room_id/ws for live updates
+	DurationMinutes  int                                 `json:"duration_minutes"`
+	Status           string                              `json:"status"` // pending, running or finished
+	StartedAt        *time.Time                          `json:"started_at"`
+	EndsAt           *time.Time                          `json:"ends_at"`
+	SecondsRemaining int64                               `json:"seconds_remaining"`  // 0 unless running
+	Problems         []VirtualContestProblemResponse     `json:"problems,omitempty"` // Hidden until the contest starts
+	Participants     []VirtualContestParticipantResponse `json:"participants"`
+	CreatedAt        time.Time                           `json:"created_at"`
+}
+
+// VirtualContestProblemResponse represents a problem in a virtual contest
+type VirtualContestProblemResponse struct {
+	Label      string    `json:"label"` // A, B, C, ...
+	ProblemID  uuid.UUID `json:"problem_id"`
+	Platform   string    `json:"platform"`
+	Title      string    `json:"title"`
+	Difficulty string    `json:"difficulty"`
+	Rating     int       `json:"rating"`
+	ProblemURL string    `json:"problem_url"`
+}
+
+// VirtualContestParticipantResponse represents a user invited to a virtual contest
+type VirtualContestParticipantResponse struct {
+	User     ContestantResponse `json:"user"`
+	Status   string             `json:"status"` // invited, joined
+	JoinedAt *time.Time         `json:"joined_at"`
+}
+
+// LeaderboardResponse represents the standings of a virtual contest
+type LeaderboardResponse struct {
+	VirtualContestID uuid.UUID        `json:"virtual_contest_id"`
+	Status           string           `json:"status"`
+	Rows             []LeaderboardRow `json:"rows"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+// LeaderboardRow is one participant's standing. Penalty is in minutes.
+type LeaderboardRow struct {
+	Rank     int                        `json:"rank"`
+	User     ContestantResponse         `json:"user"`
+	Solved   int                        `json:"solved"`
+	Penalty  int                        `json:"penalty"`
+	Problems []LeaderboardProblemResult `json:"problems"`
+}
+
+// LeaderboardProblemResult is a participant's result on one problem
+type LeaderboardProblemResult struct {
+	Label           string    `json:"label"`
+	ProblemID       uuid.UUID `json:"problem_id"`
+	Solved          bool      `json:"solved"`
+	WrongAttempts   int       `json:"wrong_attempts"`
+	SolvedAtMinutes int       `json:"solved_at_minutes"` // Minutes after the start, 0 if unsolved
+}
@@ -2,8 +2,13 @@ package handler
 
 import (
 	"dojo/internal/dto"
+	"dojo/internal/middleware"
 	"dojo/internal/service"
+	"dojo/internal/service/scrapper"
 	"dojo/internal/utils"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -19,18 +24,57 @@ func NewContestHandler(contestService *service.ContestService) *ContestHandler {
 	}
 }
 
-// ListContests handles GET /api/contests
+// ListContests handles GET /api/contests?platform=codeforces,atcoder&from=&to=&min_duration=&max_duration=
 func (h *ContestHandler) ListContests(c *fiber.Ctx) error {
 	var filters dto.ContestFilterRequest
 
 	// Parse query parameters
-	filters.Platform = c.Query("platform")
+	for _, platform := range strings.Split(c.Query("platform"), ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			filters.Platforms = append(filters.Platforms, platform)
+		}
+	}
 	filters.Upcoming = c.QueryBool("upcoming")
 	filters.Ongoing = c.QueryBool("ongoing")
+	filters.MinDuration = c.QueryInt("min_duration")
+	filters.MaxDuration = c.QueryInt("max_duration")
 	filters.Page = c.QueryInt("page", 1)
 	filters.Limit = c.QueryInt("limit", 20)
 
-	contests, total, err := h.contestService.ListContests(&filters)
+	if from := c.Query("from"); from != "" {
+		t, err := parseDateParam(from)
+		if err != nil {
+			return utils.SendBadRequest(c, "Invalid from date (use YYYY-MM-DD or RFC3339)", err)
+		}
+		filters.StartDate = &t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := parseDateParam(to)
+		if err != nil {
+			return utils.SendBadRequest(c, "Invalid to date (use YYYY-MM-DD or RFC3339)", err)
+		}
+		// A bare date includes the whole day
+		if len(to) == len("2006-01-02") {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		filters.EndDate = &t
+	}
+
+	if err := utils.ValidateStruct(&filters); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+	if filters.MaxDuration > 0 && filters.MinDuration > filters.MaxDuration {
+		return utils.SendBadRequest(c, "min_duration must not exceed max_duration", nil)
+	}
+
+	// Reminders and private contests are only included for signed-in callers
+	userID, _ := middleware.GetUserID(c)
+	var userIDStr string
+	if userID != uuid.Nil {
+		userIDStr = userID.String()
+	}
+
+	contests, total, err := h.contestService.ListContests(&filters, userIDStr)
 	if err != nil {
 		return utils.SendInternalError(c, "Failed to fetch contests", err)
 	}
@@ -47,7 +91,13 @@ func (h *ContestHandler) ListContests(c *fiber.Ctx) error {
 func (h *ContestHandler) GetContest(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	contest, err := h.contestService.GetContestByID(id)
+	userID, _ := middleware.GetUserID(c)
+	var userIDStr string
+	if userID != uuid.Nil {
+		userIDStr = userID.String()
+	}
+
+	contest, err := h.contestService.GetContestByID(id, userIDStr)
 	if err != nil {
 		if err == utils.ErrContestNotFound {
 			return utils.SendError(c, fiber.StatusNotFound, "Contest not found", err)
@@ -60,6 +110,110 @@ func (h *ContestHandler) GetContest(c *fiber.Ctx) error {
 	})
 }
 
+// GetContestCalendar handles GET /api/contests/calendar.ics?platform=
+func (h *ContestHandler) GetContestCalendar(c *fiber.Ctx) error {
+	platform := c.Query("platform")
+	if _, ok := scrapper.Get(platform); !ok && platform != "" {
+		return utils.SendBadRequest(c, "Invalid platform. Must be one of: "+strings.Join(scrapper.Names(), ", "), nil)
+	}
+
+	calendar, err := h.contestService.GetContestCalendar(platform)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to build calendar", err)
+	}
+
+	return sendCalendar(c, "contests.ics", calendar)
+}
+
+// GetReminderCalendar handles GET /api/contests/calendar/:token.ics
+func (h *ContestHandler) GetReminderCalendar(c *fiber.Ctx) error {
+	token := strings.TrimSuffix(c.Params("token"), ".ics")
+
+	calendar, err := h.contestService.GetReminderCalendar(token)
+	if err != nil {
+		if err == utils.ErrInvalidToken {
+			return utils.SendNotFound(c, "Calendar not found")
+		}
+		return utils.SendInternalError(c, "Failed to build calendar", err)
+	}
+
+	return sendCalendar(c, "reminders.ics", calendar)
+}
+
+// ResetCalendarToken handles POST /api/contests/calendar/token
+func (h *ContestHandler) ResetCalendarToken(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	token, err := h.contestService.ResetCalendarToken(userID)
+	if err != nil {
+		if err == utils.ErrUserNotFound {
+			return utils.SendError(c, fiber.StatusNotFound, "User not found", err)
+		}
+		return utils.SendInternalError(c, "Failed to create calendar token", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Calendar feed created successfully", fiber.Map{
+		"token":        token,
+		"calendar_url": c.BaseURL() + "/api/contests/calendar/" + token + ".ics",
+	})
+}
+
+// sendCalendar writes an iCalendar body that calendar apps can subscribe to
+func sendCalendar(c *fiber.Ctx, filename, calendar string) error {
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="`+filename+`"`)
+	return c.SendString(calendar)
+}
+
+// GetContestParticipants handles GET /api/contests/:id/participants?friends=true
+func (h *ContestHandler) GetContestParticipants(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	participants, err := h.contestService.GetContestParticipants(c.Params("id"), userID, c.QueryBool("friends"))
+	if err != nil {
+		if err == utils.ErrContestNotFound {
+			return utils.SendError(c, fiber.StatusNotFound, "Contest not found", err)
+		}
+		return utils.SendInternalError(c, "Failed to fetch participants", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Participants fetched successfully", fiber.Map{
+		"participants": participants,
+		"total":        len(participants),
+	})
+}
+
+// ListParticipations handles GET /api/users/contests?platform=
+func (h *ContestHandler) ListParticipations(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	platform := c.Query("platform")
+	if _, ok := scrapper.Get(platform); !ok && platform != "" {
+		return utils.SendBadRequest(c, "Invalid platform: "+platform, nil)
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	participations, total, err := h.contestService.GetUserParticipations(userID, platform, page, limit)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch contest history", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Contest history fetched successfully", fiber.Map{
+		"participations": participations,
+		"total":          total,
+		"page":           page,
+		"limit":          limit,
+	})
+}
+
 // CreateReminder handles POST /api/contests/reminders
 func (h *ContestHandler) CreateReminder(c *fiber.Ctx) error {
 	var req dto.CreateReminderRequest
@@ -87,6 +241,21 @@ func (h *ContestHandler) CreateReminder(c *fiber.Ctx) error {
 	})
 }
 
+// ListReminders handles GET /api/contests/reminders
+func (h *ContestHandler) ListReminders(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	reminders, err := h.contestService.GetUserReminders(userID)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch reminders", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Reminders fetched successfully", fiber.Map{
+		"reminders": reminders,
+		"total":     len(reminders),
+	})
+}
+
 // DeleteReminder handles DELETE /api/contests/reminders/:id
 func (h *ContestHandler) DeleteReminder(c *fiber.Ctx) error {
 	reminderID := c.Params("id")
@@ -108,16 +277,19 @@ func (h *ContestHandler) DeleteReminder(c *fiber.Ctx) error {
 	return utils.SendSuccess(c, fiber.StatusOK, "Reminder deleted successfully", nil)
 }
 
-// SyncContests handles POST /api/contests/sync?platform=codeforces|leetcode|all
+// SyncContests handles POST /api/contests/sync?platform=codeforces|leetcode|codechef|gfg|atcoder|all
 func (h *ContestHandler) SyncContests(c *fiber.Ctx) error {
 	platform := c.Query("platform", "all")
 
-	if platform != "codeforces" && platform != "leetcode" && platform != "all" {
-		return utils.SendBadRequest(c, "Invalid platform. Must be: codeforces, leetcode, or all", nil)
+	if _, ok := scrapper.Get(platform); !ok && platform != "all" {
+		return utils.SendBadRequest(c, "Invalid platform. Must be one of: "+strings.Join(scrapper.Names(), ", ")+", or all", nil)
 	}
 
 	count, err := h.contestService.SyncContestsFromPlatform(platform)
 	if err != nil {
+		if errors.Is(err, scrapper.ErrNotSupported) {
+			return utils.SendBadRequest(c, "Contest sync is not supported for "+platform, err)
+		}
 		return utils.SendInternalError(c, "Failed to sync contests", err)
 	}
 
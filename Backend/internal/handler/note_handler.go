@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/service"
+	"dojo/internal/utils"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type NoteHandler struct {
+	noteService *service.NoteService
+}
+
+func NewNoteHandler(noteService *service.NoteService) *NoteHandler {
+	return &NoteHandler{
+		noteService: noteService,
+	}
+}
+
+// CreateNote handles POST /api/problems/:id/notes
+func (h *NoteHandler) CreateNote(c *fiber.Ctx) error {
+	var req dto.CreateNoteRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	note, err := h.noteService.CreateNote(userID, c.Params("id"), &req)
+	if err != nil {
+		return h.sendError(c, "Failed to create note", err)
+	}
+
+	return utils.SendCreated(c, "Note created successfully", fiber.Map{
+		"note": note,
+	})
+}
+
+// GetProblemNotes handles GET /api/problems/:id/notes
+func (h *NoteHandler) GetProblemNotes(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	notes, err := h.noteService.GetProblemNotes(userID, c.Params("id"))
+	if err != nil {
+		return h.sendError(c, "Failed to fetch notes", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Notes fetched successfully", fiber.Map{
+		"notes": notes,
+		"total": len(notes),
+	})
+}
+
+// ListNotes handles GET /api/notes
+func (h *NoteHandler) ListNotes(c *fiber.Ctx) error {
+	var filters dto.NoteFilterRequest
+
+	if err := c.QueryParser(&filters); err != nil {
+		return utils.SendBadRequest(c, "Invalid query parameters", err)
+	}
+
+	if err := utils.ValidateStruct(&filters); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	notes, total, err := h.noteService.ListNotes(userID, &filters)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch notes", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Notes fetched successfully", fiber.Map{
+		"notes": notes,
+		"total": total,
+		"page":  filters.Page,
+		"limit": filters.Limit,
+	})
+}
+
+// UpdateNote handles PUT /api/problems/:id/notes/:noteId
+func (h *NoteHandler) UpdateNote(c *fiber.Ctx) error {
+	var req dto.UpdateNoteRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	note, err := h.noteService.UpdateNote(userID, c.Params("id"), c.Params("noteId"), &req)
+	if err != nil {
+		return h.sendError(c, "Failed to update note", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Note updated successfully", fiber.Map{
+		"note": note,
+	})
+}
+
+// DeleteNote handles DELETE /api/problems/:id/notes/:noteId
+func (h *NoteHandler) DeleteNote(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	if err := h.noteService.DeleteNote(userID, c.Params("id"), c.Params("noteId")); err != nil {
+		return h.sendError(c, "Failed to delete note", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Note deleted successfully", nil)
+}
+
+// sendError maps note service errors to responses
+func (h *NoteHandler) sendError(c *fiber.Ctx, message string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNoteNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Note not found", err)
+	case errors.Is(err, utils.ErrProblemNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+	case errors.Is(err, utils.ErrInvalidInput):
+		return utils.SendBadRequest(c, err.Error(), nil)
+	default:
+		return utils.SendInternalError(c, message, err)
+	}
+}
@@ -1 +1,130 @@
 package handler
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/service"
+	"dojo/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+}
+
+func NewNotificationHandler(notificationService *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications handles GET /api/notifications?page=1&limit=20&unread=true
+func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	unreadOnly := c.QueryBool("unread")
+
+	notifications, total, err := h.notificationService.GetNotifications(userID, unreadOnly, page, limit)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch notifications", err)
+	}
+
+	return utils.SendPaginated(c, notifications, page, limit, total)
+}
+
+// GetUnreadCount handles GET /api/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	count, err := h.notificationService.GetUnreadCount(userID)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to count unread notifications", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Unread count fetched successfully", fiber.Map{
+		"count": count,
+	})
+}
+
+// MarkAsRead handles PATCH /api/notifications/read
+func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
+	var req dto.MarkNotificationReadRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	updated, err := h.notificationService.MarkAsRead(userID, &req)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to mark notifications as read", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Notifications marked as read", fiber.Map{
+		"updated": updated,
+	})
+}
+
+// MarkOneAsRead handles PATCH /api/notifications/:id/read
+func (h *NotificationHandler) MarkOneAsRead(c *fiber.Ctx) error {
+	notificationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.SendBadRequest(c, "Invalid notification ID", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	req := dto.MarkNotificationReadRequest{Notification: []uuid.UUID{notificationID}}
+	updated, err := h.notificationService.MarkAsRead(userID, &req)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to mark notification as read", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Notification marked as read", fiber.Map{
+		"updated": updated,
+	})
+}
+
+// MarkAllAsRead handles PATCH /api/notifications/read-all
+func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	updated, err := h.notificationService.MarkAllAsRead(userID)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to mark notifications as read", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "All notifications marked as read", fiber.Map{
+		"updated": updated,
+	})
+}
+
+// DeleteNotification handles DELETE /api/notifications/:id
+func (h *NotificationHandler) DeleteNotification(c *fiber.Ctx) error {
+	notificationID := c.Params("id")
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	err := h.notificationService.DeleteNotification(userID, notificationID)
+	if err != nil {
+		if err == utils.ErrNotificationNotFound {
+			return utils.SendError(c, fiber.StatusNotFound, "Notification not found", err)
+		}
+		return utils.SendInternalError(c, "Failed to delete notification", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Notification deleted successfully", nil)
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/service"
+	"dojo/internal/utils"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PrivateContestHandler struct {
+	privateContestService *service.PrivateContestService
+}
+
+func NewPrivateContestHandler(privateContestService *service.PrivateContestService) *PrivateContestHandler {
+	return &PrivateContestHandler{
+		privateContestService: privateContestService,
+	}
+}
+
+// CreatePrivateContest handles POST /api/private-contests
+func (h *PrivateContestHandler) CreatePrivateContest(c *fiber.Ctx) error {
+	var req dto.CreatePrivateContestRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.privateContestService.CreatePrivateContest(userID, &req)
+	if err != nil {
+		return h.sendError(c, "Failed to create private contest", err)
+	}
+
+	return utils.SendCreated(c, "Private contest created successfully", fiber.Map{
+		"private_contest": contest,
+	})
+}
+
+// ListPrivateContests handles GET /api/private-contests
+func (h *PrivateContestHandler) ListPrivateContests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contests, err := h.privateContestService.ListPrivateContests(userID)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch private contests", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Private contests fetched successfully", fiber.Map{
+		"private_contests": contests,
+		"total":            len(contests),
+	})
+}
+
+// JoinPrivateContest handles POST /api/private-contests/join
+func (h *PrivateContestHandler) JoinPrivateContest(c *fiber.Ctx) error {
+	var req dto.JoinPrivateContestRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.privateContestService.JoinPrivateContest(userID, &req)
+	if err != nil {
+		return h.sendError(c, "Failed to join private contest", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Joined private contest successfully", fiber.Map{
+		"private_contest": contest,
+	})
+}
+
+// GetPrivateContest handles GET /api/private-contests/:id
+func (h *PrivateContestHandler) GetPrivateContest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.privateContestService.GetPrivateContest(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to fetch private contest", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Private contest fetched successfully", fiber.Map{
+		"private_contest": contest,
+	})
+}
+
+// Submit handles POST /api/private-contests/:id/submissions
+func (h *PrivateContestHandler) Submit(c *fiber.Ctx) error {
+	var req dto.PrivateSubmissionRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	submission, err := h.privateContestService.Submit(c.Params("id"), userID, &req)
+	if err != nil {
+		return h.sendError(c, "Failed to record submission", err)
+	}
+
+	return utils.SendCreated(c, "Submission recorded successfully", fiber.Map{
+		"submission": submission,
+	})
+}
+
+// GetStandings handles GET /api/private-contests/:id/standings
+func (h *PrivateContestHandler) GetStandings(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	standings, err := h.privateContestService.GetStandings(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to fetch standings", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Standings fetched successfully", fiber.Map{
+		"standings": standings,
+	})
+}
+
+// Unfreeze handles POST /api/private-contests/:id/unfreeze
+func (h *PrivateContestHandler) Unfreeze(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	standings, err := h.privateContestService.Unfreeze(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to lift the freeze", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Standings unfrozen successfully", fiber.Map{
+		"standings": standings,
+	})
+}
+
+// sendError maps private contest service errors to responses
+func (h *PrivateContestHandler) sendError(c *fiber.Ctx, message string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrPrivateContestNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Private contest not found", err)
+	case errors.Is(err, utils.ErrProblemNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+	case errors.Is(err, utils.ErrUnauthorized):
+		return utils.SendError(c, fiber.StatusForbidden, "Only the organizer can do this", err)
+	case errors.Is(err, utils.ErrAlreadyJoined):
+		return utils.SendConflict(c, "You already joined this contest")
+	case errors.Is(err, utils.ErrInvalidInput):
+		return utils.SendBadRequest(c, err.Error(), nil)
+	default:
+		return utils.SendInternalError(c, message, err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"dojo/internal/dto"
 	"dojo/internal/middleware"
 	"dojo/internal/service"
+	"dojo/internal/service/scrapper"
 	"dojo/internal/utils"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -34,7 +36,7 @@ func (h *ProblemHandler) CreateProblem(c *fiber.Ctx) error {
 
 	problem, err := h.problemService.CreateProblem(&req)
 	if err != nil {
-		if err.Error() == "problem with this URL already exists" {
+		if err.Error() == "problem with this URL already exists" || err.Error() == "problem with this platform ID already exists" {
 			return utils.SendConflict(c, err.Error())
 		}
 		return utils.SendInternalError(c, "Failed to create problem", err)
@@ -131,7 +133,7 @@ func (h *ProblemHandler) DeleteProblem(c *fiber.Ctx) error {
 // Syncs problems from external platforms
 func (h *ProblemHandler) SyncProblems(c *fiber.Ctx) error {
 	var req struct {
-		Platform string `json:"platform" validate:"required,oneof=leetcode codeforces"`
+		Platform string `json:"platform" validate:"required"`
 		Limit    int    `json:"limit"`
 	}
 
@@ -143,12 +145,19 @@ func (h *ProblemHandler) SyncProblems(c *fiber.Ctx) error {
 		return utils.SendBadRequest(c, "Validation failed", err)
 	}
 
+	if _, ok := scrapper.Get(req.Platform); !ok {
+		return utils.SendBadRequest(c, "Invalid platform: "+req.Platform, nil)
+	}
+
 	if req.Limit <= 0 {
 		req.Limit = 100 // Default limit
 	}
 
 	count, err := h.problemService.SyncProblems(req.Platform, req.Limit)
 	if err != nil {
+		if errors.Is(err, scrapper.ErrNotSupported) {
+			return utils.SendBadRequest(c, "Problem sync is not supported for "+req.Platform, err)
+		}
 		return utils.SendInternalError(c, "Failed to sync problems", err)
 	}
 
@@ -158,8 +167,63 @@ func (h *ProblemHandler) SyncProblems(c *fiber.Ctx) error {
 	})
 }
 
+// FetchProblemContent - POST /api/problems/:id/content
+// Fetches the problem statement from its platform
+func (h *ProblemHandler) FetchProblemContent(c *fiber.Ctx) error {
+	problem, err := h.problemService.FetchProblemContent(c.Params("id"))
+	if err != nil {
+		if errors.Is(err, utils.ErrProblemNotFound) {
+			return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+		}
+		if errors.Is(err, scrapper.ErrNotSupported) {
+			return utils.SendBadRequest(c, "Problem statements can't be fetched for this platform", err)
+		}
+		return utils.SendInternalError(c, "Failed to fetch problem content", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Problem content fetched successfully", problem)
+}
+
+// EnrichProblems - POST /api/problems/content/sync
+// Fetches the statements of problems that don't have one yet
+func (h *ProblemHandler) EnrichProblems(c *fiber.Ctx) error {
+	var req struct {
+		Platform string `json:"platform" validate:"required"`
+		Limit    int    `json:"limit" validate:"omitempty,max=500"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	if _, ok := scrapper.Get(req.Platform); !ok {
+		return utils.SendBadRequest(c, "Invalid platform: "+req.Platform, nil)
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 50 // Default limit
+	}
+
+	count, err := h.problemService.EnrichProblems(req.Platform, req.Limit)
+	if err != nil {
+		if errors.Is(err, scrapper.ErrNotSupported) {
+			return utils.SendBadRequest(c, "Problem statements can't be fetched for "+req.Platform, err)
+		}
+		return utils.SendInternalError(c, "Failed to fetch problem content", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Problem content fetched successfully", fiber.Map{
+		"enriched": count,
+		"platform": req.Platform,
+	})
+}
+
 // MarkProblemSolved - POST /api/problems/:id/solve
-// Marks a problem as solved or unsolved for the authenticated user
+// Marks a problem as solved for the authenticated user; unmarking leaves it unchanged
 func (h *ProblemHandler) MarkProblemSolved(c *fiber.Ctx) error {
 	problemID := c.Params("id")
 
@@ -177,17 +241,87 @@ func (h *ProblemHandler) MarkProblemSolved(c *fiber.Ctx) error {
 	}
 
 	if err := h.problemService.MarkProblemSolved(userID.String(), problemID, req.IsSolved); err != nil {
+		if errors.Is(err, utils.ErrProblemNotFound) {
+			return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+		}
 		return utils.SendInternalError(c, "Failed to update problem status", err)
 	}
 
 	message := "Problem marked as solved"
 	if !req.IsSolved {
-		message = "Problem marked as unsolved"
+		message = "Solved status is derived from submissions and was not changed"
 	}
 
 	return utils.SendSuccess(c, fiber.StatusOK, message, nil)
 }
 
+// LogSubmission - POST /api/problems/:id/submissions
+// Logs an attempt at a problem for the authenticated user
+func (h *ProblemHandler) LogSubmission(c *fiber.Ctx) error {
+	problemID := c.Params("id")
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return utils.SendError(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	var req dto.CreateSubmissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	progress, err := h.problemService.LogSubmission(userID.String(), problemID, &req)
+	if err != nil {
+		if errors.Is(err, utils.ErrProblemNotFound) {
+			return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+		}
+		if errors.Is(err, utils.ErrInvalidInput) {
+			return utils.SendBadRequest(c, err.Error(), nil)
+		}
+		return utils.SendInternalError(c, "Failed to log submission", err)
+	}
+
+	return utils.SendCreated(c, "Submission logged successfully", fiber.Map{
+		"progress": progress,
+	})
+}
+
+// GetSubmissionHistory - GET /api/problems/:id/submissions
+// Lists the authenticated user's submissions on a problem, newest first
+func (h *ProblemHandler) GetSubmissionHistory(c *fiber.Ctx) error {
+	problemID := c.Params("id")
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return utils.SendError(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	var filters dto.SubmissionFilterRequest
+	if err := c.QueryParser(&filters); err != nil {
+		return utils.SendBadRequest(c, "Invalid query parameters", err)
+	}
+
+	submissions, total, progress, err := h.problemService.GetSubmissionHistory(userID.String(), problemID, &filters)
+	if err != nil {
+		if errors.Is(err, utils.ErrProblemNotFound) {
+			return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+		}
+		return utils.SendInternalError(c, "Failed to fetch submissions", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Submissions retrieved successfully", fiber.Map{
+		"submissions": submissions,
+		"progress":    progress,
+		"total":       total,
+		"page":        filters.Page,
+		"limit":       filters.Limit,
+	})
+}
+
 // GetUserSolvedCount - GET /api/problems/solved/count
 // Returns the count of problems solved by the authenticated user
 func (h *ProblemHandler) GetUserSolvedCount(c *fiber.Ctx) error {
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/service"
+	"dojo/internal/utils"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RecommendationHandler struct {
+	recommendationService *service.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationService *service.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+	}
+}
+
+// GetRecommendations handles GET /api/problems/recommendations
+func (h *RecommendationHandler) GetRecommendations(c *fiber.Ctx) error {
+	var req dto.RecommendationRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid query parameters", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	recommendations, err := h.recommendationService.GetRecommendations(userID, &req)
+	if err != nil {
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return utils.SendError(c, fiber.StatusNotFound, "User not found", err)
+		}
+		return utils.SendInternalError(c, "Failed to fetch recommendations", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Recommendations fetched successfully", fiber.Map{
+		"recommendations": recommendations,
+	})
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/service"
+	"dojo/internal/utils"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	reviewService *service.ReviewService
+}
+
+func NewReviewHandler(reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetDueReviews handles GET /api/reviews/due
+func (h *ReviewHandler) GetDueReviews(c *fiber.Ctx) error {
+	var req dto.DueReviewsRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid query parameters", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	queue, err := h.reviewService.GetDueReviews(userID, &req)
+	if err != nil {
+		return h.sendError(c, "Failed to fetch due reviews", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Due reviews fetched successfully", queue)
+}
+
+// ReviewProblem handles POST /api/reviews/:problemId
+func (h *ReviewHandler) ReviewProblem(c *fiber.Ctx) error {
+	var req dto.ReviewRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	review, err := h.reviewService.ReviewProblem(userID, c.Params("problemId"), &req)
+	if err != nil {
+		return h.sendError(c, "Failed to record review", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Review recorded successfully", fiber.Map{
+		"review": review,
+	})
+}
+
+// RemoveReview handles DELETE /api/reviews/:problemId
+func (h *ReviewHandler) RemoveReview(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	if err := h.reviewService.RemoveReview(userID, c.Params("problemId")); err != nil {
+		return h.sendError(c, "Failed to remove review", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Review removed successfully", nil)
+}
+
+// sendError maps review service errors to responses
+func (h *ReviewHandler) sendError(c *fiber.Ctx, message string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrReviewNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Review not found", err)
+	case errors.Is(err, utils.ErrProblemNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+	case errors.Is(err, utils.ErrInvalidInput):
+		return utils.SendBadRequest(c, err.Error(), nil)
+	default:
+		return utils.SendInternalError(c, message, err)
+	}
+}
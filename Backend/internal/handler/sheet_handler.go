@@ -4,6 +4,7 @@ import (
 	"dojo/internal/dto"
 	"dojo/internal/service"
 	"dojo/internal/utils"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -31,6 +32,12 @@ func (h *SheetHandler) CreateSheet(c *fiber.Ctx) error {
 
 	sheet, err := h.sheetService.CreateSheet(userID, &req)
 	if err != nil {
+		if errors.Is(err, utils.ErrProblemNotFound) {
+			return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+		}
+		if errors.Is(err, utils.ErrInvalidInput) {
+			return utils.SendBadRequest(c, err.Error(), nil)
+		}
 		return utils.SendInternalError(c, "Failed to create sheet", err)
 	}
 
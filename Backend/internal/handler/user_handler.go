@@ -4,8 +4,10 @@ import (
 	"dojo/internal/dto"
 	"dojo/internal/middleware"
 	"dojo/internal/service"
+	"dojo/internal/service/scrapper"
 	"dojo/internal/utils"
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -177,15 +179,8 @@ func (h *UserHandler) SyncPlatformStats(c *fiber.Ctx) error {
 	}
 
 	// Validate platforms
-	validPlatforms := map[string]bool{
-		"leetcode":   true,
-		"codeforces": true,
-		"codechef":   true,
-		"gfg":        true,
-	}
-
 	for _, platform := range req.Platforms {
-		if !validPlatforms[platform] {
+		if _, ok := scrapper.Get(platform); !ok {
 			return utils.SendError(c, fiber.StatusBadRequest, "Invalid platform: "+platform, nil)
 		}
 	}
@@ -203,3 +198,86 @@ func (h *UserHandler) SyncPlatformStats(c *fiber.Ctx) error {
 	fmt.Printf("DEBUG HANDLER: Sync results: %+v\n", results)
 	return utils.SendSuccess(c, fiber.StatusOK, "Platform stats sync completed", results)
 }
+
+// GetSyncStatus - GET /api/users/sync-status?platform=codeforces&limit=20
+// Returns the sync state of each linked platform and the recent sync history
+func (h *UserHandler) GetSyncStatus(c *fiber.Ctx) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return utils.SendError(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	platform := c.Query("platform")
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	status, err := h.userService.GetSyncStatus(userID.String(), platform, limit)
+	if err != nil {
+		if err == utils.ErrUserNotFound {
+			return utils.SendError(c, fiber.StatusNotFound, "User not found", err)
+		}
+		return utils.SendInternalError(c, "Failed to fetch sync status", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Sync status fetched successfully", status)
+}
+
+// GetStatHistory - GET /api/users/stats/history?platform=codeforces&from=2025-01-01&to=2025-06-30&interval=weekly
+// Returns rating, max rating and solved count over time for progress charts
+func (h *UserHandler) GetStatHistory(c *fiber.Ctx) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return utils.SendError(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	req := dto.StatHistoryRequest{
+		Platform: c.Query("platform"),
+		Interval: c.Query("interval", "daily"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, err := parseDateParam(from)
+		if err != nil {
+			return utils.SendBadRequest(c, "Invalid from date (use YYYY-MM-DD or RFC3339)", err)
+		}
+		req.From = &t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := parseDateParam(to)
+		if err != nil {
+			return utils.SendBadRequest(c, "Invalid to date (use YYYY-MM-DD or RFC3339)", err)
+		}
+		// A bare date includes the whole day
+		if len(to) == len("2006-01-02") {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		req.To = &t
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+	if _, ok := scrapper.Get(req.Platform); req.Platform != "" && !ok {
+		return utils.SendBadRequest(c, "Invalid platform: "+req.Platform, nil)
+	}
+
+	series, err := h.userService.GetStatHistory(userID.String(), &req)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch stat history", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Stat history fetched successfully", fiber.Map{
+		"interval": req.Interval,
+		"series":   series,
+	})
+}
+
+// parseDateParam parses a YYYY-MM-DD or RFC3339 query parameter
+func parseDateParam(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
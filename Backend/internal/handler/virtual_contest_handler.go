@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/service"
+	"dojo/internal/utils"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type VirtualContestHandler struct {
+	virtualContestService *service.VirtualContestService
+}
+
+func NewVirtualContestHandler(virtualContestService *service.VirtualContestService) *VirtualContestHandler {
+	return &VirtualContestHandler{
+		virtualContestService: virtualContestService,
+	}
+}
+
+// CreateVirtualContest handles POST /api/virtual-contests
+func (h *VirtualContestHandler) CreateVirtualContest(c *fiber.Ctx) error {
+	var req dto.CreateVirtualContestRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request payload", err)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendBadRequest(c, "Validation failed", err)
+	}
+
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.virtualContestService.CreateVirtualContest(userID, &req)
+	if err != nil {
+		return h.sendError(c, "Failed to create virtual contest", err)
+	}
+
+	return utils.SendCreated(c, "Virtual contest created successfully", fiber.Map{
+		"virtual_contest": contest,
+	})
+}
+
+// ListVirtualContests handles GET /api/virtual-contests
+func (h *VirtualContestHandler) ListVirtualContests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contests, err := h.virtualContestService.ListVirtualContests(userID)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to fetch virtual contests", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Virtual contests fetched successfully", fiber.Map{
+		"virtual_contests": contests,
+		"total":            len(contests),
+	})
+}
+
+// GetVirtualContest handles GET /api/virtual-contests/:id
+func (h *VirtualContestHandler) GetVirtualContest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.virtualContestService.GetVirtualContest(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to fetch virtual contest", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Virtual contest fetched successfully", fiber.Map{
+		"virtual_contest": contest,
+	})
+}
+
+// JoinVirtualContest handles POST /api/virtual-contests/:id/join
+func (h *VirtualContestHandler) JoinVirtualContest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.virtualContestService.JoinVirtualContest(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to join virtual contest", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Joined virtual contest successfully", fiber.Map{
+		"virtual_contest": contest,
+	})
+}
+
+// StartVirtualContest handles POST /api/virtual-contests/:id/start
+func (h *VirtualContestHandler) StartVirtualContest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	contest, err := h.virtualContestService.StartVirtualContest(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to start virtual contest", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Virtual contest started successfully", fiber.Map{
+		"virtual_contest": contest,
+	})
+}
+
+// GetLeaderboard handles GET /api/virtual-contests/:id/leaderboard
+func (h *VirtualContestHandler) GetLeaderboard(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID).String()
+
+	leaderboard, err := h.virtualContestService.GetLeaderboard(c.Params("id"), userID)
+	if err != nil {
+		return h.sendError(c, "Failed to fetch leaderboard", err)
+	}
+
+	return utils.SendSuccess(c, fiber.StatusOK, "Leaderboard fetched successfully", fiber.Map{
+		"leaderboard": leaderboard,
+	})
+}
+
+// sendError maps virtual contest service errors to responses
+func (h *VirtualContestHandler) sendError(c *fiber.Ctx, message string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrVirtualContestNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Virtual contest not found", err)
+	case errors.Is(err, utils.ErrContestNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Contest not found", err)
+	case errors.Is(err, utils.ErrProblemNotFound):
+		return utils.SendError(c, fiber.StatusNotFound, "Problem not found", err)
+	case errors.Is(err, utils.ErrNotInvited):
+		return utils.SendError(c, fiber.StatusForbidden, "You are not invited to this virtual contest", err)
+	case errors.Is(err, utils.ErrUnauthorized):
+		return utils.SendError(c, fiber.StatusForbidden, "Only the creator can start the virtual contest", err)
+	case errors.Is(err, utils.ErrVirtualContestStarted):
+		return utils.SendConflict(c, "Virtual contest already started")
+	case errors.Is(err, utils.ErrAlreadyInRoom):
+		return utils.SendConflict(c, "You already joined this virtual contest")
+	case errors.Is(err, utils.ErrInvalidInput):
+		return utils.SendBadRequest(c, err.Error(), nil)
+	default:
+		return utils.SendInternalError(c, message, err)
+	}
+}
@@ -49,6 +49,28 @@ func AuthMiddleware(cfg *config.Config) fiber.Handler {
 	}
 }
 
+// OptionalAuthMiddleware sets the user info like AuthMiddleware when a valid bearer token is
+// sent, but lets the request through anonymously otherwise (for public routes that return
+// extra data to signed-in users)
+func OptionalAuthMiddleware(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, err := utils.ExtractTokenFromHeader(c.Get("Authorization"))
+		if err != nil {
+			return c.Next()
+		}
+
+		claims, err := utils.ValidateToken(token, cfg.JWT.Secret)
+		if err != nil {
+			return c.Next()
+		}
+
+		c.Locals("userID", claims.UserID)
+		c.Locals("email", claims.Email)
+
+		return c.Next()
+	}
+}
+
 // GetUserID gets user ID from context
 func GetUserID(c *fiber.Ctx) (uuid.UUID, error) {
 	userID := c.Locals("userID")
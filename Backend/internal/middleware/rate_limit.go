@@ -7,8 +7,9 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
-// RateLimitMiddleware limits request rate
-func RateLimitMiddleware(cfg *config.Config) fiber.Handler {
+// RateLimitMiddleware limits request rate.
+// Pass a shared storage (e.g. Redis) so limits hold across instances; nil keeps counters in memory.
+func RateLimitMiddleware(cfg *config.Config, storage fiber.Storage) fiber.Handler {
 	return limiter.New(limiter.Config{
 		Max:        cfg.RateLimit.RequestsPerMinute,
 		Expiration: cfg.RateLimit.Window,
@@ -24,6 +25,6 @@ func RateLimitMiddleware(cfg *config.Config) fiber.Handler {
 		},
 		SkipFailedRequests:     false,
 		SkipSuccessfulRequests: false,
-		Storage:                nil, // Use memory storage (can be replaced with Redis)
+		Storage:                storage,
 	})
 }
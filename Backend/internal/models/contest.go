@@ -10,17 +10,20 @@ import (
 // Contest represents a coding contest from various platforms
 type Contest struct {
 	ID                uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	Platform          string    `gorm:"type:varchar(50);not null;index" json:"platform"`
-	PlatformContestID string    `gorm:"type:varchar(255)" json:"platform_contest_id"`
+	Platform          string    `gorm:"type:varchar(50);not null;index;uniqueIndex:idx_contests_platform_contest" json:"platform"`
+	PlatformContestID string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_contests_platform_contest" json:"platform_contest_id"`
 	Name              string    `gorm:"type:varchar(500);not null" json:"name"`
 	StartTime         time.Time `gorm:"not null;index" json:"start_time"`
 	DurationSeconds   int       `gorm:"not null" json:"duration_seconds"`
 	ContestURL        string    `gorm:"type:text;index" json:"contest_url"`
 	Description       string    `gorm:"type:text" json:"description"`
+	IsPrivate         bool      `gorm:"default:false;index" json:"is_private"` // Private Dojo contest, only listed for its organizer and participants
 	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relationships
-	Reminders []ContestReminder `gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE" json:"-"`
+	Reminders       []ContestReminder       `gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE" json:"-"`
+	ScheduleChanges []ContestScheduleChange `gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 // BeforeCreate hook
@@ -62,3 +65,60 @@ func (r *ContestReminder) BeforeCreate(tx *gorm.DB) error {
 func (ContestReminder) TableName() string {
 	return "contest_reminders"
 }
+
+// ContestScheduleChange records a platform moving a contest's start time or duration
+type ContestScheduleChange struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ContestID          uuid.UUID `gorm:"type:uuid;not null;index" json:"contest_id"`
+	OldStartTime       time.Time `gorm:"not null" json:"old_start_time"`
+	NewStartTime       time.Time `gorm:"not null" json:"new_start_time"`
+	OldDurationSeconds int       `gorm:"not null" json:"old_duration_seconds"`
+	NewDurationSeconds int       `gorm:"not null" json:"new_duration_seconds"`
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook
+func (c *ContestScheduleChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ContestScheduleChange) TableName() string {
+	return "contest_schedule_changes"
+}
+
+// ContestParticipation records a user's result in a rated contest
+type ContestParticipation struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_participations_user_contest" json:"user_id"`
+	ContestID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_participations_user_contest;index" json:"contest_id"`
+	Platform       string    `gorm:"type:varchar(50);not null" json:"platform"`
+	Rank           int       `json:"rank"`
+	OldRating      int       `json:"old_rating"`
+	NewRating      int       `json:"new_rating"`
+	RatingChange   int       `json:"rating_change"`
+	ProblemsSolved int       `gorm:"default:0" json:"problems_solved"`
+	TotalProblems  int       `gorm:"default:0" json:"total_problems"` // 0 if unknown
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	User    User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Contest Contest `gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE" json:"contest,omitempty"`
+}
+
+// BeforeCreate hook
+func (p *ContestParticipation) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ContestParticipation) TableName() string {
+	return "contest_participations"
+}
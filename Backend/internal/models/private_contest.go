@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Private contest scoring modes
+const (
+	ScoringModeICPC = "icpc" // problems solved, then penalty time
+	ScoringModeIOI  = "ioi"  // total partial points, then time of the last improvement
+)
+
+// PrivateContestPlatform is the Contest.Platform of private contests
+const PrivateContestPlatform = "dojo"
+
+// PrivateContest is an invite-only contest organized on Dojo. Its schedule lives in the
+// linked Contest row, so it is listed next to external contests for its participants.
+type PrivateContest struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ContestID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"contest_id"`
+	CreatedBy     uuid.UUID  `gorm:"type:uuid;not null;index" json:"created_by"` // The organizer
+	InviteCode    string     `gorm:"type:varchar(20);not null;uniqueIndex" json:"-"`
+	ScoringMode   string     `gorm:"type:varchar(10);not null;default:'icpc'" json:"scoring_mode"` // icpc, ioi
+	FreezeMinutes int        `gorm:"default:0" json:"freeze_minutes"`                              // Standings freeze before the end, 0 for none
+	UnfrozenAt    *time.Time `json:"unfrozen_at"`                                                  // Set when the organizer lifts the freeze
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Contest      Contest                     `gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE" json:"contest"`
+	Creator      User                        `gorm:"foreignKey:CreatedBy;constraint:OnDelete:CASCADE" json:"-"`
+	Problems     []PrivateContestProblem     `gorm:"foreignKey:PrivateContestID;constraint:OnDelete:CASCADE" json:"problems,omitempty"`
+	Participants []PrivateContestParticipant `gorm:"foreignKey:PrivateContestID;constraint:OnDelete:CASCADE" json:"participants,omitempty"`
+}
+
+// BeforeCreate hook
+func (p *PrivateContest) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (PrivateContest) TableName() string {
+	return "private_contests"
+}
+
+// FreezeTime returns when the standings freeze, or nil if they never do
+func (p *PrivateContest) FreezeTime() *time.Time {
+	if p.FreezeMinutes <= 0 {
+		return nil
+	}
+	end := p.Contest.StartTime.Add(time.Duration(p.Contest.DurationSeconds) * time.Second)
+	freeze := end.Add(-time.Duration(p.FreezeMinutes) * time.Minute)
+	return &freeze
+}
+
+// PrivateContestProblem is a problem in a private contest
+type PrivateContestProblem struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PrivateContestID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_private_contest_problem" json:"private_contest_id"`
+	ProblemID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_private_contest_problem" json:"problem_id"`
+	Position         int       `gorm:"not null" json:"position"`  // 0 for problem A, 1 for B, ...
+	Points           int       `gorm:"default:100" json:"points"` // Full score in IOI mode
+
+	// Relationships
+	Problem Problem `gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE" json:"problem,omitempty"`
+}
+
+// BeforeCreate hook
+func (p *PrivateContestProblem) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (PrivateContestProblem) TableName() string {
+	return "private_contest_problems"
+}
+
+// PrivateContestParticipant is a user who joined a private contest with its invite code
+type PrivateContestParticipant struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PrivateContestID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_private_contest_participant" json:"private_contest_id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_private_contest_participant;index" json:"user_id"`
+	JoinedAt         time.Time `gorm:"autoCreateTime" json:"joined_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate hook
+func (p *PrivateContestParticipant) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (PrivateContestParticipant) TableName() string {
+	return "private_contest_participants"
+}
+
+// PrivateContestSubmission is a verdict a participant reported for a problem during a private contest
+type PrivateContestSubmission struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PrivateContestID uuid.UUID `gorm:"type:uuid;not null;index" json:"private_contest_id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	ProblemID        uuid.UUID `gorm:"type:uuid;not null" json:"problem_id"`
+	Accepted         bool      `gorm:"default:false" json:"accepted"`
+	Score            int       `gorm:"default:0" json:"score"` // IOI points, full points when accepted
+	SubmittedAt      time.Time `gorm:"not null;index" json:"submitted_at"`
+
+	// Relationships
+	PrivateContest PrivateContest `gorm:"foreignKey:PrivateContestID;constraint:OnDelete:CASCADE" json:"-"`
+	User           User           `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Problem        Problem        `gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate hook
+func (s *PrivateContestSubmission) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (PrivateContestSubmission) TableName() string {
+	return "private_contest_submissions"
+}
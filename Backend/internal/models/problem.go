@@ -12,11 +12,12 @@ import (
 // Problem represents a coding problem from various platforms
 type Problem struct {
 	ID                uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	Platform          string          `gorm:"type:varchar(50);not null;index" json:"platform"` // 'leetcode', 'codeforces', etc.
-	PlatformProblemID string          `gorm:"type:varchar(255);not null" json:"platform_problem_id"`
+	Platform          string          `gorm:"type:varchar(50);not null;index;uniqueIndex:idx_problems_platform_problem,priority:1" json:"platform"` // 'leetcode', 'codeforces', etc.
+	PlatformProblemID string          `gorm:"type:varchar(255);not null;uniqueIndex:idx_problems_platform_problem,priority:2" json:"platform_problem_id"`
 	Title             string          `gorm:"type:varchar(500);not null" json:"title"`
 	Slug              string          `gorm:"type:varchar(500)" json:"slug"`
 	Difficulty        string          `gorm:"type:varchar(20);index" json:"difficulty"` // 'easy', 'medium', 'hard'
+	Rating            int             `gorm:"default:0;index" json:"rating"`            // Platform difficulty rating, 0 if unknown
 	Tags              pq.StringArray  `gorm:"type:text[]" json:"tags"`                  // PostgreSQL array
 	AcceptanceRate    float64         `json:"acceptance_rate"`
 	ProblemURL        string          `gorm:"type:text" json:"problem_url"`
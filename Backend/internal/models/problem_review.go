@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultEaseFactor is the SM-2 ease factor of a newly scheduled review
+const DefaultEaseFactor = 2.5
+
+// ProblemReview schedules re-solving a solved problem with the SM-2 spaced repetition algorithm
+type ProblemReview struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_problem_reviews_user_problem;index:idx_problem_reviews_user_due,priority:1" json:"user_id"`
+	ProblemID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_problem_reviews_user_problem" json:"problem_id"`
+	EaseFactor     float64    `gorm:"not null;default:2.5" json:"ease_factor"`
+	IntervalDays   int        `gorm:"not null;default:1" json:"interval_days"`
+	Repetitions    int        `gorm:"not null;default:0" json:"repetitions"` // Successful recalls in a row, the solve included
+	ReviewCount    int        `gorm:"not null;default:0" json:"review_count"`
+	LastQuality    *int       `json:"last_quality"` // 0-5 recall rating of the last review
+	LastReviewedAt *time.Time `json:"last_reviewed_at"`
+	DueAt          time.Time  `gorm:"not null;index:idx_problem_reviews_user_due,priority:2" json:"due_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	User    User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Problem Problem `gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE" json:"problem,omitempty"`
+}
+
+// BeforeCreate hook
+func (pr *ProblemReview) BeforeCreate(tx *gorm.DB) error {
+	if pr.ID == uuid.Nil {
+		pr.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ProblemReview) TableName() string {
+	return "problem_reviews"
+}
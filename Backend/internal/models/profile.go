@@ -19,6 +19,7 @@ type UserProfile struct {
 	CodeforcesUsername string `gorm:"type:varchar(100)" json:"codeforces_username"`
 	CodechefUsername   string `gorm:"type:varchar(100)" json:"codechef_username"`
 	GFGUsername        string `gorm:"type:varchar(100)" json:"gfg_username"`
+	AtcoderUsername    string `gorm:"type:varchar(100)" json:"atcoder_username"`
 
 	// Aggregated stats
 	TotalSolved  int `gorm:"default:0" json:"total_solved"`
@@ -46,6 +47,41 @@ func (UserProfile) TableName() string {
 	return "user_profiles"
 }
 
+// platformUsernameColumns maps each platform to the user_profiles column holding its handle.
+// Supporting a platform takes a field on UserProfile and entries here and in PlatformUsername.
+var platformUsernameColumns = map[string]string{
+	"leetcode":   "leetcode_username",
+	"codeforces": "codeforces_username",
+	"codechef":   "codechef_username",
+	"gfg":        "gfg_username",
+	"atcoder":    "atcoder_username",
+}
+
+// PlatformUsernameColumn returns the user_profiles column holding the handle linked for a
+// platform, or false if profiles can't link one
+func PlatformUsernameColumn(platform string) (string, bool) {
+	column, ok := platformUsernameColumns[platform]
+	return column, ok
+}
+
+// PlatformUsername returns the handle linked for a platform ("" if none)
+func (p *UserProfile) PlatformUsername(platform string) string {
+	switch platform {
+	case "leetcode":
+		return p.LeetcodeUsername
+	case "codeforces":
+		return p.CodeforcesUsername
+	case "codechef":
+		return p.CodechefUsername
+	case "gfg":
+		return p.GFGUsername
+	case "atcoder":
+		return p.AtcoderUsername
+	default:
+		return ""
+	}
+}
+
 // UserPlatformStat holds detailed statistics for each coding platform.
 // UserPlatformStat holds detailed statistics for each coding platform.
 type UserPlatformStat struct {
@@ -80,3 +116,75 @@ func (s *UserPlatformStat) BeforeCreate(tx *gorm.DB) error {
 func (UserPlatformStat) TableName() string {
 	return "user_platform_stats"
 }
+
+// PlatformStatSnapshot is a point-in-time copy of a UserPlatformStat, written on every sync,
+// used to chart rating and solved count over time.
+type PlatformStatSnapshot struct {
+	ID                 uuid.UUID `gorm:"type:uuid; primaryKey; default:gen_random_uuid()" json:"id"`
+	UserID             uuid.UUID `gorm:"type:uuid; not null; index:idx_stat_snapshot_user_platform_time" json:"user_id"`
+	Platform           string    `gorm:"type:varchar(50); not null; index:idx_stat_snapshot_user_platform_time" json:"platform"`
+	Rating             int       `json:"rating"`
+	MaxRating          int       `json:"max_rating"`
+	ProblemsSolved     int       `gorm:"default:0" json:"problems_solved"`
+	EasyProblemsSolved int       `gorm:"default:0" json:"easy_problems_solved"`
+	MedProblemsSolved  int       `gorm:"default:0" json:"med_problems_solved"`
+	HardProblemsSolved int       `gorm:"default:0" json:"hard_problems_solved"`
+	ContestsAttended   int       `gorm:"default:0" json:"contests_attended"`
+	GlobalRank         int       `json:"global_rank"`
+	RecordedAt         time.Time `gorm:"not null; index:idx_stat_snapshot_user_platform_time" json:"recorded_at"`
+
+	// Relationship with User
+	User User `gorm:"foreignKey:UserID; constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate Hook
+func (s *PlatformStatSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	if s.RecordedAt.IsZero() {
+		s.RecordedAt = time.Now()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the PlatformStatSnapshot model.
+func (PlatformStatSnapshot) TableName() string {
+	return "platform_stat_snapshots"
+}
+
+// Platform sync statuses stored in PlatformSyncLog.Status
+const (
+	SyncStatusSuccess = "success"
+	SyncStatusPartial = "partial"
+	SyncStatusFailed  = "failed"
+)
+
+// PlatformSyncLog records the outcome of every platform stats sync (manual or scheduled).
+type PlatformSyncLog struct {
+	ID         uuid.UUID `gorm:"type:uuid; primaryKey; default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid; not null; index:idx_sync_log_user_platform" json:"user_id"`
+	Platform   string    `gorm:"type:varchar(50); not null; index:idx_sync_log_user_platform" json:"platform"`
+	Username   string    `gorm:"type:varchar(100)" json:"username"`
+	Trigger    string    `gorm:"type:varchar(20); not null" json:"trigger"` // "manual" or "scheduled"
+	Status     string    `gorm:"type:varchar(20); not null" json:"status"`  // success, partial, failed
+	Error      string    `gorm:"type:text" json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `gorm:"autoCreateTime; index" json:"created_at"`
+
+	// Relationship with User
+	User User `gorm:"foreignKey:UserID; constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate Hook
+func (l *PlatformSyncLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the PlatformSyncLog model.
+func (PlatformSyncLog) TableName() string {
+	return "platform_sync_logs"
+}
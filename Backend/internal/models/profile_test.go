@@ -0,0 +1,69 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestPlatformUsername(t *testing.T) {
+	profile := &UserProfile{
+		LeetcodeUsername:   "lc",
+		CodeforcesUsername: "cf",
+		CodechefUsername:   "cc",
+		GFGUsername:        "gfg",
+		AtcoderUsername:    "ac",
+	}
+
+	tests := map[string]string{
+		"leetcode":   "lc",
+		"codeforces": "cf",
+		"codechef":   "cc",
+		"gfg":        "gfg",
+		"atcoder":    "ac",
+		"dojo":       "",
+		"":           "",
+	}
+	for platform, want := range tests {
+		if got := profile.PlatformUsername(platform); got != want {
+			t.Errorf("PlatformUsername(%q) = %q, want %q", platform, got, want)
+		}
+	}
+}
+
+// Every platform column must be a real column holding the handle PlatformUsername returns
+func TestPlatformUsernameColumn(t *testing.T) {
+	s, err := schema.Parse(&UserProfile{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse UserProfile: %v", err)
+	}
+
+	for _, platform := range []string{"leetcode", "codeforces", "codechef", "gfg", "atcoder"} {
+		column, ok := PlatformUsernameColumn(platform)
+		if !ok {
+			t.Errorf("no column for %s", platform)
+			continue
+		}
+		field := s.LookUpField(column)
+		if field == nil || field.DBName != column {
+			t.Errorf("%s column %q is not a UserProfile column", platform, column)
+			continue
+		}
+
+		profile := &UserProfile{}
+		reflectValue := reflect.ValueOf(profile).Elem()
+		if err := field.Set(context.Background(), reflectValue, platform+"-handle"); err != nil {
+			t.Fatalf("failed to set %s: %v", column, err)
+		}
+		if got := profile.PlatformUsername(platform); got != platform+"-handle" {
+			t.Errorf("PlatformUsername(%q) = %q, want the %s column", platform, got, column)
+		}
+	}
+
+	if _, ok := PlatformUsernameColumn("dojo"); ok {
+		t.Error("dojo has a username column")
+	}
+}
@@ -16,8 +16,10 @@ type User struct {
 	AvatarURL    string    `gorm:"type: varchar(500)" json:"avatar_url"`
 	IsVerified   bool      `gorm:"default:false" json:"is_verified"`
 	IsActive     bool      `gorm:"default:true" json:"is_active"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// CalendarToken authenticates the user's personal contest calendar feed
+	CalendarToken *string   `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relationships with other models
 	Profile       *UserProfile       `gorm:"foreignKey:UserID; constraint: OnDelete:CASCADE" json:"profile,omitempty"`
@@ -7,17 +7,36 @@ import (
 	"gorm.io/gorm"
 )
 
-// UserProblemProgress tracks which problems a user has solved
+// Submission verdicts
+const (
+	VerdictAccepted            = "AC"
+	VerdictWrongAnswer         = "WA"
+	VerdictTimeLimitExceeded   = "TLE"
+	VerdictMemoryLimitExceeded = "MLE"
+	VerdictRuntimeError        = "RE"
+	VerdictCompilationError    = "CE"
+	VerdictOther               = "OTHER"
+)
+
+// SubmissionSourceManual marks submissions logged in Dojo. Imported submissions use the
+// platform name as their source.
+const SubmissionSourceManual = "manual"
+
+// UserProblemProgress tracks which problems a user has solved. It is a summary of the user's
+// ProblemSubmissions for the problem and is recomputed whenever one is logged. There is at
+// most one per user and problem.
 type UserProblemProgress struct {
-	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
-	ProblemID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"problem_id"`
-	IsSolved    bool       `gorm:"default:false;not null" json:"is_solved"`
-	SolvedAt    *time.Time `json:"solved_at"`
-	Attempts    int        `gorm:"default:0" json:"attempts"`
-	LastAttempt *time.Time `json:"last_attempt"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_problem_progress_user_problem,priority:1" json:"user_id"`
+	ProblemID        uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_problem_progress_user_problem,priority:2" json:"problem_id"`
+	IsSolved         bool       `gorm:"default:false;not null" json:"is_solved"` // Any accepted submission
+	SolvedAt         *time.Time `json:"solved_at"`                               // First accepted submission
+	Attempts         int        `gorm:"default:0" json:"attempts"`               // Number of submissions
+	LastAttempt      *time.Time `json:"last_attempt"`
+	LastVerdict      string     `gorm:"type:varchar(10)" json:"last_verdict"`
+	TimeSpentSeconds int        `gorm:"default:0" json:"time_spent_seconds"` // Total over all submissions
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relationships
 	User    User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
@@ -36,3 +55,65 @@ func (upp *UserProblemProgress) BeforeCreate(tx *gorm.DB) error {
 func (UserProblemProgress) TableName() string {
 	return "user_problem_progress"
 }
+
+// ProblemSubmission is one attempt at a problem
+type ProblemSubmission struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;index:idx_problem_submissions_user_problem,priority:1;uniqueIndex:idx_problem_submissions_external,priority:1,where:external_id <> ''" json:"user_id"`
+	ProblemID        uuid.UUID `gorm:"type:uuid;not null;index:idx_problem_submissions_user_problem,priority:2" json:"problem_id"`
+	Verdict          string    `gorm:"type:varchar(10);not null" json:"verdict"` // AC, WA, TLE, MLE, RE, CE or OTHER
+	Language         string    `gorm:"type:varchar(50)" json:"language"`
+	TimeSpentSeconds int       `gorm:"default:0" json:"time_spent_seconds"`
+	Code             string    `gorm:"type:text" json:"code"`
+	SubmittedAt      time.Time `gorm:"not null;index:idx_problem_submissions_user_problem,priority:3" json:"submitted_at"`
+	Source           string    `gorm:"type:varchar(50);not null;default:'manual';uniqueIndex:idx_problem_submissions_external,priority:2" json:"source"`
+	ExternalID       string    `gorm:"type:varchar(100);uniqueIndex:idx_problem_submissions_external,priority:3" json:"external_id,omitempty"` // Platform submission ID for imports
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User    User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Problem Problem `gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate hook
+func (ps *ProblemSubmission) BeforeCreate(tx *gorm.DB) error {
+	if ps.ID == uuid.Nil {
+		ps.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (ProblemSubmission) TableName() string {
+	return "problem_submissions"
+}
+
+// PendingSubmission is an imported accepted submission to a problem that isn't in the catalogue
+// yet. Submissions identified only by slug can't create their problem, so they wait here and
+// are imported by a later sync once the problem is stored.
+type PendingSubmission struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_pending_submissions_external,priority:1" json:"user_id"`
+	Source      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_pending_submissions_external,priority:2" json:"source"`
+	ExternalID  string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_pending_submissions_external,priority:3" json:"external_id"`
+	ProblemSlug string    `gorm:"type:varchar(500);not null" json:"problem_slug"`
+	Language    string    `gorm:"type:varchar(50)" json:"language"`
+	SubmittedAt time.Time `gorm:"not null" json:"submitted_at"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate hook
+func (ps *PendingSubmission) BeforeCreate(tx *gorm.DB) error {
+	if ps.ID == uuid.Nil {
+		ps.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (PendingSubmission) TableName() string {
+	return "pending_submissions"
+}
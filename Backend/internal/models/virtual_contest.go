@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Virtual contest participant statuses
+const (
+	VirtualParticipantInvited = "invited"
+	VirtualParticipantJoined  = "joined"
+)
+
+// VirtualContest is a timed re-run of a set of problems that friends solve together.
+// Live updates are pushed over the WebSocket of its room.
+type VirtualContest struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name            string     `gorm:"type:varchar(255);not null" json:"name"`
+	CreatedBy       uuid.UUID  `gorm:"type:uuid;not null;index" json:"created_by"`
+	SourceContestID *uuid.UUID `gorm:"type:uuid" json:"source_contest_id"` // Past contest the problems were taken from
+	RoomID          uuid.UUID  `gorm:"type:uuid;not null" json:"room_id"`
+	DurationMinutes int        `gorm:"not null" json:"duration_minutes"`
+	StartedAt       *time.Time `json:"started_at"` // nil until the creator starts the timer
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Creator       User                        `gorm:"foreignKey:CreatedBy;constraint:OnDelete:CASCADE" json:"-"`
+	SourceContest *Contest                    `gorm:"foreignKey:SourceContestID;constraint:OnDelete:SET NULL" json:"-"`
+	Room          Room                        `gorm:"foreignKey:RoomID;constraint:OnDelete:CASCADE" json:"-"`
+	Problems      []VirtualContestProblem     `gorm:"foreignKey:VirtualContestID;constraint:OnDelete:CASCADE" json:"problems,omitempty"`
+	Participants  []VirtualContestParticipant `gorm:"foreignKey:VirtualContestID;constraint:OnDelete:CASCADE" json:"participants,omitempty"`
+}
+
+// BeforeCreate hook
+func (v *VirtualContest) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (VirtualContest) TableName() string {
+	return "virtual_contests"
+}
+
+// EndsAt returns when the contest ends, or nil if it hasn't started
+func (v *VirtualContest) EndsAt() *time.Time {
+	if v.StartedAt == nil {
+		return nil
+	}
+	end := v.StartedAt.Add(time.Duration(v.DurationMinutes) * time.Minute)
+	return &end
+}
+
+// VirtualContestProblem is a problem in a virtual contest
+type VirtualContestProblem struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	VirtualContestID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_problem" json:"virtual_contest_id"`
+	ProblemID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_problem;index" json:"problem_id"`
+	Position         int       `gorm:"not null" json:"position"` // 0 for problem A, 1 for B, ...
+
+	// Relationships
+	Problem Problem `gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE" json:"problem,omitempty"`
+}
+
+// BeforeCreate hook
+func (p *VirtualContestProblem) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (VirtualContestProblem) TableName() string {
+	return "virtual_contest_problems"
+}
+
+// VirtualContestParticipant is a user invited to or taking part in a virtual contest
+type VirtualContestParticipant struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	VirtualContestID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_participant" json:"virtual_contest_id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_participant;index" json:"user_id"`
+	Status           string     `gorm:"type:varchar(20);not null;default:'invited'" json:"status"` // invited, joined
+	JoinedAt         *time.Time `json:"joined_at"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate hook
+func (p *VirtualContestParticipant) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (VirtualContestParticipant) TableName() string {
+	return "virtual_contest_participants"
+}
+
+// VirtualContestSubmission tracks a participant's attempts at a problem during a virtual contest
+type VirtualContestSubmission struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	VirtualContestID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_submission" json:"virtual_contest_id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_submission" json:"user_id"`
+	ProblemID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_virtual_contest_submission" json:"problem_id"`
+	WrongAttempts    int        `gorm:"default:0" json:"wrong_attempts"` // Attempts before the problem was solved
+	SolvedAt         *time.Time `json:"solved_at"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	VirtualContest VirtualContest `gorm:"foreignKey:VirtualContestID;constraint:OnDelete:CASCADE" json:"-"`
+	User           User           `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Problem        Problem        `gorm:"foreignKey:ProblemID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BeforeCreate hook
+func (s *VirtualContestSubmission) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name
+func (VirtualContestSubmission) TableName() string {
+	return "virtual_contest_submissions"
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"dojo/internal/models"
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ContestRepository struct {
@@ -22,18 +25,40 @@ func (r *ContestRepository) FindAll(filters map[string]interface{}, page, limit
 
 	query := r.db.Model(&models.Contest{})
 
+	// Private contests are only listed for their organizer and participants
+	viewerID, _ := filters["viewer_id"].(string)
+	query = visibleTo(query, viewerID)
+
 	// Apply filters
-	platform, ok := filters["platform"]
+	platforms, ok := filters["platforms"].([]string)
+	if ok && len(platforms) > 0 {
+		query = query.Where("platform IN ?", platforms)
+	}
+	startDate, ok := filters["start_date"].(time.Time)
 	if ok {
-		query = query.Where("platform=?", platform)
+		query = query.Where("start_time>=?", startDate)
+	}
+	endDate, ok := filters["end_date"].(time.Time)
+	if ok {
+		query = query.Where("start_time<=?", endDate)
+	}
+	minDuration, ok := filters["min_duration"].(int)
+	if ok {
+		query = query.Where("duration_seconds>=?", minDuration)
+	}
+	maxDuration, ok := filters["max_duration"].(int)
+	if ok {
+		query = query.Where("duration_seconds<=?", maxDuration)
 	}
 	upcoming, ok := filters["upcoming"]
 	if ok && upcoming.(bool) {
 		query = query.Where("start_time>?", time.Now())
 	}
+	// There is no end_time column, the end is derived from the duration
 	ongoing, ok := filters["ongoing"]
 	if ok && ongoing.(bool) {
-		query = query.Where("start_time<=? AND end_time>=?", time.Now(), time.Now())
+		now := time.Now()
+		query = query.Where("start_time<=? AND start_time + duration_seconds * interval '1 second' >= ?", now, now)
 	}
 	// Total count
 	err := query.Count(&total).Error
@@ -49,6 +74,20 @@ func (r *ContestRepository) FindAll(filters map[string]interface{}, page, limit
 	return contests, total, nil
 }
 
+// FindCalendarContests retrieves contests starting after since for a calendar feed
+func (r *ContestRepository) FindCalendarContests(platform string, since time.Time, limit int) ([]models.Contest, error) {
+	var contests []models.Contest
+	query := r.db.Where("start_time >= ? AND is_private = ?", since, false)
+	if platform != "" {
+		query = query.Where("platform=?", platform)
+	}
+	err := query.Order("start_time ASC").Limit(limit).Find(&contests).Error
+	if err != nil {
+		return nil, err
+	}
+	return contests, nil
+}
+
 // FindByID retrieves the contests by ID
 func (r *ContestRepository) FindByID(id string) (*models.Contest, error) {
 	var contest models.Contest
@@ -59,18 +98,87 @@ func (r *ContestRepository) FindByID(id string) (*models.Contest, error) {
 	return &contest, nil
 }
 
-// Create creates a new contest (with upsert to avoid duplicates)
-func (r *ContestRepository) Create(contest *models.Contest) error {
-	// Use FirstOrCreate to avoid duplicates based on URL
-	var existing models.Contest
-	result := r.db.Where("contest_url = ?", contest.ContestURL).FirstOrCreate(&existing, contest)
-	return result.Error
+// FindVisibleByID retrieves a contest by ID if the user can see it. userID is empty for
+// anonymous callers, who only see public contests.
+func (r *ContestRepository) FindVisibleByID(id, userID string) (*models.Contest, error) {
+	var contest models.Contest
+	err := visibleTo(r.db.Where("id = ?", id), userID).First(&contest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// visibleTo limits a contest query to public contests and the private contests the user
+// organizes or joined
+func visibleTo(query *gorm.DB, userID string) *gorm.DB {
+	if userID == "" {
+		return query.Where("contests.is_private = ?", false)
+	}
+	return query.Where(
+		"contests.is_private = ? OR contests.id IN (SELECT contest_id FROM private_contests WHERE created_by = ? OR id IN (SELECT private_contest_id FROM private_contest_participants WHERE user_id = ?))",
+		false, userID, userID,
+	)
+}
+
+// UpsertContest inserts the contest or updates the stored one with the same platform and
+// platform contest ID in place. Rows saved before contests were keyed by platform ID, whose
+// backfilled ID may not match the platform's, are matched by URL and adopted. previous is a
+// copy of the row before the update, or nil when the contest was created.
+func (r *ContestRepository) UpsertContest(contest *models.Contest) (previous *models.Contest, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.Contest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("platform = ? AND platform_contest_id = ?", contest.Platform, contest.PlatformContestID).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) && contest.ContestURL != "" {
+			err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("platform = ? AND contest_url = ?", contest.Platform, contest.ContestURL).
+				First(&existing).Error
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(contest).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		old := existing
+		previous = &old
+		contest.ID = existing.ID
+		contest.CreatedAt = existing.CreatedAt
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"platform_contest_id": contest.PlatformContestID,
+			"name":                contest.Name,
+			"start_time":          contest.StartTime,
+			"duration_seconds":    contest.DurationSeconds,
+			"contest_url":         contest.ContestURL,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// CreateScheduleChange records a contest schedule change
+func (r *ContestRepository) CreateScheduleChange(change *models.ContestScheduleChange) error {
+	return r.db.Create(change).Error
+}
+
+// FindOrCreateContest loads the contest with the same platform and platform contest ID into
+// contest, creating it if it doesn't exist. Existing contests are left unchanged.
+func (r *ContestRepository) FindOrCreateContest(contest *models.Contest) error {
+	return r.db.Where("platform = ? AND platform_contest_id = ?", contest.Platform, contest.PlatformContestID).
+		FirstOrCreate(contest).Error
 }
 
-// DeleteOldContests removes contests older than specified days
+// DeleteOldContests removes external contests older than specified days that no user took part in
 func (r *ContestRepository) DeleteOldContests(daysOld int) (int64, error) {
 	cutoffDate := time.Now().AddDate(0, 0, -daysOld)
-	result := r.db.Where("start_time < ?", cutoffDate).Delete(&models.Contest{})
+	result := r.db.Where("start_time < ? AND is_private = ?", cutoffDate, false).
+		Where("NOT EXISTS (SELECT 1 FROM contest_participations WHERE contest_participations.contest_id = contests.id)").
+		Delete(&models.Contest{})
 	return result.RowsAffected, result.Error
 }
 
@@ -106,13 +214,33 @@ func (r *ContestRepository) DeleteReminder(id string) error {
 
 func (r *ContestRepository) FindReminderByuserID(userID string) ([]models.ContestReminder, error) {
 	var reminders []models.ContestReminder
-	err := r.db.Where("user_id=?", userID).Preload("Contest").Find(&reminders).Error
+	err := r.db.Where("user_id=?", userID).Preload("Contest").Order("created_at DESC").Find(&reminders).Error
 	if err != nil {
 		return nil, err
 	}
 	return reminders, nil
 }
 
+// FindReminderIDsByContestIDs maps each of the given contests the user set a reminder for to the reminder ID
+func (r *ContestRepository) FindReminderIDsByContestIDs(userID string, contestIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	reminderIDs := make(map[uuid.UUID]uuid.UUID)
+	if len(contestIDs) == 0 {
+		return reminderIDs, nil
+	}
+
+	var reminders []models.ContestReminder
+	err := r.db.Select("id", "contest_id").
+		Where("user_id=? AND contest_id IN ?", userID, contestIDs).
+		Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, reminder := range reminders {
+		reminderIDs[reminder.ContestID] = reminder.ID
+	}
+	return reminderIDs, nil
+}
+
 // Existsreminder checks if a reminder exists for a user and contest
 func (r *ContestRepository) ExistsReminder(userID, contestID string) (bool, error) {
 	var count int64
@@ -122,3 +250,128 @@ func (r *ContestRepository) ExistsReminder(userID, contestID string) (bool, erro
 	}
 	return count > 0, nil
 }
+
+// ClaimDueReminders marks reminders whose notify time has passed as notified and returns them
+// with User and Contest preloaded. Rows are locked with SKIP LOCKED and the flag is committed
+// before anything is delivered, so a reminder is never handed out twice (even across restarts
+// or several running instances).
+func (r *ContestRepository) ClaimDueReminders(now time.Time, limit int) ([]models.ContestReminder, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ContestReminder{}).
+			Joins("JOIN contests ON contests.id = contest_reminders.contest_id").
+			Where("contest_reminders.is_notified = ?", false).
+			Where("contests.start_time > ?", now).
+			Where("contests.start_time - contest_reminders.remind_before_minutes * interval '1 minute' <= ?", now).
+			Order("contests.start_time ASC").
+			Limit(limit).
+			Clauses(clause.Locking{
+				Strength: "UPDATE",
+				Table:    clause.Table{Name: "contest_reminders"},
+				Options:  "SKIP LOCKED",
+			}).
+			Pluck("contest_reminders.id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&models.ContestReminder{}).Where("id IN ?", ids).Update("is_notified", true).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var reminders []models.ContestReminder
+	err = r.db.Preload("User").Preload("Contest").Where("id IN ?", ids).Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// ExpireMissedReminders marks reminders whose contest already started as notified,
+// so reminders missed while the server was down are not sent late
+func (r *ContestRepository) ExpireMissedReminders(now time.Time) (int64, error) {
+	result := r.db.Model(&models.ContestReminder{}).
+		Where("is_notified = ? AND contest_id IN (?)", false,
+			r.db.Model(&models.Contest{}).Select("id").Where("start_time <= ?", now)).
+		Update("is_notified", true)
+	return result.RowsAffected, result.Error
+}
+
+// FindRemindersByContestID retrieves all reminders for a contest with Contest preloaded
+func (r *ContestRepository) FindRemindersByContestID(contestID string) ([]models.ContestReminder, error) {
+	var reminders []models.ContestReminder
+	err := r.db.Preload("Contest").Where("contest_id=?", contestID).Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// ResetRescheduledReminders marks a contest's reminders as pending again when the contest
+// moved and their notify time is still ahead, so they fire relative to the new start time
+func (r *ContestRepository) ResetRescheduledReminders(contestID string, now time.Time) (int64, error) {
+	result := r.db.Model(&models.ContestReminder{}).
+		Where("contest_id = ? AND is_notified = ?", contestID, true).
+		Where("? - remind_before_minutes * interval '1 minute' > ?",
+			r.db.Model(&models.Contest{}).Select("start_time").Where("id = ?", contestID), now).
+		Update("is_notified", false)
+	return result.RowsAffected, result.Error
+}
+
+// UpsertParticipation creates or updates a user's result in a contest
+func (r *ContestRepository) UpsertParticipation(participation *models.ContestParticipation) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "contest_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"rank", "old_rating", "new_rating", "rating_change", "problems_solved", "total_problems", "updated_at",
+		}),
+	}).Create(participation).Error
+}
+
+// FindParticipationsByUserID retrieves a user's contest results, most recent contest first
+func (r *ContestRepository) FindParticipationsByUserID(userID, platform string, page, limit int) ([]models.ContestParticipation, int64, error) {
+	var participations []models.ContestParticipation
+	var total int64
+
+	query := r.db.Model(&models.ContestParticipation{}).Where("contest_participations.user_id = ?", userID)
+	if platform != "" {
+		query = query.Where("contest_participations.platform = ?", platform)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err = query.Joins("Contest").
+		Order(`"Contest"."start_time" DESC`).
+		Offset(offset).Limit(limit).
+		Find(&participations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return participations, total, nil
+}
+
+// FindParticipationsByContestID retrieves the results in a contest ordered by rank, with User
+// preloaded. If friendsOf is set only that user's and their friends' results are returned.
+func (r *ContestRepository) FindParticipationsByContestID(contestID, friendsOf string) ([]models.ContestParticipation, error) {
+	var participations []models.ContestParticipation
+
+	query := r.db.Preload("User").Where("contest_id = ?", contestID)
+	if friendsOf != "" {
+		query = query.Where("user_id = ? OR user_id IN (?)", friendsOf,
+			r.db.Model(&models.Friend{}).Select("friend_id").Where("user_id = ?", friendsOf))
+	}
+
+	err := query.Order("rank ASC").Find(&participations).Error
+	if err != nil {
+		return nil, err
+	}
+	return participations, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"dojo/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NoteRepository struct {
+	db *gorm.DB
+}
+
+func NewNoteRepository(db *gorm.DB) *NoteRepository {
+	return &NoteRepository{db: db}
+}
+
+// Create creates a new note
+func (r *NoteRepository) Create(note *models.UserNote) error {
+	return r.db.Create(note).Error
+}
+
+// FindByID retrieves a note by ID
+func (r *NoteRepository) FindByID(id string) (*models.UserNote, error) {
+	var note models.UserNote
+	err := r.db.Preload("Problem").Where("id = ?", id).First(&note).Error
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// FindByUserAndProblem retrieves a user's notes on a problem, favorites first then newest
+func (r *NoteRepository) FindByUserAndProblem(userID, problemID string) ([]models.UserNote, error) {
+	var notes []models.UserNote
+	err := r.db.Preload("Problem").
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		Order("is_favorite DESC, updated_at DESC").
+		Find(&notes).Error
+	return notes, err
+}
+
+// FindByUserID retrieves a user's notes with their problems and pagination. With a search
+// query notes are matched with full-text search and ordered by relevance, otherwise the most
+// recently updated come first.
+func (r *NoteRepository) FindByUserID(userID, search string, favoritesOnly bool, page, limit int) ([]models.UserNote, int64, error) {
+	var notes []models.UserNote
+	var total int64
+
+	query := r.db.Model(&models.UserNote{}).Where("user_id = ?", userID)
+	if favoritesOnly {
+		query = query.Where("is_favorite = ?", true)
+	}
+	if search != "" {
+		// Uses the idx_user_notes_content_search expression index
+		query = query.Where("to_tsvector('english', content) @@ websearch_to_tsquery('english', ?)", search)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if search != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(to_tsvector('english', content), websearch_to_tsquery('english', ?)) DESC",
+			Vars: []interface{}{search},
+		}})
+	}
+	offset := (page - 1) * limit
+	err := query.Preload("Problem").
+		Order("updated_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&notes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notes, total, nil
+}
+
+// Update updates an existing note
+func (r *NoteRepository) Update(note *models.UserNote) error {
+	return r.db.Omit("Problem").Save(note).Error
+}
+
+// Delete deletes a note by ID
+func (r *NoteRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&models.UserNote{}).Error
+}
@@ -1 +1,82 @@
 package repository
+
+import (
+	"dojo/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create creates a new notification
+func (r *NotificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// FindByID retrieves a notification by ID
+func (r *NotificationRepository) FindByID(id string) (*models.Notification, error) {
+	var notification models.Notification
+	err := r.db.Where("id = ?", id).First(&notification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// FindByUserID retrieves a user's notifications (newest first) with pagination
+func (r *NotificationRepository) FindByUserID(userID string, unreadOnly bool, page, limit int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread counts the unread notifications of a user
+func (r *NotificationRepository) CountUnread(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("user_id = ? AND is_read = ?", userID, false).Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead marks the given notifications of a user as read
+func (r *NotificationRepository) MarkAsRead(userID string, ids []uuid.UUID) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND id IN ? AND is_read = ?", userID, ids, false).
+		Update("is_read", true)
+	return result.RowsAffected, result.Error
+}
+
+// MarkAllAsRead marks every unread notification of a user as read
+func (r *NotificationRepository) MarkAllAsRead(userID string) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Update("is_read", true)
+	return result.RowsAffected, result.Error
+}
+
+// Delete deletes a notification belonging to a user
+func (r *NotificationRepository) Delete(userID, id string) (int64, error) {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Notification{})
+	return result.RowsAffected, result.Error
+}
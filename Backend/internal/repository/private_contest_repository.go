@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"dojo/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PrivateContestRepository struct {
+	db *gorm.DB
+}
+
+func NewPrivateContestRepository(db *gorm.DB) *PrivateContestRepository {
+	return &PrivateContestRepository{db: db}
+}
+
+// Create creates a private contest with its problems and the Contest row holding its schedule
+func (r *PrivateContestRepository) Create(contest *models.PrivateContest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&contest.Contest).Error; err != nil {
+			return err
+		}
+		contest.ContestID = contest.Contest.ID
+		return tx.Omit("Contest").Create(contest).Error
+	})
+}
+
+// FindByID retrieves a private contest with its schedule, problems (in order) and participants
+func (r *PrivateContestRepository) FindByID(id string) (*models.PrivateContest, error) {
+	var contest models.PrivateContest
+	err := r.db.Preload("Contest").
+		Preload("Problems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Problems.Problem").
+		Preload("Participants.User").
+		Where("id = ?", id).
+		First(&contest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// FindByInviteCode retrieves a private contest by its invite code
+func (r *PrivateContestRepository) FindByInviteCode(inviteCode string) (*models.PrivateContest, error) {
+	var contest models.PrivateContest
+	err := r.db.Preload("Contest").Where("invite_code = ?", inviteCode).First(&contest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// FindUserContests retrieves the private contests a user organizes or joined, latest first
+func (r *PrivateContestRepository) FindUserContests(userID string) ([]models.PrivateContest, error) {
+	var contests []models.PrivateContest
+	err := r.db.Joins("Contest").
+		Preload("Participants").
+		Where("private_contests.created_by = ? OR private_contests.id IN (SELECT private_contest_id FROM private_contest_participants WHERE user_id = ?)", userID, userID).
+		Order(`"Contest".start_time DESC`).
+		Find(&contests).Error
+	return contests, err
+}
+
+// AddParticipant adds a user to a private contest
+func (r *PrivateContestRepository) AddParticipant(participant *models.PrivateContestParticipant) error {
+	return r.db.Create(participant).Error
+}
+
+// IsParticipant checks if a user joined a private contest
+func (r *PrivateContestRepository) IsParticipant(contestID, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PrivateContestParticipant{}).
+		Where("private_contest_id = ? AND user_id = ?", contestID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateSubmission records a submission
+func (r *PrivateContestRepository) CreateSubmission(submission *models.PrivateContestSubmission) error {
+	return r.db.Create(submission).Error
+}
+
+// FindSubmissions retrieves every submission in a private contest, oldest first
+func (r *PrivateContestRepository) FindSubmissions(contestID string) ([]models.PrivateContestSubmission, error) {
+	var submissions []models.PrivateContestSubmission
+	err := r.db.Where("private_contest_id = ?", contestID).
+		Order("submitted_at ASC").
+		Find(&submissions).Error
+	return submissions, err
+}
+
+// Unfreeze lifts the standings freeze. Returns false if it was already lifted.
+func (r *PrivateContestRepository) Unfreeze(id string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.PrivateContest{}).
+		Where("id = ? AND unfrozen_at IS NULL", id).
+		Update("unfrozen_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GenerateUniqueInviteCode generates a unique 8-character invite code
+func (r *PrivateContestRepository) GenerateUniqueInviteCode() (string, error) {
+	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	const codeLength = 8
+
+	for attempts := 0; attempts < 10; attempts++ {
+		code := ""
+		uid := uuid.New()
+		for i := 0; i < codeLength; i++ {
+			code += string(charset[uid[i]%byte(len(charset))])
+		}
+
+		// Check if code already exists
+		var count int64
+		if err := r.db.Model(&models.PrivateContest{}).Where("invite_code = ?", code).Count(&count).Error; err != nil {
+			return "", err
+		}
+
+		if count == 0 {
+			return code, nil
+		}
+	}
+
+	return "", gorm.ErrInvalidData
+}
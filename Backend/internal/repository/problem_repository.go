@@ -3,14 +3,25 @@ package repository
 import (
 	"dojo/internal/models"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProblemRepository struct {
 	db *gorm.DB
 }
 
+// TagStat is a user's progress on the problems with a tag
+type TagStat struct {
+	Tag       string
+	Attempted int
+	Solved    int
+}
+
 func NewProblemRepository(db *gorm.DB) *ProblemRepository {
 	return &ProblemRepository{db: db}
 }
@@ -20,6 +31,19 @@ func (r *ProblemRepository) Create(problem *models.Problem) error {
 	return r.db.Create(problem).Error
 }
 
+// CreateMissing stores the problems whose platform ID isn't taken yet and skips the rest, so
+// concurrent imports of the same problem store it once. Skipped problems keep an ID that was
+// never stored, so look them up again by platform ID.
+func (r *ProblemRepository) CreateMissing(problems []models.Problem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "platform"}, {Name: "platform_problem_id"}},
+		DoNothing: true,
+	}).Create(&problems).Error
+}
+
 // FindByID retrieves a problem by ID
 func (r *ProblemRepository) FindByID(id string) (*models.Problem, error) {
 	var problem models.Problem
@@ -43,9 +67,13 @@ func (r *ProblemRepository) FindAll(filters map[string]interface{}, page, limit
 		query = query.Where("platform = ?", platform)
 	}
 
-	if search, ok := filters["search"].(string); ok && search != "" {
-		searchPattern := "%" + strings.ToLower(search) + "%"
-		query = query.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", searchPattern, searchPattern)
+	// Full-text search over the search_vector column (title, tags and description) with prefix
+	// matching, falling back to trigram similarity on the title to tolerate typos
+	search, _ := filters["search"].(string)
+	search = strings.TrimSpace(search)
+	tsQuery := prefixTSQuery(search)
+	if tsQuery != "" {
+		query = query.Where("search_vector @@ to_tsquery('english', ?) OR ? <% title", tsQuery, search)
 	}
 
 	if tags, ok := filters["tags"].([]string); ok && len(tags) > 0 {
@@ -57,6 +85,22 @@ func (r *ProblemRepository) FindAll(filters map[string]interface{}, page, limit
 		return nil, 0, err
 	}
 
+	sort, _ := filters["sort"].(string)
+	switch sort {
+	case "difficulty":
+		query = query.Order("CASE difficulty WHEN 'easy' THEN 1 WHEN 'medium' THEN 2 WHEN 'hard' THEN 3 ELSE 4 END, rating ASC")
+	case "acceptance_rate":
+		query = query.Order("acceptance_rate DESC")
+	case "relevance", "":
+		// Relevance needs a search query, otherwise newest first
+		if tsQuery != "" {
+			query = query.Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "ts_rank(search_vector, to_tsquery('english', ?)) + word_similarity(?, title) DESC",
+				Vars: []interface{}{tsQuery, search},
+			}})
+		}
+	}
+
 	// Pagination
 	offset := (page - 1) * limit
 	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&problems).Error; err != nil {
@@ -66,11 +110,130 @@ func (r *ProblemRepository) FindAll(filters map[string]interface{}, page, limit
 	return problems, total, nil
 }
 
+// FindByIDs retrieves the problems with the given IDs
+func (r *ProblemRepository) FindByIDs(ids []uuid.UUID) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := r.db.Where("id IN ?", ids).Find(&problems).Error
+	return problems, err
+}
+
+// FindByPlatformIDs retrieves a platform's problems with the given platform IDs
+func (r *ProblemRepository) FindByPlatformIDs(platform string, platformIDs []string) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := r.db.Where("platform = ? AND platform_problem_id IN ?", platform, platformIDs).Find(&problems).Error
+	return problems, err
+}
+
+// FindByPlatformSlugs retrieves a platform's problems by slug
+func (r *ProblemRepository) FindByPlatformSlugs(platform string, slugs []string) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := r.db.Where("platform = ? AND slug IN ?", platform, slugs).Find(&problems).Error
+	return problems, err
+}
+
+// FindByPlatformIDPattern retrieves a platform's problems whose platform ID matches a
+// POSIX regular expression, ordered by platform ID
+func (r *ProblemRepository) FindByPlatformIDPattern(platform, pattern string) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := r.db.Where("platform = ? AND platform_problem_id ~ ?", platform, pattern).
+		Order("platform_problem_id ASC").
+		Find(&problems).Error
+	return problems, err
+}
+
+// FindPopularTags retrieves the most common tags in the problem catalogue
+func (r *ProblemRepository) FindPopularTags(platform string, limit int) ([]string, error) {
+	var tags []string
+	query := r.db.Model(&models.Problem{}).
+		Select("tag").
+		Joins("CROSS JOIN LATERAL unnest(tags) AS tag")
+	if platform != "" {
+		query = query.Where("platform = ?", platform)
+	}
+	err := query.Group("tag").
+		Order("COUNT(*) DESC").
+		Limit(limit).
+		Pluck("tag", &tags).Error
+	return tags, err
+}
+
+// FindUserTagStats counts the problems a user attempted and solved per tag
+func (r *ProblemRepository) FindUserTagStats(userID string) ([]TagStat, error) {
+	var stats []TagStat
+	err := r.db.Raw(`SELECT tag, COUNT(*) AS attempted, COUNT(*) FILTER (WHERE upp.is_solved) AS solved
+		FROM user_problem_progress upp
+		JOIN problems p ON p.id = upp.problem_id
+		CROSS JOIN LATERAL unnest(p.tags) AS tag
+		WHERE upp.user_id = ?
+		GROUP BY tag`, userID).Scan(&stats).Error
+	return stats, err
+}
+
+// FindSolvedProblemIDs returns which of the given problems the user has solved
+func (r *ProblemRepository) FindSolvedProblemIDs(userID string, problemIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var solved []uuid.UUID
+	err := r.db.Model(&models.UserProblemProgress{}).
+		Where("user_id = ? AND problem_id IN ? AND is_solved = ?", userID, problemIDs, true).
+		Pluck("problem_id", &solved).Error
+	return solved, err
+}
+
+// FindSolvedRatings retrieves the ratings of the rated problems a user solved
+func (r *ProblemRepository) FindSolvedRatings(userID string) ([]int, error) {
+	var ratings []int
+	err := r.db.Model(&models.Problem{}).
+		Joins("JOIN user_problem_progress upp ON upp.problem_id = problems.id").
+		Where("upp.user_id = ? AND upp.is_solved = ? AND problems.rating > 0", userID, true).
+		Pluck("problems.rating", &ratings).Error
+	return ratings, err
+}
+
+// FindUnsolvedByTag retrieves problems with a tag that the user has not solved. Rated problems
+// must fall within [minRating, maxRating]; unrated ones must have the given difficulty.
+// Problems closest to targetRating and with the highest acceptance rate come first.
+func (r *ProblemRepository) FindUnsolvedByTag(userID, tag, platform string, minRating, maxRating, targetRating int, difficulty string, limit int) ([]models.Problem, error) {
+	var problems []models.Problem
+	query := r.db.Where("? = ANY(tags)", tag).
+		Where("(rating BETWEEN ? AND ?) OR (rating = 0 AND difficulty = ?)", minRating, maxRating, difficulty).
+		Where("NOT EXISTS (SELECT 1 FROM user_problem_progress upp WHERE upp.problem_id = problems.id AND upp.user_id = ? AND upp.is_solved)", userID)
+	if platform != "" {
+		query = query.Where("platform = ?", platform)
+	}
+	err := query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "CASE WHEN rating > 0 THEN ABS(rating - ?) ELSE 150 END, acceptance_rate DESC",
+		Vars: []interface{}{targetRating},
+	}}).
+		Limit(limit).
+		Find(&problems).Error
+	return problems, err
+}
+
 // Update updates an existing problem
 func (r *ProblemRepository) Update(problem *models.Problem) error {
 	return r.db.Save(problem).Error
 }
 
+// UpdateContent updates a problem's statement, examples and hints
+func (r *ProblemRepository) UpdateContent(problem *models.Problem) error {
+	return r.db.Model(problem).Select("description", "constraints", "examples", "hints").Updates(problem).Error
+}
+
+// FindMissingContent retrieves up to limit problems on a platform without a statement, least
+// recently updated first
+func (r *ProblemRepository) FindMissingContent(platform string, limit int) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := r.db.Where("platform = ? AND (description IS NULL OR description = '')", platform).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&problems).Error
+	return problems, err
+}
+
+// Touch bumps a problem's updated_at, moving it to the back of FindMissingContent
+func (r *ProblemRepository) Touch(id uuid.UUID) error {
+	return r.db.Model(&models.Problem{}).Where("id = ?", id).Update("updated_at", time.Now()).Error
+}
+
 // Delete deletes a problem by ID
 func (r *ProblemRepository) Delete(id string) error {
 	return r.db.Delete(&models.Problem{}, "id = ?", id).Error
@@ -92,6 +255,18 @@ func (r *ProblemRepository) ExistsByPlatformID(platform, platformID string) (boo
 	return count > 0, err
 }
 
+// prefixTSQuery turns free text into a tsquery where every word matches as a prefix,
+// e.g. "binary sea" becomes "binary:* & sea:*". Returns "" if there are no words.
+func prefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
 // GetDB returns the underlying database connection
 func (r *ProblemRepository) GetDB() *gorm.DB {
 	return r.db
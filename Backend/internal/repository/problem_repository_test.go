@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		want   string
+	}{
+		{"single word", "graph", "graph:*"},
+		{"words are prefixes joined with and", "Binary sea", "binary:* & sea:*"},
+		{"empty", "", ""},
+		{"whitespace only", " \t\n ", ""},
+		{"operators only", `&|!():*'`, ""},
+		{"operators between words", "dp & !greedy | (bfs)", "dp:* & greedy:* & bfs:*"},
+		{"prefix and weight syntax", "tree:* node:A", "tree:* & node:* & a:*"},
+		{"quotes", `'two' "pointers" it's`, "two:* & pointers:* & it:* & s:*"},
+		{"backslash", `a\b`, "a:* & b:*"},
+		{"phrase operator", "dfs <-> bfs <2> x", "dfs:* & bfs:* & 2:* & x:*"},
+		{"digits", "1700A div2", "1700a:* & div2:*"},
+		{"unicode letters", "Zählen 木", "zählen:* & 木:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prefixTSQuery(tt.search)
+			if got != tt.want {
+				t.Errorf("prefixTSQuery(%q) = %q, want %q", tt.search, got, tt.want)
+			}
+
+			// Every operand must be a plain word so to_tsquery can't fail on the input
+			if got == "" {
+				return
+			}
+			for _, operand := range strings.Split(got, " & ") {
+				word := strings.TrimSuffix(operand, ":*")
+				if word == "" || strings.IndexFunc(word, func(r rune) bool {
+					return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+				}) >= 0 {
+					t.Errorf("operand %q of %q is not a prefix-matched word", operand, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"dojo/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// CreateIfMissing schedules a review unless the user already has one for the problem
+func (r *ReviewRepository) CreateIfMissing(review *models.ProblemReview) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(review).Error
+}
+
+// FindByUserAndProblem retrieves a user's review of a problem
+func (r *ReviewRepository) FindByUserAndProblem(userID, problemID string) (*models.ProblemReview, error) {
+	var review models.ProblemReview
+	err := r.db.Preload("Problem").
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		First(&review).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindDue retrieves a user's reviews due before the given time, most overdue first
+func (r *ReviewRepository) FindDue(userID string, before time.Time, limit int) ([]models.ProblemReview, int64, error) {
+	var reviews []models.ProblemReview
+	var total int64
+
+	query := r.db.Model(&models.ProblemReview{}).Where("user_id = ? AND due_at < ?", userID, before)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Problem").
+		Order("due_at ASC").
+		Limit(limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// CountScheduled counts a user's reviews due at or after the given time
+func (r *ReviewRepository) CountScheduled(userID string, from time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ProblemReview{}).
+		Where("user_id = ? AND due_at >= ?", userID, from).
+		Count(&count).Error
+	return count, err
+}
+
+// Update updates an existing review
+func (r *ReviewRepository) Update(review *models.ProblemReview) error {
+	return r.db.Omit("Problem").Save(review).Error
+}
+
+// Delete removes a user's review of a problem
+func (r *ReviewRepository) Delete(userID, problemID string) (int64, error) {
+	result := r.db.Where("user_id = ? AND problem_id = ?", userID, problemID).Delete(&models.ProblemReview{})
+	return result.RowsAffected, result.Error
+}
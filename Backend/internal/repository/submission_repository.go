@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"dojo/internal/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubmissionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubmissionRepository(db *gorm.DB) *SubmissionRepository {
+	return &SubmissionRepository{db: db}
+}
+
+// CreateAndSummarize logs a submission and recomputes the user's progress on the problem
+func (r *SubmissionRepository) CreateAndSummarize(submission *models.ProblemSubmission) (*models.UserProblemProgress, error) {
+	var progress *models.UserProblemProgress
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Problem").Create(submission).Error; err != nil {
+			return err
+		}
+		var err error
+		progress, err = summarize(tx, submission.UserID, submission.ProblemID)
+		return err
+	})
+	return progress, err
+}
+
+// ImportAndSummarize stores imported submissions of one user and source, skipping ones
+// imported before, and recomputes the user's progress on every problem that got a new
+// submission. Pending submissions among them are resolved. Returns the number of new
+// submissions.
+func (r *SubmissionRepository) ImportAndSummarize(userID uuid.UUID, source string, submissions []models.ProblemSubmission) (int, error) {
+	if len(submissions) == 0 {
+		return 0, nil
+	}
+
+	externalIDs := make([]string, len(submissions))
+	for i, submission := range submissions {
+		externalIDs[i] = submission.ExternalID
+	}
+
+	var newSubmissions []models.ProblemSubmission
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND source = ? AND external_id IN ?", userID, source, externalIDs).
+			Delete(&models.PendingSubmission{}).Error
+		if err != nil {
+			return err
+		}
+
+		var existing []string
+		err = tx.Model(&models.ProblemSubmission{}).
+			Where("user_id = ? AND source = ? AND external_id IN ?", userID, source, externalIDs).
+			Pluck("external_id", &existing).Error
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool, len(existing))
+		for _, id := range existing {
+			seen[id] = true
+		}
+
+		var changed []uuid.UUID
+		isChanged := make(map[uuid.UUID]bool)
+		for _, submission := range submissions {
+			if seen[submission.ExternalID] {
+				continue
+			}
+			seen[submission.ExternalID] = true
+			submission.UserID = userID
+			submission.Source = source
+			newSubmissions = append(newSubmissions, submission)
+			if !isChanged[submission.ProblemID] {
+				isChanged[submission.ProblemID] = true
+				changed = append(changed, submission.ProblemID)
+			}
+		}
+		if len(newSubmissions) == 0 {
+			return nil
+		}
+
+		err = tx.Omit("User", "Problem").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			CreateInBatches(&newSubmissions, 500).Error
+		if err != nil {
+			return err
+		}
+
+		// Progress rows are locked in a fixed order so concurrent imports can't deadlock
+		sort.Slice(changed, func(i, j int) bool { return changed[i].String() < changed[j].String() })
+		for _, problemID := range changed {
+			if _, err := summarize(tx, userID, problemID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(newSubmissions), nil
+}
+
+// SavePending stores imported submissions whose problems aren't in the catalogue yet, skipping
+// ones already pending
+func (r *SubmissionRepository) SavePending(pending []models.PendingSubmission) error {
+	if len(pending) == 0 {
+		return nil
+	}
+	return r.db.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(&pending).Error
+}
+
+// FindPending retrieves a user's pending submissions from a source, oldest first
+func (r *SubmissionRepository) FindPending(userID, source string) ([]models.PendingSubmission, error) {
+	var pending []models.PendingSubmission
+	err := r.db.Where("user_id = ? AND source = ?", userID, source).
+		Order("submitted_at ASC").
+		Find(&pending).Error
+	return pending, err
+}
+
+// FindLatestImport returns when the user's newest submission imported from a source was made,
+// or nil if nothing was imported yet
+func (r *SubmissionRepository) FindLatestImport(userID, source string) (*time.Time, error) {
+	var latest *time.Time
+	err := r.db.Model(&models.ProblemSubmission{}).
+		Select("MAX(submitted_at)").
+		Where("user_id = ? AND source = ?", userID, source).
+		Scan(&latest).Error
+	return latest, err
+}
+
+// FindByUserAndProblem retrieves a user's submissions on a problem, newest first, with pagination
+func (r *SubmissionRepository) FindByUserAndProblem(userID, problemID string, page, limit int) ([]models.ProblemSubmission, int64, error) {
+	var submissions []models.ProblemSubmission
+	var total int64
+
+	query := r.db.Model(&models.ProblemSubmission{}).Where("user_id = ? AND problem_id = ?", userID, problemID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("submitted_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&submissions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return submissions, total, nil
+}
+
+// FindProgress retrieves a user's progress summary on a problem
+func (r *SubmissionRepository) FindProgress(userID, problemID string) (*models.UserProblemProgress, error) {
+	var progress models.UserProblemProgress
+	err := r.db.Where("user_id = ? AND problem_id = ?", userID, problemID).First(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// BackfillFromProgress logs the attempts recorded before submissions existed, so recomputed
+// summaries keep them: one submission per missing attempt, the last of them accepted if the
+// problem was solved. They are placed at the recorded solve or attempt time, a second apart and
+// before any submission logged since. Safe to run repeatedly.
+func (r *SubmissionRepository) BackfillFromProgress() (int64, error) {
+	result := r.db.Exec(`INSERT INTO problem_submissions (id, user_id, problem_id, verdict, submitted_at, source, created_at)
+		SELECT gen_random_uuid(), m.user_id, m.problem_id,
+			CASE WHEN m.needs_accepted AND n = m.missing THEN ? ELSE ? END,
+			m.anchor - make_interval(secs => m.missing - n + CASE WHEN m.needs_accepted OR m.logged = 0 THEN 0 ELSE 1 END),
+			?, NOW()
+		FROM (
+			SELECT p.user_id, p.problem_id, s.needs_accepted,
+				GREATEST(p.attempts - s.logged, CASE WHEN s.needs_accepted THEN 1 ELSE 0 END) AS missing,
+				s.logged,
+				LEAST(COALESCE(p.solved_at, p.last_attempt, p.created_at), s.first_submitted_at) AS anchor
+			FROM user_problem_progress p
+			CROSS JOIN LATERAL (
+				SELECT COUNT(*) AS logged, MIN(submitted_at) AS first_submitted_at,
+					p.is_solved AND COUNT(*) FILTER (WHERE verdict = ?) = 0 AS needs_accepted
+				FROM problem_submissions
+				WHERE user_id = p.user_id AND problem_id = p.problem_id
+			) s
+		) m
+		CROSS JOIN LATERAL generate_series(1, m.missing) AS n
+		WHERE m.missing > 0`,
+		models.VerdictAccepted, models.VerdictOther, models.SubmissionSourceManual, models.VerdictAccepted)
+	return result.RowsAffected, result.Error
+}
+
+// summarize recomputes a user's progress on a problem from their submissions. The progress row
+// is created if needed and locked first, so concurrent writers summarize one after another.
+func summarize(tx *gorm.DB, userID, problemID uuid.UUID) (*models.UserProblemProgress, error) {
+	err := tx.Omit("User", "Problem").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "problem_id"}},
+			DoNothing: true,
+		}).
+		Create(&models.UserProblemProgress{UserID: userID, ProblemID: problemID}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var progress models.UserProblemProgress
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		First(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var submissions []models.ProblemSubmission
+	err = tx.Select("verdict", "time_spent_seconds", "submitted_at").
+		Where("user_id = ? AND problem_id = ?", userID, problemID).
+		Order("submitted_at, created_at").
+		Find(&submissions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summarizeSubmissions(&progress, submissions)
+	if err := tx.Omit("User", "Problem").Save(&progress).Error; err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// summarizeSubmissions sets the summary fields of progress from the user's submissions on the
+// problem, given oldest first
+func summarizeSubmissions(progress *models.UserProblemProgress, submissions []models.ProblemSubmission) {
+	progress.IsSolved = false
+	progress.SolvedAt = nil
+	progress.Attempts = len(submissions)
+	progress.LastAttempt = nil
+	progress.LastVerdict = ""
+	progress.TimeSpentSeconds = 0
+
+	for _, submission := range submissions {
+		submittedAt := submission.SubmittedAt
+		if submission.Verdict == models.VerdictAccepted && !progress.IsSolved {
+			progress.IsSolved = true
+			progress.SolvedAt = &submittedAt
+		}
+		progress.LastAttempt = &submittedAt
+		progress.LastVerdict = submission.Verdict
+		progress.TimeSpentSeconds += submission.TimeSpentSeconds
+	}
+}
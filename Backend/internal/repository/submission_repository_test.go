@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"dojo/internal/models"
+	"testing"
+	"time"
+)
+
+func TestSummarizeSubmissions(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	submission := func(verdict string, minutes, timeSpent int) models.ProblemSubmission {
+		return models.ProblemSubmission{Verdict: verdict, SubmittedAt: at(minutes), TimeSpentSeconds: timeSpent}
+	}
+
+	tests := []struct {
+		name        string
+		submissions []models.ProblemSubmission
+		solvedAt    *time.Time
+		attempts    int
+		lastAttempt *time.Time
+		lastVerdict string
+		timeSpent   int
+	}{
+		{
+			name: "no submissions",
+		},
+		{
+			name: "only wrong attempts",
+			submissions: []models.ProblemSubmission{
+				submission(models.VerdictWrongAnswer, 0, 600),
+				submission(models.VerdictTimeLimitExceeded, 10, 300),
+			},
+			attempts:    2,
+			lastAttempt: ptr(at(10)),
+			lastVerdict: models.VerdictTimeLimitExceeded,
+			timeSpent:   900,
+		},
+		{
+			name: "solved after wrong attempts",
+			submissions: []models.ProblemSubmission{
+				submission(models.VerdictWrongAnswer, 0, 600),
+				submission(models.VerdictCompilationError, 5, 0),
+				submission(models.VerdictAccepted, 20, 900),
+			},
+			solvedAt:    ptr(at(20)),
+			attempts:    3,
+			lastAttempt: ptr(at(20)),
+			lastVerdict: models.VerdictAccepted,
+			timeSpent:   1500,
+		},
+		{
+			name: "first accepted submission is the solve",
+			submissions: []models.ProblemSubmission{
+				submission(models.VerdictAccepted, 0, 0),
+				submission(models.VerdictWrongAnswer, 30, 0),
+				submission(models.VerdictAccepted, 40, 0),
+			},
+			solvedAt:    ptr(at(0)),
+			attempts:    3,
+			lastAttempt: ptr(at(40)),
+			lastVerdict: models.VerdictAccepted,
+		},
+		{
+			name: "stays solved after a later wrong attempt",
+			submissions: []models.ProblemSubmission{
+				submission(models.VerdictAccepted, 0, 0),
+				submission(models.VerdictRuntimeError, 30, 120),
+			},
+			solvedAt:    ptr(at(0)),
+			attempts:    2,
+			lastAttempt: ptr(at(30)),
+			lastVerdict: models.VerdictRuntimeError,
+			timeSpent:   120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Stale values from the previous summary must be replaced
+			progress := models.UserProblemProgress{
+				IsSolved:         true,
+				SolvedAt:         ptr(at(-60)),
+				Attempts:         7,
+				LastAttempt:      ptr(at(-60)),
+				LastVerdict:      models.VerdictOther,
+				TimeSpentSeconds: 42,
+			}
+			summarizeSubmissions(&progress, tt.submissions)
+
+			if progress.IsSolved != (tt.solvedAt != nil) {
+				t.Errorf("IsSolved = %v, want %v", progress.IsSolved, tt.solvedAt != nil)
+			}
+			assertTime(t, "SolvedAt", progress.SolvedAt, tt.solvedAt)
+			assertTime(t, "LastAttempt", progress.LastAttempt, tt.lastAttempt)
+			if progress.Attempts != tt.attempts {
+				t.Errorf("Attempts = %d, want %d", progress.Attempts, tt.attempts)
+			}
+			if progress.LastVerdict != tt.lastVerdict {
+				t.Errorf("LastVerdict = %q, want %q", progress.LastVerdict, tt.lastVerdict)
+			}
+			if progress.TimeSpentSeconds != tt.timeSpent {
+				t.Errorf("TimeSpentSeconds = %d, want %d", progress.TimeSpentSeconds, tt.timeSpent)
+			}
+		})
+	}
+}
+
+func assertTime(t *testing.T, field string, got, want *time.Time) {
+	t.Helper()
+
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", field, got, want)
+	case !got.Equal(*want):
+		t.Errorf("%s = %s, want %s", field, got, want)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -3,9 +3,13 @@ package repository
 import (
 	"dojo/internal/models"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -48,6 +52,13 @@ func (r *UserRepository) FindByUsername(username string) (*models.User, error) {
 	return &user, err
 }
 
+// FindByCalendarToken retrieves a user by their calendar feed token
+func (r *UserRepository) FindByCalendarToken(token string) (*models.User, error) {
+	var user models.User
+	err := r.db.First(&user, "calendar_token=?", token).Error
+	return &user, err
+}
+
 // Upadate updates an existing user in the database
 func (r *UserRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
@@ -114,3 +125,102 @@ func (r *UserRepository) UpsertPlatformStat(stat *models.UserPlatformStat) error
 	stat.ID = existing.ID
 	return r.db.Save(stat).Error
 }
+
+// FindLinkedProfiles retrieves all profiles with a username set for at least one of the platforms
+func (r *UserRepository) FindLinkedProfiles(platforms []string) ([]models.UserProfile, error) {
+	var linked []clause.Expression
+	for _, platform := range platforms {
+		if column, ok := models.PlatformUsernameColumn(platform); ok {
+			linked = append(linked, clause.Neq{Column: column, Value: ""})
+		}
+	}
+
+	var profiles []models.UserProfile
+	if len(linked) == 0 {
+		return profiles, nil
+	}
+	err := r.db.Where(clause.Or(linked...)).Find(&profiles).Error
+	return profiles, err
+}
+
+// FindPlatformStatsSyncedSince retrieves platform stats refreshed after the given time
+func (r *UserRepository) FindPlatformStatsSyncedSince(since time.Time) ([]models.UserPlatformStat, error) {
+	var stats []models.UserPlatformStat
+	err := r.db.Select("user_id", "platform", "last_synced").Where("last_synced > ?", since).Find(&stats).Error
+	return stats, err
+}
+
+// CreateSyncLog stores the outcome of a platform sync
+func (r *UserRepository) CreateSyncLog(log *models.PlatformSyncLog) error {
+	return r.db.Create(log).Error
+}
+
+// FindSyncLogs retrieves a user's most recent sync logs, optionally for one platform
+func (r *UserRepository) FindSyncLogs(userID, platform string, limit int) ([]models.PlatformSyncLog, error) {
+	var logs []models.PlatformSyncLog
+	query := r.db.Where("user_id = ?", userID)
+	if platform != "" {
+		query = query.Where("platform = ?", platform)
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&logs).Error
+	return logs, err
+}
+
+// FindLatestSyncLogs retrieves the most recent sync log per platform for a user
+func (r *UserRepository) FindLatestSyncLogs(userID string) ([]models.PlatformSyncLog, error) {
+	var logs []models.PlatformSyncLog
+	err := r.db.Raw(`SELECT DISTINCT ON (platform) * FROM platform_sync_logs
+		WHERE user_id = ? ORDER BY platform, created_at DESC`, userID).Scan(&logs).Error
+	return logs, err
+}
+
+// DeleteOldSyncLogs removes sync logs older than the specified number of days
+func (r *UserRepository) DeleteOldSyncLogs(daysOld int) (int64, error) {
+	cutoffDate := time.Now().AddDate(0, 0, -daysOld)
+	result := r.db.Where("created_at < ?", cutoffDate).Delete(&models.PlatformSyncLog{})
+	return result.RowsAffected, result.Error
+}
+
+// CreateStatSnapshot stores a point-in-time copy of a platform stat
+func (r *UserRepository) CreateStatSnapshot(snapshot *models.PlatformStatSnapshot) error {
+	return r.db.Create(snapshot).Error
+}
+
+// FindStatHistory retrieves a user's stat snapshots downsampled to one (the latest) per
+// platform and bucket. bucket must be a date_trunc unit ("day", "week" or "month");
+// RecordedAt of each result is set to the start of its bucket.
+func (r *UserRepository) FindStatHistory(userID, platform, bucket string, from, to *time.Time) ([]models.PlatformStatSnapshot, error) {
+	switch bucket {
+	case "day", "week", "month":
+	default:
+		return nil, fmt.Errorf("invalid bucket: %s", bucket)
+	}
+	trunc := fmt.Sprintf("date_trunc('%s', s.recorded_at)", bucket)
+
+	conditions := []string{"s.user_id = ?"}
+	args := []interface{}{userID}
+	if platform != "" {
+		conditions = append(conditions, "s.platform = ?")
+		args = append(args, platform)
+	}
+	if from != nil {
+		conditions = append(conditions, "s.recorded_at >= ?")
+		args = append(args, *from)
+	}
+	if to != nil {
+		conditions = append(conditions, "s.recorded_at <= ?")
+		args = append(args, *to)
+	}
+
+	query := fmt.Sprintf(`SELECT DISTINCT ON (s.platform, %[1]s)
+			s.id, s.user_id, s.platform, s.rating, s.max_rating, s.problems_solved,
+			s.easy_problems_solved, s.med_problems_solved, s.hard_problems_solved,
+			s.contests_attended, s.global_rank, %[1]s AS recorded_at
+		FROM platform_stat_snapshots s
+		WHERE %[2]s
+		ORDER BY s.platform, %[1]s, s.recorded_at DESC`, trunc, strings.Join(conditions, " AND "))
+
+	var snapshots []models.PlatformStatSnapshot
+	err := r.db.Raw(query, args...).Scan(&snapshots).Error
+	return snapshots, err
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"dojo/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VirtualContestRepository struct {
+	db *gorm.DB
+}
+
+func NewVirtualContestRepository(db *gorm.DB) *VirtualContestRepository {
+	return &VirtualContestRepository{db: db}
+}
+
+// Create creates a virtual contest with its problems and participants, together with the
+// room its live updates are pushed to (the creator joins the room)
+func (r *VirtualContestRepository) Create(contest *models.VirtualContest, room *models.Room) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(room).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.RoomParticipant{RoomID: room.ID, UserID: contest.CreatedBy}).Error; err != nil {
+			return err
+		}
+
+		contest.RoomID = room.ID
+		return tx.Create(contest).Error
+	})
+}
+
+// FindByID retrieves a virtual contest with its problems (in order) and participants
+func (r *VirtualContestRepository) FindByID(id string) (*models.VirtualContest, error) {
+	var contest models.VirtualContest
+	err := r.db.Preload("Problems", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).
+		Preload("Problems.Problem").
+		Preload("Participants.User").
+		Where("id = ?", id).
+		First(&contest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// FindUserContests retrieves the virtual contests a user was invited to or created, newest first
+func (r *VirtualContestRepository) FindUserContests(userID string) ([]models.VirtualContest, error) {
+	var contests []models.VirtualContest
+	err := r.db.Preload("Participants.User").
+		Where("id IN (SELECT virtual_contest_id FROM virtual_contest_participants WHERE user_id = ?)", userID).
+		Order("created_at DESC").
+		Find(&contests).Error
+	return contests, err
+}
+
+// JoinParticipant accepts an invitation and adds the user to the contest room
+func (r *VirtualContestRepository) JoinParticipant(contest *models.VirtualContest, userID string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.VirtualContestParticipant{}).
+			Where("virtual_contest_id = ? AND user_id = ? AND status = ?", contest.ID, userID, models.VirtualParticipantInvited).
+			Updates(map[string]interface{}{
+				"status":    models.VirtualParticipantJoined,
+				"joined_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		participant := &models.RoomParticipant{RoomID: contest.RoomID, UserID: parseUUID(userID)}
+		return tx.Create(participant).Error
+	})
+}
+
+// Start sets the start time of a contest that hasn't started. Returns false if it already had.
+func (r *VirtualContestRepository) Start(id string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.VirtualContest{}).
+		Where("id = ? AND started_at IS NULL", id).
+		Update("started_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindRunningWithProblem retrieves the running contests the user joined that include the problem
+func (r *VirtualContestRepository) FindRunningWithProblem(userID, problemID string, now time.Time) ([]models.VirtualContest, error) {
+	var contests []models.VirtualContest
+	err := r.db.
+		Where("started_at <= ? AND started_at + duration_minutes * interval '1 minute' > ?", now, now).
+		Where("id IN (SELECT virtual_contest_id FROM virtual_contest_participants WHERE user_id = ? AND status = ?)",
+			userID, models.VirtualParticipantJoined).
+		Where("id IN (SELECT virtual_contest_id FROM virtual_contest_problems WHERE problem_id = ?)", problemID).
+		Find(&contests).Error
+	return contests, err
+}
+
+// RecordAttempt records a solve or a wrong attempt at a problem. Attempts after the problem
+// was solved are ignored; returns whether anything changed.
+func (r *VirtualContestRepository) RecordAttempt(submission *models.VirtualContestSubmission, solved bool, now time.Time) (bool, error) {
+	changed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.VirtualContestSubmission
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("virtual_contest_id = ? AND user_id = ? AND problem_id = ?",
+				submission.VirtualContestID, submission.UserID, submission.ProblemID).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if solved {
+				submission.SolvedAt = &now
+			} else {
+				submission.WrongAttempts = 1
+			}
+			changed = true
+			return tx.Create(submission).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if existing.SolvedAt != nil {
+			return nil
+		}
+		if solved {
+			existing.SolvedAt = &now
+		} else {
+			existing.WrongAttempts++
+		}
+		changed = true
+		return tx.Save(&existing).Error
+	})
+	return changed, err
+}
+
+// FindSubmissions retrieves every participant's attempts in a contest
+func (r *VirtualContestRepository) FindSubmissions(contestID string) ([]models.VirtualContestSubmission, error) {
+	var submissions []models.VirtualContestSubmission
+	err := r.db.Where("virtual_contest_id = ?", contestID).Find(&submissions).Error
+	return submissions, err
+}
@@ -4,7 +4,9 @@ import (
 	"dojo/internal/config"
 	"dojo/internal/handler"
 	"dojo/internal/middleware"
+	"dojo/internal/service/scrapper"
 	"dojo/internal/websocket"
+	"dojo/pkg/redis"
 
 	fiberws "github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
@@ -16,9 +18,20 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 	api := app.Group("/api")
 	// Health  Check
 	api.Get("/health", func(c *fiber.Ctx) error {
+		status := "ok"
+		redisStatus := "disabled"
+		if redis.Client != nil {
+			redisStatus = "ok"
+			if err := redis.HealthCheck(c.UserContext()); err != nil {
+				status = "degraded"
+				redisStatus = "unavailable"
+			}
+		}
 		return c.JSON(fiber.Map{
-			"status":  "ok",
-			"message": "Server is Running",
+			"status":   status,
+			"message":  "Server is Running",
+			"redis":    redisStatus,
+			"scrapers": scrapper.BreakerStates(),
 		})
 	})
 	// Auth Routes(PUBLIC WALEE!!)
@@ -37,8 +50,14 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 	// Public contest routes (no authentication required)
 	contestRoutes := api.Group("/contests")
 	{
-		contestRoutes.Get("", handlers.Contest.ListContests)
-		contestRoutes.Get("/:id", handlers.Contest.GetContest)
+		contestRoutes.Get("", middleware.OptionalAuthMiddleware(cfg), handlers.Contest.ListContests)
+		// Registered before /:id so "reminders" is not treated as a contest ID
+		contestRoutes.Get("/reminders", middleware.AuthMiddleware(cfg), handlers.Contest.ListReminders)
+		contestRoutes.Get("/calendar.ics", handlers.Contest.GetContestCalendar)
+		// Personal reminder feed, authenticated by the token in the URL so calendar apps can subscribe
+		contestRoutes.Get("/calendar/:token", handlers.Contest.GetReminderCalendar)
+		contestRoutes.Get("/:id", middleware.OptionalAuthMiddleware(cfg), handlers.Contest.GetContest)
+		contestRoutes.Get("/:id/participants", middleware.AuthMiddleware(cfg), handlers.Contest.GetContestParticipants)
 	}
 
 	// Protected routes(require authentication)
@@ -51,6 +70,9 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 			userRoutes.Patch("/account", handlers.User.UpdateUser)
 			userRoutes.Post("/change-password", handlers.User.ChangePassword)
 			userRoutes.Post("/sync-stats", handlers.User.SyncPlatformStats)
+			userRoutes.Get("/sync-status", handlers.User.GetSyncStatus)
+			userRoutes.Get("/stats/history", handlers.User.GetStatHistory)
+			userRoutes.Get("/contests", handlers.Contest.ListParticipations)
 
 		}
 		// Problem Routes
@@ -59,16 +81,35 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 			problemRoutes.Get("", handlers.Problem.ListProblems)
 			problemRoutes.Post("", handlers.Problem.CreateProblem)
 			problemRoutes.Post("/sync", handlers.Problem.SyncProblems)
+			problemRoutes.Post("/content/sync", handlers.Problem.EnrichProblems)
 			problemRoutes.Get("/solved/count", handlers.Problem.GetUserSolvedCount)
+			problemRoutes.Get("/recommendations", handlers.Recommendation.GetRecommendations)
 			problemRoutes.Get("/:id", handlers.Problem.GetProblem)
 			problemRoutes.Put("/:id", handlers.Problem.UpdateProblem)
 			problemRoutes.Delete("/:id", handlers.Problem.DeleteProblem)
+			problemRoutes.Post("/:id/content", handlers.Problem.FetchProblemContent)
 			problemRoutes.Post("/:id/solve", handlers.Problem.MarkProblemSolved)
+			problemRoutes.Get("/:id/submissions", handlers.Problem.GetSubmissionHistory)
+			problemRoutes.Post("/:id/submissions", handlers.Problem.LogSubmission)
+			problemRoutes.Get("/:id/notes", handlers.Note.GetProblemNotes)
+			problemRoutes.Post("/:id/notes", handlers.Note.CreateNote)
+			problemRoutes.Put("/:id/notes/:noteId", handlers.Note.UpdateNote)
+			problemRoutes.Delete("/:id/notes/:noteId", handlers.Note.DeleteNote)
+		}
+		// Personal notes across all problems
+		protected.Get("/notes", handlers.Note.ListNotes)
+		// Spaced repetition review queue
+		reviewRoutes := protected.Group("/reviews")
+		{
+			reviewRoutes.Get("/due", handlers.Review.GetDueReviews)
+			reviewRoutes.Post("/:problemId", handlers.Review.ReviewProblem)
+			reviewRoutes.Delete("/:problemId", handlers.Review.RemoveReview)
 		}
 		// Protected Contest Routes (sync and reminders require auth)
 		protectedContestRoutes := protected.Group("/contests")
 		{
 			protectedContestRoutes.Post("/sync", handlers.Contest.SyncContests)
+			protectedContestRoutes.Post("/calendar/token", handlers.Contest.ResetCalendarToken)
 			protectedContestRoutes.Post("/reminders", handlers.Contest.CreateReminder)
 			protectedContestRoutes.Delete("/reminders/:id", handlers.Contest.DeleteReminder)
 		}
@@ -107,6 +148,40 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 			socialRoutes.Get("/users/search", handlers.Social.SearchUsers)
 		}
 
+		// Notification Routes
+		notificationRoutes := protected.Group("/notifications")
+		{
+			notificationRoutes.Get("", handlers.Notification.GetNotifications)
+			notificationRoutes.Get("/unread-count", handlers.Notification.GetUnreadCount)
+			notificationRoutes.Patch("/read", handlers.Notification.MarkAsRead)
+			notificationRoutes.Patch("/read-all", handlers.Notification.MarkAllAsRead)
+			notificationRoutes.Patch("/:id/read", handlers.Notification.MarkOneAsRead)
+			notificationRoutes.Delete("/:id", handlers.Notification.DeleteNotification)
+		}
+
+		// Virtual Contest Routes (live updates are pushed over the contest room's WebSocket)
+		virtualContestRoutes := protected.Group("/virtual-contests")
+		{
+			virtualContestRoutes.Post("", handlers.VirtualContest.CreateVirtualContest)
+			virtualContestRoutes.Get("", handlers.VirtualContest.ListVirtualContests)
+			virtualContestRoutes.Get("/:id", handlers.VirtualContest.GetVirtualContest)
+			virtualContestRoutes.Post("/:id/join", handlers.VirtualContest.JoinVirtualContest)
+			virtualContestRoutes.Post("/:id/start", handlers.VirtualContest.StartVirtualContest)
+			virtualContestRoutes.Get("/:id/leaderboard", handlers.VirtualContest.GetLeaderboard)
+		}
+
+		// Private Contest Routes (the organizer shares the invite code)
+		privateContestRoutes := protected.Group("/private-contests")
+		{
+			privateContestRoutes.Post("", handlers.PrivateContest.CreatePrivateContest)
+			privateContestRoutes.Get("", handlers.PrivateContest.ListPrivateContests)
+			privateContestRoutes.Post("/join", handlers.PrivateContest.JoinPrivateContest)
+			privateContestRoutes.Get("/:id", handlers.PrivateContest.GetPrivateContest)
+			privateContestRoutes.Post("/:id/submissions", handlers.PrivateContest.Submit)
+			privateContestRoutes.Get("/:id/standings", handlers.PrivateContest.GetStandings)
+			privateContestRoutes.Post("/:id/unfreeze", handlers.PrivateContest.Unfreeze)
+		}
+
 		// Room Routes
 		roomRoutes := protected.Group("/rooms")
 		{
@@ -126,12 +201,18 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 }
 
 type Handlers struct {
-	Auth    *handler.AuthHandler
-	User    *handler.UserHandler
-	Problem *handler.ProblemHandler
-	Contest *handler.ContestHandler
-	Sheet   *handler.SheetHandler
-	Social  *handler.SocialHandler
-	Room    *handler.RoomHandler
-	RoomWS  *websocket.RoomHandler
+	Auth           *handler.AuthHandler
+	User           *handler.UserHandler
+	Problem        *handler.ProblemHandler
+	Note           *handler.NoteHandler
+	Recommendation *handler.RecommendationHandler
+	Review         *handler.ReviewHandler
+	Contest        *handler.ContestHandler
+	VirtualContest *handler.VirtualContestHandler
+	PrivateContest *handler.PrivateContestHandler
+	Sheet          *handler.SheetHandler
+	Social         *handler.SocialHandler
+	Notification   *handler.NotificationHandler
+	Room           *handler.RoomHandler
+	RoomWS         *websocket.RoomHandler
 }
@@ -75,6 +75,7 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.TokenResponse, er
 		CodeforcesUsername: req.CodeforcesUsername,
 		CodechefUsername:   req.CodechefUsername,
 		GFGUsername:        req.GFGUsername,
+		AtcoderUsername:    req.AtcoderUsername,
 	}
 
 	if err := s.userRepo.CreateProfile(profile); err != nil {
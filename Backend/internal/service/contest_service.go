@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"dojo/internal/dto"
 	"dojo/internal/models"
 	"dojo/internal/repository"
@@ -8,23 +9,35 @@ import (
 	"dojo/internal/utils"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// Calendar feeds include contests from the past week onwards, capped at calendarFeedLimit
+const (
+	calendarFeedLookback = 7 * 24 * time.Hour
+	calendarFeedLimit    = 500
+)
+
 type ContestService struct {
-	contestRepo *repository.ContestRepository
+	contestRepo         *repository.ContestRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
 }
 
-func NewContestService(contestRepo *repository.ContestRepository) *ContestService {
+func NewContestService(contestRepo *repository.ContestRepository, userRepo *repository.UserRepository, notificationService *NotificationService) *ContestService {
 	return &ContestService{
-		contestRepo: contestRepo,
+		contestRepo:         contestRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
 	}
 }
 
-// ListContests retrieves contests with filters and pagination
-func (s *ContestService) ListContests(filters *dto.ContestFilterRequest) ([]dto.ContestResponse, int64, error) {
+// ListContests retrieves contests with filters and pagination. userID is empty for anonymous
+// callers, otherwise the caller's reminders are filled in.
+func (s *ContestService) ListContests(filters *dto.ContestFilterRequest, userID string) ([]dto.ContestResponse, int64, error) {
 	// Default pagination
 	if filters.Page < 1 {
 		filters.Page = 1
@@ -35,8 +48,20 @@ func (s *ContestService) ListContests(filters *dto.ContestFilterRequest) ([]dto.
 
 	// Build filter map
 	filterMap := make(map[string]interface{})
-	if filters.Platform != "" {
-		filterMap["platform"] = filters.Platform
+	if len(filters.Platforms) > 0 {
+		filterMap["platforms"] = filters.Platforms
+	}
+	if filters.StartDate != nil {
+		filterMap["start_date"] = *filters.StartDate
+	}
+	if filters.EndDate != nil {
+		filterMap["end_date"] = *filters.EndDate
+	}
+	if filters.MinDuration > 0 {
+		filterMap["min_duration"] = filters.MinDuration * 60
+	}
+	if filters.MaxDuration > 0 {
+		filterMap["max_duration"] = filters.MaxDuration * 60
 	}
 	if filters.Upcoming {
 		filterMap["upcoming"] = true
@@ -44,6 +69,9 @@ func (s *ContestService) ListContests(filters *dto.ContestFilterRequest) ([]dto.
 	if filters.Ongoing {
 		filterMap["ongoing"] = true
 	}
+	if userID != "" {
+		filterMap["viewer_id"] = userID
+	}
 
 	contests, total, err := s.contestRepo.FindAll(filterMap, filters.Page, filters.Limit)
 	if err != nil {
@@ -55,25 +83,104 @@ func (s *ContestService) ListContests(filters *dto.ContestFilterRequest) ([]dto.
 		responses[i] = *s.mapContestToResponse(&contest)
 	}
 
+	if err := s.fillReminders(userID, responses); err != nil {
+		return nil, 0, err
+	}
+
 	return responses, total, nil
 }
 
-// GetContestByID retrieves a contest by ID
-func (s *ContestService) GetContestByID(id string) (*dto.ContestResponse, error) {
-	contest, err := s.contestRepo.FindByID(id)
+// GetContestByID retrieves a contest by ID. userID is empty for anonymous callers.
+func (s *ContestService) GetContestByID(id, userID string) (*dto.ContestResponse, error) {
+	contest, err := s.contestRepo.FindVisibleByID(id, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrContestNotFound
 		}
 		return nil, err
 	}
-	return s.mapContestToResponse(contest), nil
+
+	responses := []dto.ContestResponse{*s.mapContestToResponse(contest)}
+	if err := s.fillReminders(userID, responses); err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// GetUserParticipations retrieves a user's contest results with pagination, most recent first
+func (s *ContestService) GetUserParticipations(userID, platform string, page, limit int) ([]dto.ParticipationResponse, int64, error) {
+	participations, total, err := s.contestRepo.FindParticipationsByUserID(userID, platform, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]dto.ParticipationResponse, len(participations))
+	for i := range participations {
+		responses[i] = *s.mapParticipationToResponse(&participations[i])
+		responses[i].Contest = s.mapContestToResponse(&participations[i].Contest)
+	}
+	return responses, total, nil
+}
+
+// GetContestParticipants retrieves the stored results in a contest ordered by rank.
+// With friendsOnly only the caller's and their friends' results are returned.
+func (s *ContestService) GetContestParticipants(contestID, userID string, friendsOnly bool) ([]dto.ParticipationResponse, error) {
+	if _, err := s.contestRepo.FindVisibleByID(contestID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrContestNotFound
+		}
+		return nil, err
+	}
+
+	friendsOf := ""
+	if friendsOnly {
+		friendsOf = userID
+	}
+	participations, err := s.contestRepo.FindParticipationsByContestID(contestID, friendsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ParticipationResponse, len(participations))
+	for i := range participations {
+		responses[i] = *s.mapParticipationToResponse(&participations[i])
+		responses[i].User = &dto.ContestantResponse{
+			ID:        participations[i].User.ID,
+			Username:  participations[i].User.Username,
+			AvatarURL: participations[i].User.AvatarURL,
+		}
+	}
+	return responses, nil
+}
+
+// fillReminders sets HasReminder and ReminderID on the contests the user set a reminder for
+func (s *ContestService) fillReminders(userID string, contests []dto.ContestResponse) error {
+	if userID == "" || len(contests) == 0 {
+		return nil
+	}
+
+	contestIDs := make([]uuid.UUID, len(contests))
+	for i := range contests {
+		contestIDs[i] = contests[i].ID
+	}
+
+	reminderIDs, err := s.contestRepo.FindReminderIDsByContestIDs(userID, contestIDs)
+	if err != nil {
+		return err
+	}
+	for i := range contests {
+		if reminderID, ok := reminderIDs[contests[i].ID]; ok {
+			contests[i].HasReminder = true
+			contests[i].ReminderID = &reminderID
+		}
+	}
+	return nil
 }
 
 // CreateReminder creates a contest reminder for a user
 func (s *ContestService) CreateReminder(userID string, req *dto.CreateReminderRequest) (*dto.ReminderResponse, error) {
 	// Check if contest exists
-	contest, err := s.contestRepo.FindByID(req.ContestID.String())
+	contest, err := s.contestRepo.FindVisibleByID(req.ContestID.String(), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrContestNotFound
@@ -96,11 +203,16 @@ func (s *ContestService) CreateReminder(userID string, req *dto.CreateReminderRe
 		return nil, errors.New("invalid user ID")
 	}
 
+	remindBefore := req.RemindBeforeMinutes
+	if remindBefore <= 0 {
+		remindBefore = 30
+	}
+
 	// Create reminder
 	reminder := &models.ContestReminder{
 		UserID:              userUUID,
 		ContestID:           req.ContestID,
-		RemindBeforeMinutes: req.RemindBeforeMinutes,
+		RemindBeforeMinutes: remindBefore,
 		IsNotified:          false,
 	}
 
@@ -114,6 +226,21 @@ func (s *ContestService) CreateReminder(userID string, req *dto.CreateReminderRe
 	return s.mapReminderToResponse(reminder), nil
 }
 
+// GetUserReminders retrieves all reminders set by a user
+func (s *ContestService) GetUserReminders(userID string) ([]dto.ReminderResponse, error) {
+	reminders, err := s.contestRepo.FindReminderByuserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		responses[i] = *s.mapReminderToResponse(&reminder)
+	}
+
+	return responses, nil
+}
+
 // DeleteReminder deletes a contest reminder
 func (s *ContestService) DeleteReminder(userID, reminderID string) error {
 	// Check if reminder exists
@@ -133,26 +260,208 @@ func (s *ContestService) DeleteReminder(userID, reminderID string) error {
 	return s.contestRepo.DeleteReminder(reminderID)
 }
 
-// mapContestToResponse converts Contest model to ContestResponse DTO
+// GetContestCalendar renders recent and upcoming contests, optionally for one platform, as an iCalendar feed
+func (s *ContestService) GetContestCalendar(platform string) (string, error) {
+	contests, err := s.contestRepo.FindCalendarContests(platform, time.Now().Add(-calendarFeedLookback), calendarFeedLimit)
+	if err != nil {
+		return "", err
+	}
+
+	events := make([]utils.ICalEvent, len(contests))
+	for i := range contests {
+		events[i] = s.mapContestToICalEvent(&contests[i])
+	}
+
+	name := "Dojo Contests"
+	if platform != "" {
+		name += " (" + platform + ")"
+	}
+	return utils.BuildICalendar(name, events), nil
+}
+
+// GetReminderCalendar renders the contests a user set reminders for as an iCalendar feed,
+// with an alarm RemindBeforeMinutes before each contest. The user is looked up by calendar token.
+func (s *ContestService) GetReminderCalendar(token string) (string, error) {
+	user, err := s.userRepo.FindByCalendarToken(token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", utils.ErrInvalidToken
+		}
+		return "", err
+	}
+
+	reminders, err := s.contestRepo.FindReminderByuserID(user.ID.String())
+	if err != nil {
+		return "", err
+	}
+
+	since := time.Now().Add(-calendarFeedLookback)
+	events := make([]utils.ICalEvent, 0, len(reminders))
+	for i := range reminders {
+		if reminders[i].Contest.StartTime.Before(since) {
+			continue
+		}
+		event := s.mapContestToICalEvent(&reminders[i].Contest)
+		event.AlarmMinutes = reminders[i].RemindBeforeMinutes
+		events = append(events, event)
+	}
+
+	return utils.BuildICalendar("Dojo Contest Reminders", events), nil
+}
+
+// ResetCalendarToken issues a new calendar feed token for a user, invalidating the previous feed URL
+func (s *ContestService) ResetCalendarToken(userID string) (string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", utils.ErrUserNotFound
+		}
+		return "", err
+	}
+
+	token, err := utils.GenerateCalendarToken()
+	if err != nil {
+		return "", err
+	}
+	user.CalendarToken = &token
+	if err := s.userRepo.Update(user); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// mapContestToICalEvent converts Contest model to a calendar event
+func (s *ContestService) mapContestToICalEvent(contest *models.Contest) utils.ICalEvent {
+	return utils.ICalEvent{
+		UID:         contest.ID.String() + "@dojo",
+		Summary:     contest.Name,
+		Description: fmt.Sprintf("%s contest\n%s", contest.Platform, contest.ContestURL),
+		URL:         contest.ContestURL,
+		Start:       contest.StartTime,
+		End:         contest.StartTime.Add(time.Duration(contest.DurationSeconds) * time.Second),
+	}
+}
+
+// handleScheduleChange records a contest being moved, re-arms reminders whose notify time
+// is ahead again and tells every user with a reminder about the new schedule
+func (s *ContestService) handleScheduleChange(previous, contest *models.Contest) {
+	change := &models.ContestScheduleChange{
+		ContestID:          contest.ID,
+		OldStartTime:       previous.StartTime,
+		NewStartTime:       contest.StartTime,
+		OldDurationSeconds: previous.DurationSeconds,
+		NewDurationSeconds: contest.DurationSeconds,
+	}
+	if err := s.contestRepo.CreateScheduleChange(change); err != nil {
+		fmt.Printf("Warning: Failed to record schedule change for contest '%s': %v\n", contest.Name, err)
+	}
+
+	// Only a moved start time affects when reminders fire
+	if previous.StartTime.Equal(contest.StartTime) {
+		return
+	}
+
+	if _, err := s.contestRepo.ResetRescheduledReminders(contest.ID.String(), time.Now()); err != nil {
+		fmt.Printf("Warning: Failed to reschedule reminders for contest '%s': %v\n", contest.Name, err)
+	}
+
+	reminders, err := s.contestRepo.FindRemindersByContestID(contest.ID.String())
+	if err != nil {
+		fmt.Printf("Warning: Failed to load reminders for contest '%s': %v\n", contest.Name, err)
+		return
+	}
+	for i := range reminders {
+		if err := s.notificationService.NotifyContestRescheduled(&reminders[i], previous.StartTime); err != nil {
+			fmt.Printf("Warning: Failed to notify user %s about rescheduled contest '%s': %v\n", reminders[i].UserID, contest.Name, err)
+		}
+	}
+}
+
+// mapContestToResponse converts Contest model to ContestResponse DTO, computing the phase
+// and countdown at the current time
 func (s *ContestService) mapContestToResponse(contest *models.Contest) *dto.ContestResponse {
-	return &dto.ContestResponse{
+	now := time.Now()
+	endTime := contest.StartTime.Add(time.Duration(contest.DurationSeconds) * time.Second)
+
+	response := &dto.ContestResponse{
 		ID:              contest.ID,
 		Platform:        contest.Platform,
 		Name:            contest.Name,
 		StartTime:       contest.StartTime,
+		EndTime:         endTime,
 		DurationSeconds: contest.DurationSeconds,
 		ContestURL:      contest.ContestURL,
 		Description:     contest.Description,
-		TimeUntilStart:  "",
+		IsPrivate:       contest.IsPrivate,
 		HasReminder:     false,
 	}
+
+	response.Phase = contestPhase(contest, now)
+	if response.Phase == dto.ContestPhaseUpcoming {
+		untilStart := contest.StartTime.Sub(now)
+		response.SecondsUntilStart = int64(untilStart / time.Second)
+		response.TimeUntilStart = formatCountdown(untilStart)
+	}
+
+	return response
+}
+
+// contestPhase returns whether a contest is upcoming, ongoing or finished at now
+func contestPhase(contest *models.Contest, now time.Time) string {
+	endTime := contest.StartTime.Add(time.Duration(contest.DurationSeconds) * time.Second)
+	switch {
+	case now.Before(contest.StartTime):
+		return dto.ContestPhaseUpcoming
+	case now.Before(endTime):
+		return dto.ContestPhaseOngoing
+	default:
+		return dto.ContestPhaseFinished
+	}
+}
+
+// formatCountdown formats a positive duration as e.g. "2d 3h 15m" (or "45s" under a minute)
+func formatCountdown(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// mapParticipationToResponse converts ContestParticipation model to ParticipationResponse DTO
+func (s *ContestService) mapParticipationToResponse(participation *models.ContestParticipation) *dto.ParticipationResponse {
+	return &dto.ParticipationResponse{
+		ID:             participation.ID,
+		Platform:       participation.Platform,
+		Rank:           participation.Rank,
+		OldRating:      participation.OldRating,
+		NewRating:      participation.NewRating,
+		RatingChange:   participation.RatingChange,
+		ProblemsSolved: participation.ProblemsSolved,
+		TotalProblems:  participation.TotalProblems,
+	}
 }
 
 // mapReminderToResponse converts ContestReminder model to ReminderResponse DTO
 func (s *ContestService) mapReminderToResponse(reminder *models.ContestReminder) *dto.ReminderResponse {
+	contest := s.mapContestToResponse(&reminder.Contest)
+	contest.HasReminder = true
+	contest.ReminderID = &reminder.ID
+
 	return &dto.ReminderResponse{
 		ID:                  reminder.ID,
-		Contest:             *s.mapContestToResponse(&reminder.Contest),
+		Contest:             *contest,
 		RemindBeforeMinutes: reminder.RemindBeforeMinutes,
 		IsNotified:          reminder.IsNotified,
 		CreatedAt:           reminder.CreatedAt,
@@ -164,15 +473,14 @@ func (s *ContestService) SyncContestsFromPlatform(platform string) (int, error)
 	var contests []scrapper.ContestInfo
 	var err error
 
-	switch platform {
-	case "codeforces":
-		contests, err = scrapper.FetchCodeforcesContests()
-	case "leetcode":
-		contests, err = scrapper.FetchLeetCodeContests()
-	case "all":
-		contests, err = scrapper.FetchAllContests()
-	default:
-		return 0, fmt.Errorf("unsupported platform: %s", platform)
+	if platform == "all" {
+		contests, err = scrapper.FetchAllContests(context.Background())
+	} else {
+		p, ok := scrapper.Get(platform)
+		if !ok {
+			return 0, fmt.Errorf("unsupported platform: %s", platform)
+		}
+		contests, err = p.FetchContests(context.Background())
 	}
 
 	if err != nil {
@@ -182,11 +490,14 @@ func (s *ContestService) SyncContestsFromPlatform(platform string) (int, error)
 	// Store contests in database
 	stored := 0
 	for _, contestInfo := range contests {
-		// Generate platform contest ID (using URL as fallback if not provided)
-		platformID := contestInfo.Platform + "_" + contestInfo.Name
-		if contestInfo.ContestURL != "" {
+		// Prefer the platform's own contest ID, falling back to the URL or name
+		platformID := contestInfo.PlatformContestID
+		if platformID == "" {
 			platformID = contestInfo.ContestURL
 		}
+		if platformID == "" {
+			platformID = contestInfo.Platform + "_" + contestInfo.Name
+		}
 
 		contest := &models.Contest{
 			Platform:          contestInfo.Platform,
@@ -197,12 +508,16 @@ func (s *ContestService) SyncContestsFromPlatform(platform string) (int, error)
 			ContestURL:        contestInfo.ContestURL,
 		}
 
-		// Create (FirstOrCreate will handle duplicates)
-		if err := s.contestRepo.Create(contest); err != nil {
+		previous, err := s.contestRepo.UpsertContest(contest)
+		if err != nil {
 			fmt.Printf("Warning: Failed to store contest '%s': %v\n", contest.Name, err)
 			continue
 		}
 		stored++
+
+		if previous != nil && (!previous.StartTime.Equal(contest.StartTime) || previous.DurationSeconds != contest.DurationSeconds) {
+			s.handleScheduleChange(previous, contest)
+		}
 	}
 
 	// Cleanup old contests (older than 60 days)
@@ -9,14 +9,14 @@ import (
 type ContestSyncService struct {
 	contestService *ContestService
 	ticker         *time.Ticker
-	stopChan       chan bool
+	stopChan       chan struct{}
 }
 
 // NewContestSyncService creates a new contest sync service
 func NewContestSyncService(contestService *ContestService) *ContestSyncService {
 	return &ContestSyncService{
 		contestService: contestService,
-		stopChan:       make(chan bool),
+		stopChan:       make(chan struct{}),
 	}
 }
 
@@ -46,7 +46,7 @@ func (s *ContestSyncService) Start(interval time.Duration) {
 // Stop halts the periodic synchronization
 func (s *ContestSyncService) Stop() {
 	log.Println("Stopping contest sync service...")
-	s.stopChan <- true
+	close(s.stopChan)
 }
 
 // syncContests fetches and stores contests from all platforms
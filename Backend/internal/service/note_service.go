@@ -0,0 +1,166 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NoteService struct {
+	noteRepo    *repository.NoteRepository
+	problemRepo *repository.ProblemRepository
+}
+
+func NewNoteService(noteRepo *repository.NoteRepository, problemRepo *repository.ProblemRepository) *NoteService {
+	return &NoteService{
+		noteRepo:    noteRepo,
+		problemRepo: problemRepo,
+	}
+}
+
+// CreateNote adds a personal note to a problem. Content is stored as sanitized markdown.
+func (s *NoteService) CreateNote(userID, problemID string, req *dto.CreateNoteRequest) (*dto.NoteResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	problem, err := s.problemRepo.FindByID(problemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrProblemNotFound
+		}
+		return nil, err
+	}
+
+	content := utils.NormalizeMarkdown(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: note is empty", utils.ErrInvalidInput)
+	}
+
+	note := &models.UserNote{
+		UserID:     userUUID,
+		ProblemID:  problem.ID,
+		Content:    content,
+		IsFavorite: req.IsFavorite,
+	}
+	if err := s.noteRepo.Create(note); err != nil {
+		return nil, err
+	}
+
+	note.Problem = *problem
+	return s.mapNoteToResponse(note), nil
+}
+
+// GetProblemNotes retrieves a user's notes on a problem
+func (s *NoteService) GetProblemNotes(userID, problemID string) ([]dto.NoteResponse, error) {
+	if _, err := s.problemRepo.FindByID(problemID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrProblemNotFound
+		}
+		return nil, err
+	}
+
+	notes, err := s.noteRepo.FindByUserAndProblem(userID, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.NoteResponse, len(notes))
+	for i := range notes {
+		responses[i] = *s.mapNoteToResponse(&notes[i])
+	}
+	return responses, nil
+}
+
+// ListNotes retrieves all of a user's notes with optional full-text search and favorites filter
+func (s *NoteService) ListNotes(userID string, filters *dto.NoteFilterRequest) ([]dto.NoteResponse, int64, error) {
+	// Default pagination
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.Limit < 1 || filters.Limit > 100 {
+		filters.Limit = 20
+	}
+
+	notes, total, err := s.noteRepo.FindByUserID(userID, strings.TrimSpace(filters.Search), filters.Favorites, filters.Page, filters.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]dto.NoteResponse, len(notes))
+	for i := range notes {
+		responses[i] = *s.mapNoteToResponse(&notes[i])
+	}
+	return responses, total, nil
+}
+
+// UpdateNote updates the content or favorite flag of a user's note on a problem
+func (s *NoteService) UpdateNote(userID, problemID, noteID string, req *dto.UpdateNoteRequest) (*dto.NoteResponse, error) {
+	note, err := s.findUserNote(userID, problemID, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Content != "" {
+		content := utils.NormalizeMarkdown(req.Content)
+		if content == "" {
+			return nil, fmt.Errorf("%w: note is empty", utils.ErrInvalidInput)
+		}
+		note.Content = content
+	}
+	if req.IsFavorite != nil {
+		note.IsFavorite = *req.IsFavorite
+	}
+
+	if err := s.noteRepo.Update(note); err != nil {
+		return nil, err
+	}
+	return s.mapNoteToResponse(note), nil
+}
+
+// DeleteNote deletes a user's note on a problem
+func (s *NoteService) DeleteNote(userID, problemID, noteID string) error {
+	if _, err := s.findUserNote(userID, problemID, noteID); err != nil {
+		return err
+	}
+	return s.noteRepo.Delete(noteID)
+}
+
+// findUserNote loads a note, checking that it belongs to the user and the problem
+func (s *NoteService) findUserNote(userID, problemID, noteID string) (*models.UserNote, error) {
+	note, err := s.noteRepo.FindByID(noteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNoteNotFound
+		}
+		return nil, err
+	}
+
+	// Other users' notes are reported as missing
+	if note.UserID.String() != userID || note.ProblemID.String() != problemID {
+		return nil, utils.ErrNoteNotFound
+	}
+	return note, nil
+}
+
+// mapNoteToResponse converts UserNote model to NoteResponse DTO
+func (s *NoteService) mapNoteToResponse(note *models.UserNote) *dto.NoteResponse {
+	return &dto.NoteResponse{
+		ID:          note.ID,
+		ProblemID:   note.ProblemID,
+		Problem:     mapProblemSummary(&note.Problem),
+		Content:     note.Content,
+		ContentHTML: utils.RenderMarkdown(note.Content),
+		IsFavorite:  note.IsFavorite,
+		CreatedAt:   note.CreatedAt,
+		UpdatedAt:   note.UpdatedAt,
+	}
+}
@@ -1 +1,223 @@
 package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types stored in Notification.Type
+const (
+	NotificationTypeFriendRequest         = "friend_request"
+	NotificationTypeFriendRequestAccepted = "friend_request_accepted"
+	NotificationTypeContestReminder       = "contest_reminder"
+	NotificationTypeContestRescheduled    = "contest_rescheduled"
+	NotificationTypeVirtualContestInvite  = "virtual_contest_invite"
+)
+
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo *repository.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// CreateNotification stores a new notification for a user
+func (s *NotificationService) CreateNotification(userID uuid.UUID, notificationType, title, message string, data map[string]interface{}) (*dto.NotificationResponse, error) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification data: %w", err)
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Data:    string(dataJSON),
+		IsRead:  false,
+	}
+
+	if err := s.notificationRepo.Create(notification); err != nil {
+		return nil, err
+	}
+
+	return s.mapNotificationToResponse(notification), nil
+}
+
+// GetNotifications retrieves a user's notifications with pagination
+func (s *NotificationService) GetNotifications(userID string, unreadOnly bool, page, limit int) ([]dto.NotificationResponse, int64, error) {
+	notifications, total, err := s.notificationRepo.FindByUserID(userID, unreadOnly, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]dto.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		responses[i] = *s.mapNotificationToResponse(&notification)
+	}
+
+	return responses, total, nil
+}
+
+// GetUnreadCount returns the number of unread notifications for a user
+func (s *NotificationService) GetUnreadCount(userID string) (int64, error) {
+	return s.notificationRepo.CountUnread(userID)
+}
+
+// MarkAsRead marks the given notifications as read
+func (s *NotificationService) MarkAsRead(userID string, req *dto.MarkNotificationReadRequest) (int64, error) {
+	return s.notificationRepo.MarkAsRead(userID, req.Notification)
+}
+
+// MarkAllAsRead marks all of a user's notifications as read
+func (s *NotificationService) MarkAllAsRead(userID string) (int64, error) {
+	return s.notificationRepo.MarkAllAsRead(userID)
+}
+
+// DeleteNotification deletes one of the user's notifications
+func (s *NotificationService) DeleteNotification(userID, notificationID string) error {
+	if _, err := uuid.Parse(notificationID); err != nil {
+		return utils.ErrNotificationNotFound
+	}
+
+	deleted, err := s.notificationRepo.Delete(userID, notificationID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return utils.ErrNotificationNotFound
+	}
+	return nil
+}
+
+// NotifyFriendRequest tells the receiver that a friend request arrived.
+// The request must have its Sender preloaded.
+func (s *NotificationService) NotifyFriendRequest(request *models.FriendRequest) error {
+	_, err := s.CreateNotification(
+		request.ReceiverID,
+		NotificationTypeFriendRequest,
+		"New friend request",
+		fmt.Sprintf("%s sent you a friend request", request.Sender.Username),
+		map[string]interface{}{
+			"request_id":      request.ID,
+			"sender_id":       request.SenderID,
+			"sender_username": request.Sender.Username,
+		},
+	)
+	return err
+}
+
+// NotifyFriendRequestAccepted tells the sender that their request was accepted.
+// The request must have its Receiver preloaded.
+func (s *NotificationService) NotifyFriendRequestAccepted(request *models.FriendRequest) error {
+	_, err := s.CreateNotification(
+		request.SenderID,
+		NotificationTypeFriendRequestAccepted,
+		"Friend request accepted",
+		fmt.Sprintf("%s accepted your friend request", request.Receiver.Username),
+		map[string]interface{}{
+			"request_id":      request.ID,
+			"friend_id":       request.ReceiverID,
+			"friend_username": request.Receiver.Username,
+		},
+	)
+	return err
+}
+
+// NotifyContestReminder tells a user that a contest they set a reminder for is about to start.
+// The reminder must have its Contest preloaded.
+func (s *NotificationService) NotifyContestReminder(reminder *models.ContestReminder) error {
+	if reminder.Contest.ID == uuid.Nil {
+		return errors.New("reminder contest not loaded")
+	}
+
+	_, err := s.CreateNotification(
+		reminder.UserID,
+		NotificationTypeContestReminder,
+		"Contest starting soon",
+		fmt.Sprintf("%s starts in %d minutes", reminder.Contest.Name, reminder.RemindBeforeMinutes),
+		map[string]interface{}{
+			"reminder_id": reminder.ID,
+			"contest_id":  reminder.ContestID,
+			"platform":    reminder.Contest.Platform,
+			"contest_url": reminder.Contest.ContestURL,
+			"start_time":  reminder.Contest.StartTime,
+		},
+	)
+	return err
+}
+
+// NotifyContestRescheduled tells a user that a contest they set a reminder for has moved.
+// The reminder must have its Contest preloaded with the new schedule.
+func (s *NotificationService) NotifyContestRescheduled(reminder *models.ContestReminder, oldStartTime time.Time) error {
+	if reminder.Contest.ID == uuid.Nil {
+		return errors.New("reminder contest not loaded")
+	}
+
+	_, err := s.CreateNotification(
+		reminder.UserID,
+		NotificationTypeContestRescheduled,
+		"Contest rescheduled",
+		fmt.Sprintf("%s now starts at %s", reminder.Contest.Name, reminder.Contest.StartTime.UTC().Format("Jan 2, 15:04 MST")),
+		map[string]interface{}{
+			"reminder_id":    reminder.ID,
+			"contest_id":     reminder.ContestID,
+			"platform":       reminder.Contest.Platform,
+			"contest_url":    reminder.Contest.ContestURL,
+			"old_start_time": oldStartTime,
+			"start_time":     reminder.Contest.StartTime,
+		},
+	)
+	return err
+}
+
+// NotifyVirtualContestInvite tells a friend that they were invited to a virtual contest
+func (s *NotificationService) NotifyVirtualContestInvite(contest *models.VirtualContest, inviteeID uuid.UUID, inviterUsername string) error {
+	_, err := s.CreateNotification(
+		inviteeID,
+		NotificationTypeVirtualContestInvite,
+		"Virtual contest invite",
+		fmt.Sprintf("%s invited you to %s (%d minutes)", inviterUsername, contest.Name, contest.DurationMinutes),
+		map[string]interface{}{
+			"virtual_contest_id": contest.ID,
+			"room_id":            contest.RoomID,
+			"inviter_id":         contest.CreatedBy,
+			"inviter_username":   inviterUsername,
+		},
+	)
+	return err
+}
+
+// mapNotificationToResponse converts Notification model to NotificationResponse DTO
+func (s *NotificationService) mapNotificationToResponse(notification *models.Notification) *dto.NotificationResponse {
+	data := map[string]interface{}{}
+	if notification.Data != "" {
+		if err := json.Unmarshal([]byte(notification.Data), &data); err != nil {
+			data = map[string]interface{}{}
+		}
+	}
+
+	return &dto.NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Data:      data,
+		IsRead:    notification.IsRead,
+		CreatedAt: notification.CreatedAt,
+	}
+}
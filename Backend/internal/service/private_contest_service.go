@@ -0,0 +1,471 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultProblemPoints is the full score of a problem in IOI mode when none is given
+const defaultProblemPoints = 100
+
+type PrivateContestService struct {
+	privateContestRepo *repository.PrivateContestRepository
+	problemRepo        *repository.ProblemRepository
+}
+
+func NewPrivateContestService(privateContestRepo *repository.PrivateContestRepository, problemRepo *repository.ProblemRepository) *PrivateContestService {
+	return &PrivateContestService{
+		privateContestRepo: privateContestRepo,
+		problemRepo:        problemRepo,
+	}
+}
+
+// CreatePrivateContest creates an invite-only contest organized by the user
+func (s *PrivateContestService) CreatePrivateContest(userID string, req *dto.CreatePrivateContestRequest) (*dto.PrivateContestResponse, error) {
+	organizerID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if !req.StartTime.After(time.Now()) {
+		return nil, fmt.Errorf("%w: start_time must be in the future", utils.ErrInvalidInput)
+	}
+	if req.FreezeMinutes >= req.DurationMinutes {
+		return nil, fmt.Errorf("%w: freeze_minutes must be shorter than the contest", utils.ErrInvalidInput)
+	}
+	if len(req.Points) > 0 && len(req.Points) != len(req.ProblemIDs) {
+		return nil, fmt.Errorf("%w: points must have one entry per problem", utils.ErrInvalidInput)
+	}
+
+	found, err := s.problemRepo.FindByIDs(req.ProblemIDs)
+	if err != nil {
+		return nil, err
+	}
+	foundIDs := make(map[uuid.UUID]bool, len(found))
+	for _, problem := range found {
+		foundIDs[problem.ID] = true
+	}
+
+	inviteCode, err := s.privateContestRepo.GenerateUniqueInviteCode()
+	if err != nil {
+		return nil, errors.New("failed to generate invite code")
+	}
+
+	contest := &models.PrivateContest{
+		ID:            uuid.New(),
+		CreatedBy:     organizerID,
+		InviteCode:    inviteCode,
+		ScoringMode:   req.ScoringMode,
+		FreezeMinutes: req.FreezeMinutes,
+	}
+	contest.Contest = models.Contest{
+		Platform:          models.PrivateContestPlatform,
+		PlatformContestID: contest.ID.String(),
+		Name:              req.Name,
+		Description:       req.Description,
+		StartTime:         req.StartTime,
+		DurationSeconds:   req.DurationMinutes * 60,
+		IsPrivate:         true,
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for i, problemID := range req.ProblemIDs {
+		if !foundIDs[problemID] {
+			return nil, utils.ErrProblemNotFound
+		}
+		if seen[problemID] {
+			return nil, fmt.Errorf("%w: duplicate problem %s", utils.ErrInvalidInput, problemID)
+		}
+		seen[problemID] = true
+
+		points := defaultProblemPoints
+		if len(req.Points) > 0 {
+			points = req.Points[i]
+		}
+		contest.Problems = append(contest.Problems, models.PrivateContestProblem{
+			ProblemID: problemID,
+			Position:  i,
+			Points:    points,
+		})
+	}
+
+	if err := s.privateContestRepo.Create(contest); err != nil {
+		return nil, err
+	}
+
+	return s.GetPrivateContest(contest.ID.String(), userID)
+}
+
+// ListPrivateContests retrieves the private contests a user organizes or joined
+func (s *PrivateContestService) ListPrivateContests(userID string) ([]dto.PrivateContestResponse, error) {
+	contests, err := s.privateContestRepo.FindUserContests(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]dto.PrivateContestResponse, len(contests))
+	for i := range contests {
+		responses[i] = *s.mapPrivateContestToResponse(&contests[i], userID, now)
+	}
+	return responses, nil
+}
+
+// GetPrivateContest retrieves a private contest the user organizes or joined
+func (s *PrivateContestService) GetPrivateContest(id, userID string) (*dto.PrivateContestResponse, error) {
+	contest, err := s.findForMember(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapPrivateContestToResponse(contest, userID, time.Now()), nil
+}
+
+// JoinPrivateContest adds the user to the private contest with the invite code
+func (s *PrivateContestService) JoinPrivateContest(userID string, req *dto.JoinPrivateContestRequest) (*dto.PrivateContestResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	contest, err := s.privateContestRepo.FindByInviteCode(strings.ToUpper(strings.TrimSpace(req.InviteCode)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrPrivateContestNotFound
+		}
+		return nil, err
+	}
+
+	if contest.CreatedBy == userUUID {
+		return nil, fmt.Errorf("%w: organizers can't take part in their own contest", utils.ErrInvalidInput)
+	}
+	if contestPhase(&contest.Contest, time.Now()) == dto.ContestPhaseFinished {
+		return nil, fmt.Errorf("%w: contest has finished", utils.ErrInvalidInput)
+	}
+
+	joined, err := s.privateContestRepo.IsParticipant(contest.ID.String(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if joined {
+		return nil, utils.ErrAlreadyJoined
+	}
+
+	participant := &models.PrivateContestParticipant{
+		PrivateContestID: contest.ID,
+		UserID:           userUUID,
+	}
+	if err := s.privateContestRepo.AddParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	return s.GetPrivateContest(contest.ID.String(), userID)
+}
+
+// Submit records a participant's verdict for a problem while the contest is running. In IOI
+// mode the score is out of the problem's points and full points count as accepted.
+func (s *PrivateContestService) Submit(id, userID string, req *dto.PrivateSubmissionRequest) (*dto.PrivateSubmissionResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	contest, err := s.findForMember(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if contest.CreatedBy.String() == userID {
+		return nil, fmt.Errorf("%w: organizers can't submit", utils.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	if contestPhase(&contest.Contest, now) != dto.ContestPhaseOngoing {
+		return nil, fmt.Errorf("%w: contest is not running", utils.ErrInvalidInput)
+	}
+
+	var problem *models.PrivateContestProblem
+	for i := range contest.Problems {
+		if contest.Problems[i].ProblemID == req.ProblemID {
+			problem = &contest.Problems[i]
+			break
+		}
+	}
+	if problem == nil {
+		return nil, utils.ErrProblemNotFound
+	}
+
+	submission := &models.PrivateContestSubmission{
+		PrivateContestID: contest.ID,
+		UserID:           userUUID,
+		ProblemID:        req.ProblemID,
+		Accepted:         req.Accepted,
+		SubmittedAt:      now,
+	}
+	if contest.ScoringMode == models.ScoringModeIOI {
+		if req.Score > problem.Points {
+			return nil, fmt.Errorf("%w: score must be at most %d", utils.ErrInvalidInput, problem.Points)
+		}
+		submission.Score = req.Score
+		submission.Accepted = req.Score == problem.Points
+	} else if req.Accepted {
+		submission.Score = problem.Points
+	}
+
+	if err := s.privateContestRepo.CreateSubmission(submission); err != nil {
+		return nil, err
+	}
+
+	return &dto.PrivateSubmissionResponse{
+		ID:          submission.ID,
+		ProblemID:   submission.ProblemID,
+		Label:       problemLabel(problem.Position),
+		Accepted:    submission.Accepted,
+		Score:       submission.Score,
+		SubmittedAt: submission.SubmittedAt,
+	}, nil
+}
+
+// Unfreeze lifts the standings freeze once the contest is over. Only the organizer can unfreeze.
+func (s *PrivateContestService) Unfreeze(id, userID string) (*dto.StandingsResponse, error) {
+	contest, err := s.findForMember(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if contest.CreatedBy.String() != userID {
+		return nil, utils.ErrUnauthorized
+	}
+	if contest.FreezeTime() == nil {
+		return nil, fmt.Errorf("%w: contest has no freeze", utils.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	if contestPhase(&contest.Contest, now) != dto.ContestPhaseFinished {
+		return nil, fmt.Errorf("%w: the freeze can only be lifted after the contest", utils.ErrInvalidInput)
+	}
+	if _, err := s.privateContestRepo.Unfreeze(id, now); err != nil {
+		return nil, err
+	}
+
+	return s.GetStandings(id, userID)
+}
+
+// GetStandings builds the scoreboard as the user sees it. Once the standings freeze, other
+// participants' submissions are shown as pending until the organizer lifts the freeze; the
+// organizer always sees the full standings.
+func (s *PrivateContestService) GetStandings(id, userID string) (*dto.StandingsResponse, error) {
+	contest, err := s.findForMember(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.privateContestRepo.FindSubmissions(id)
+	if err != nil {
+		return nil, err
+	}
+	return buildStandings(contest, submissions, userID, time.Now()), nil
+}
+
+// buildStandings scores the submissions, oldest first, into the standings userID sees at now
+func buildStandings(contest *models.PrivateContest, submissions []models.PrivateContestSubmission, userID string, now time.Time) *dto.StandingsResponse {
+	standings := &dto.StandingsResponse{
+		PrivateContestID: contest.ID,
+		ScoringMode:      contest.ScoringMode,
+		Phase:            contestPhase(&contest.Contest, now),
+		Rows:             []dto.StandingsRow{},
+		UpdatedAt:        now,
+	}
+
+	var frozenFrom *time.Time
+	if freeze := contest.FreezeTime(); freeze != nil && contest.UnfrozenAt == nil &&
+		contest.CreatedBy.String() != userID && !now.Before(*freeze) {
+		frozenFrom = freeze
+		standings.Frozen = true
+	}
+
+	// Like the contest itself, rows don't reveal the problems to participants before the start
+	problems := contest.Problems
+	if contest.CreatedBy.String() != userID && standings.Phase == dto.ContestPhaseUpcoming {
+		problems = nil
+	}
+
+	type cellKey struct{ userID, problemID uuid.UUID }
+	cells := make(map[cellKey]*dto.StandingsCell)
+	positions := make(map[uuid.UUID]models.PrivateContestProblem, len(contest.Problems))
+	for _, problem := range contest.Problems {
+		positions[problem.ProblemID] = problem
+	}
+
+	// Submissions are oldest first
+	for _, submission := range submissions {
+		problem, ok := positions[submission.ProblemID]
+		if !ok {
+			continue
+		}
+		key := cellKey{submission.UserID, submission.ProblemID}
+		cell, ok := cells[key]
+		if !ok {
+			cell = &dto.StandingsCell{Label: problemLabel(problem.Position), ProblemID: problem.ProblemID}
+			cells[key] = cell
+		}
+		if cell.Solved && contest.ScoringMode == models.ScoringModeICPC {
+			continue
+		}
+		if frozenFrom != nil && submission.UserID.String() != userID && !submission.SubmittedAt.Before(*frozenFrom) {
+			cell.PendingAttempts++
+			continue
+		}
+
+		minutes := int(submission.SubmittedAt.Sub(contest.Contest.StartTime).Minutes())
+		if contest.ScoringMode == models.ScoringModeIOI {
+			if submission.Score > cell.Score {
+				cell.Score = submission.Score
+				cell.SolvedAtMinutes = minutes
+			}
+			cell.Solved = cell.Solved || submission.Accepted
+			continue
+		}
+		if submission.Accepted {
+			cell.Solved = true
+			cell.SolvedAtMinutes = minutes
+		} else {
+			cell.Attempts++
+		}
+	}
+
+	for _, participant := range contest.Participants {
+		row := dto.StandingsRow{
+			User: dto.ContestantResponse{
+				ID:        participant.User.ID,
+				Username:  participant.User.Username,
+				AvatarURL: participant.User.AvatarURL,
+			},
+			Problems: make([]dto.StandingsCell, len(problems)),
+		}
+		for i, problem := range problems {
+			cell, ok := cells[cellKey{participant.UserID, problem.ProblemID}]
+			if !ok {
+				row.Problems[i] = dto.StandingsCell{Label: problemLabel(problem.Position), ProblemID: problem.ProblemID}
+				continue
+			}
+			row.Problems[i] = *cell
+
+			if cell.Solved {
+				row.Solved++
+			}
+			if contest.ScoringMode == models.ScoringModeIOI {
+				row.Score += cell.Score
+				if cell.Score > 0 && cell.SolvedAtMinutes > row.Penalty {
+					row.Penalty = cell.SolvedAtMinutes
+				}
+			} else if cell.Solved {
+				row.Penalty += cell.SolvedAtMinutes + cell.Attempts*wrongAttemptPenaltyMinutes
+			}
+		}
+		standings.Rows = append(standings.Rows, row)
+	}
+
+	rankStandings(standings.Rows, contest.ScoringMode)
+	return standings
+}
+
+// rankStandings sorts the rows by problems solved (ICPC) or score (IOI), then penalty, and
+// assigns ranks with ties sharing a rank
+func rankStandings(rows []dto.StandingsRow, scoringMode string) {
+	primary := func(row dto.StandingsRow) int {
+		if scoringMode == models.ScoringModeIOI {
+			return row.Score
+		}
+		return row.Solved
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if primary(rows[i]) != primary(rows[j]) {
+			return primary(rows[i]) > primary(rows[j])
+		}
+		if rows[i].Penalty != rows[j].Penalty {
+			return rows[i].Penalty < rows[j].Penalty
+		}
+		return rows[i].User.Username < rows[j].User.Username
+	})
+
+	for i := range rows {
+		rows[i].Rank = i + 1
+		if i > 0 && primary(rows[i-1]) == primary(rows[i]) && rows[i-1].Penalty == rows[i].Penalty {
+			rows[i].Rank = rows[i-1].Rank
+		}
+	}
+}
+
+// findForMember loads a private contest if the user organizes or joined it. Other users get
+// ErrPrivateContestNotFound so private contests can't be discovered by ID.
+func (s *PrivateContestService) findForMember(id, userID string) (*models.PrivateContest, error) {
+	contest, err := s.privateContestRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrPrivateContestNotFound
+		}
+		return nil, err
+	}
+
+	if contest.CreatedBy.String() == userID {
+		return contest, nil
+	}
+	for _, participant := range contest.Participants {
+		if participant.UserID.String() == userID {
+			return contest, nil
+		}
+	}
+	return nil, utils.ErrPrivateContestNotFound
+}
+
+// mapPrivateContestToResponse converts PrivateContest model to PrivateContestResponse DTO.
+// Problems are hidden from participants until the contest starts.
+func (s *PrivateContestService) mapPrivateContestToResponse(contest *models.PrivateContest, userID string, now time.Time) *dto.PrivateContestResponse {
+	isOrganizer := contest.CreatedBy.String() == userID
+	response := &dto.PrivateContestResponse{
+		ID:               contest.ID,
+		ContestID:        contest.ContestID,
+		Name:             contest.Contest.Name,
+		Description:      contest.Contest.Description,
+		CreatedBy:        contest.CreatedBy,
+		IsOrganizer:      isOrganizer,
+		ScoringMode:      contest.ScoringMode,
+		StartTime:        contest.Contest.StartTime,
+		EndTime:          contest.Contest.StartTime.Add(time.Duration(contest.Contest.DurationSeconds) * time.Second),
+		DurationSeconds:  contest.Contest.DurationSeconds,
+		FreezeMinutes:    contest.FreezeMinutes,
+		UnfrozenAt:       contest.UnfrozenAt,
+		Phase:            contestPhase(&contest.Contest, now),
+		ParticipantCount: len(contest.Participants),
+		CreatedAt:        contest.CreatedAt,
+	}
+
+	if isOrganizer {
+		response.InviteCode = contest.InviteCode
+	}
+
+	if isOrganizer || response.Phase != dto.ContestPhaseUpcoming {
+		for _, problem := range contest.Problems {
+			response.Problems = append(response.Problems, dto.PrivateContestProblemResponse{
+				Label:      problemLabel(problem.Position),
+				ProblemID:  problem.ProblemID,
+				Platform:   problem.Problem.Platform,
+				Title:      problem.Problem.Title,
+				Difficulty: problem.Problem.Difficulty,
+				ProblemURL: problem.Problem.ProblemURL,
+				Points:     problem.Points,
+			})
+		}
+	}
+
+	return response
+}
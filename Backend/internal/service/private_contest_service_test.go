@@ -0,0 +1,317 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// standingsFixture is a two-hour private contest with problems A and B whose standings freeze
+// 30 minutes before the end
+type standingsFixture struct {
+	start     time.Time
+	contest   *models.PrivateContest
+	users     map[string]uuid.UUID
+	organizer uuid.UUID
+	problemA  uuid.UUID
+	problemB  uuid.UUID
+}
+
+func newStandingsFixture(scoringMode string) *standingsFixture {
+	f := &standingsFixture{
+		start:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		users:     map[string]uuid.UUID{"alice": uuid.New(), "bob": uuid.New(), "carol": uuid.New()},
+		organizer: uuid.New(),
+		problemA:  uuid.New(),
+		problemB:  uuid.New(),
+	}
+	f.contest = &models.PrivateContest{
+		ID:            uuid.New(),
+		CreatedBy:     f.organizer,
+		ScoringMode:   scoringMode,
+		FreezeMinutes: 30,
+		Contest:       models.Contest{StartTime: f.start, DurationSeconds: 2 * 60 * 60},
+		Problems: []models.PrivateContestProblem{
+			{ProblemID: f.problemA, Position: 0},
+			{ProblemID: f.problemB, Position: 1},
+		},
+	}
+	for _, username := range []string{"alice", "bob", "carol"} {
+		f.contest.Participants = append(f.contest.Participants, models.PrivateContestParticipant{
+			UserID: f.users[username],
+			User:   models.User{ID: f.users[username], Username: username},
+		})
+	}
+	return f
+}
+
+// at returns the time the given number of minutes into the contest
+func (f *standingsFixture) at(minutes int) time.Time {
+	return f.start.Add(time.Duration(minutes) * time.Minute)
+}
+
+func (f *standingsFixture) submission(username string, problemID uuid.UUID, minutes int, accepted bool, score int) models.PrivateContestSubmission {
+	return models.PrivateContestSubmission{
+		UserID:      f.users[username],
+		ProblemID:   problemID,
+		Accepted:    accepted,
+		Score:       score,
+		SubmittedAt: f.at(minutes),
+	}
+}
+
+// row returns a user's row in the standings
+func row(t *testing.T, standings *dto.StandingsResponse, username string) dto.StandingsRow {
+	t.Helper()
+
+	for _, row := range standings.Rows {
+		if row.User.Username == username {
+			return row
+		}
+	}
+	t.Fatalf("no standings row for %s", username)
+	return dto.StandingsRow{}
+}
+
+func TestBuildStandingsFreeze(t *testing.T) {
+	f := newStandingsFixture(models.ScoringModeICPC)
+	submissions := []models.PrivateContestSubmission{
+		f.submission("alice", f.problemA, 30, false, 0),
+		f.submission("alice", f.problemA, 95, false, 0),
+		f.submission("alice", f.problemA, 100, true, 0),
+		f.submission("bob", f.problemA, 20, true, 0),
+		f.submission("bob", f.problemA, 92, false, 0), // after the solve, ignored
+		f.submission("bob", f.problemB, 105, true, 0),
+	}
+
+	tests := []struct {
+		name       string
+		viewer     uuid.UUID
+		now        time.Time
+		unfrozenAt *time.Time
+		wantFrozen bool
+		alice      dto.StandingsCell
+		bobSolved  int
+	}{
+		{
+			name:      "before the freeze",
+			viewer:    f.users["carol"],
+			now:       f.at(80),
+			alice:     dto.StandingsCell{Attempts: 1},
+			bobSolved: 1,
+		},
+		{
+			name:       "frozen attempts are pending for other participants",
+			viewer:     f.users["carol"],
+			now:        f.at(110),
+			wantFrozen: true,
+			alice:      dto.StandingsCell{Attempts: 1, PendingAttempts: 2},
+			bobSolved:  1,
+		},
+		{
+			name:       "participants see their own frozen attempts",
+			viewer:     f.users["alice"],
+			now:        f.at(110),
+			wantFrozen: true,
+			alice:      dto.StandingsCell{Solved: true, Attempts: 2, SolvedAtMinutes: 100},
+			bobSolved:  1,
+		},
+		{
+			name:      "the organizer sees everything",
+			viewer:    f.organizer,
+			now:       f.at(110),
+			alice:     dto.StandingsCell{Solved: true, Attempts: 2, SolvedAtMinutes: 100},
+			bobSolved: 2,
+		},
+		{
+			name:       "lifting the freeze reveals everything",
+			viewer:     f.users["carol"],
+			now:        f.at(130),
+			unfrozenAt: ptr(f.at(125)),
+			alice:      dto.StandingsCell{Solved: true, Attempts: 2, SolvedAtMinutes: 100},
+			bobSolved:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f.contest.UnfrozenAt = tt.unfrozenAt
+			var submitted []models.PrivateContestSubmission
+			for _, submission := range submissions {
+				if submission.SubmittedAt.Before(tt.now) {
+					submitted = append(submitted, submission)
+				}
+			}
+			standings := buildStandings(f.contest, submitted, tt.viewer.String(), tt.now)
+
+			if standings.Frozen != tt.wantFrozen {
+				t.Errorf("Frozen = %v, want %v", standings.Frozen, tt.wantFrozen)
+			}
+			want := tt.alice
+			want.Label, want.ProblemID = "A", f.problemA
+			if got := row(t, standings, "alice").Problems[0]; got != want {
+				t.Errorf("alice's A = %+v, want %+v", got, want)
+			}
+			if got := row(t, standings, "bob").Solved; got != tt.bobSolved {
+				t.Errorf("bob solved %d, want %d", got, tt.bobSolved)
+			}
+		})
+	}
+}
+
+func TestBuildStandingsBeforeStart(t *testing.T) {
+	f := newStandingsFixture(models.ScoringModeICPC)
+	before := f.at(-10)
+
+	standings := buildStandings(f.contest, nil, f.users["alice"].String(), before)
+	if standings.Phase != dto.ContestPhaseUpcoming {
+		t.Fatalf("Phase = %q, want %q", standings.Phase, dto.ContestPhaseUpcoming)
+	}
+	for _, row := range standings.Rows {
+		if row.Problems == nil || len(row.Problems) != 0 {
+			t.Errorf("%s's problems = %+v, want none before the start", row.User.Username, row.Problems)
+		}
+	}
+
+	// The organizer already knows the problems
+	standings = buildStandings(f.contest, nil, f.organizer.String(), before)
+	if got := row(t, standings, "alice").Problems; len(got) != 2 || got[0].ProblemID != f.problemA {
+		t.Errorf("organizer sees alice's problems = %+v, want A and B", got)
+	}
+}
+
+func TestBuildStandingsICPCPenalty(t *testing.T) {
+	f := newStandingsFixture(models.ScoringModeICPC)
+	f.contest.FreezeMinutes = 0
+	submissions := []models.PrivateContestSubmission{
+		f.submission("alice", f.problemA, 10, false, 0),
+		f.submission("alice", f.problemA, 15, true, 0),
+		f.submission("alice", f.problemB, 50, true, 0),
+		f.submission("bob", f.problemA, 40, true, 0),
+		f.submission("bob", f.problemB, 44, true, 0),
+		f.submission("carol", f.problemB, 5, false, 0),
+		f.submission("carol", f.problemB, 6, false, 0),
+	}
+
+	standings := buildStandings(f.contest, submissions, f.users["carol"].String(), f.at(130))
+
+	want := []struct {
+		username string
+		rank     int
+		solved   int
+		penalty  int
+	}{
+		{"bob", 1, 2, 40 + 44},
+		{"alice", 2, 2, 15 + 20 + 50},
+		{"carol", 3, 0, 0}, // Rejected attempts on unsolved problems cost nothing
+	}
+	for i, want := range want {
+		got := standings.Rows[i]
+		if got.User.Username != want.username || got.Rank != want.rank || got.Solved != want.solved || got.Penalty != want.penalty {
+			t.Errorf("row %d = %s rank %d, %d solved, penalty %d; want %s rank %d, %d solved, penalty %d",
+				i, got.User.Username, got.Rank, got.Solved, got.Penalty, want.username, want.rank, want.solved, want.penalty)
+		}
+	}
+}
+
+func TestBuildStandingsIOI(t *testing.T) {
+	f := newStandingsFixture(models.ScoringModeIOI)
+	f.contest.FreezeMinutes = 0
+	submissions := []models.PrivateContestSubmission{
+		f.submission("alice", f.problemA, 10, false, 30),
+		f.submission("alice", f.problemA, 20, true, 100),
+		f.submission("alice", f.problemA, 30, false, 60), // worse, keeps the best score
+		f.submission("alice", f.problemB, 50, false, 40),
+		f.submission("bob", f.problemA, 5, false, 70),
+		f.submission("bob", f.problemB, 15, false, 70),
+	}
+
+	standings := buildStandings(f.contest, submissions, f.users["carol"].String(), f.at(130))
+
+	alice := row(t, standings, "alice")
+	wantA := dto.StandingsCell{Label: "A", ProblemID: f.problemA, Solved: true, Score: 100, SolvedAtMinutes: 20}
+	if alice.Problems[0] != wantA {
+		t.Errorf("alice's A = %+v, want %+v", alice.Problems[0], wantA)
+	}
+	// Equal scores go to the earlier last improvement
+	if alice.Score != 140 || alice.Solved != 1 || alice.Penalty != 50 || alice.Rank != 2 {
+		t.Errorf("alice = score %d, %d solved, penalty %d, rank %d; want 140, 1, 50, 2", alice.Score, alice.Solved, alice.Penalty, alice.Rank)
+	}
+	bob := row(t, standings, "bob")
+	if bob.Score != 140 || bob.Solved != 0 || bob.Penalty != 15 || bob.Rank != 1 {
+		t.Errorf("bob = score %d, %d solved, penalty %d, rank %d; want 140, 0, 15, 1", bob.Score, bob.Solved, bob.Penalty, bob.Rank)
+	}
+}
+
+func TestRankStandings(t *testing.T) {
+	standingsRow := func(username string, solved, score, penalty int) dto.StandingsRow {
+		return dto.StandingsRow{User: dto.ContestantResponse{Username: username}, Solved: solved, Score: score, Penalty: penalty}
+	}
+
+	tests := []struct {
+		name        string
+		scoringMode string
+		rows        []dto.StandingsRow
+		wantOrder   []string
+		wantRanks   []int
+	}{
+		{
+			name:        "icpc ranks by solved, then penalty",
+			scoringMode: models.ScoringModeICPC,
+			rows: []dto.StandingsRow{
+				standingsRow("alice", 1, 0, 10),
+				standingsRow("bob", 2, 0, 300),
+				standingsRow("carol", 2, 0, 100),
+			},
+			wantOrder: []string{"carol", "bob", "alice"},
+			wantRanks: []int{1, 2, 3},
+		},
+		{
+			name:        "ties share a rank and are ordered by username",
+			scoringMode: models.ScoringModeICPC,
+			rows: []dto.StandingsRow{
+				standingsRow("dave", 1, 0, 50),
+				standingsRow("carol", 2, 0, 100),
+				standingsRow("bob", 2, 0, 100),
+				standingsRow("alice", 1, 0, 60),
+			},
+			wantOrder: []string{"bob", "carol", "dave", "alice"},
+			wantRanks: []int{1, 1, 3, 4},
+		},
+		{
+			name:        "ioi ranks by score, ignoring solved",
+			scoringMode: models.ScoringModeIOI,
+			rows: []dto.StandingsRow{
+				standingsRow("alice", 2, 200, 90),
+				standingsRow("bob", 0, 250, 100),
+				standingsRow("carol", 1, 200, 80),
+			},
+			wantOrder: []string{"bob", "carol", "alice"},
+			wantRanks: []int{1, 2, 3},
+		},
+		{
+			name:        "nobody scored",
+			scoringMode: models.ScoringModeIOI,
+			rows: []dto.StandingsRow{
+				standingsRow("bob", 0, 0, 0),
+				standingsRow("alice", 0, 0, 0),
+			},
+			wantOrder: []string{"alice", "bob"},
+			wantRanks: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankStandings(tt.rows, tt.scoringMode)
+			for i, row := range tt.rows {
+				if row.User.Username != tt.wantOrder[i] || row.Rank != tt.wantRanks[i] {
+					t.Errorf("row %d = %s rank %d, want %s rank %d", i, row.User.Username, row.Rank, tt.wantOrder[i], tt.wantRanks[i])
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"dojo/internal/dto"
 	"dojo/internal/models"
 	"dojo/internal/repository"
 	"dojo/internal/service/scrapper"
 	"dojo/internal/utils"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -17,12 +19,18 @@ import (
 )
 
 type ProblemService struct {
-	problemRepo *repository.ProblemRepository
+	problemRepo           *repository.ProblemRepository
+	submissionRepo        *repository.SubmissionRepository
+	virtualContestService *VirtualContestService
+	reviewService         *ReviewService
 }
 
-func NewProblemService(problemRepo *repository.ProblemRepository) *ProblemService {
+func NewProblemService(problemRepo *repository.ProblemRepository, submissionRepo *repository.SubmissionRepository, virtualContestService *VirtualContestService, reviewService *ReviewService) *ProblemService {
 	return &ProblemService{
-		problemRepo: problemRepo,
+		problemRepo:           problemRepo,
+		submissionRepo:        submissionRepo,
+		virtualContestService: virtualContestService,
+		reviewService:         reviewService,
 	}
 }
 
@@ -36,6 +44,13 @@ func (s *ProblemService) CreateProblem(req *dto.CreateProblemRequest) (*dto.Prob
 	if exists {
 		return nil, errors.New("problem with this URL already exists")
 	}
+	exists, err = s.problemRepo.ExistsByPlatformID(req.Platform, req.PlatformProblemID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("problem with this platform ID already exists")
+	}
 	// Create problem models
 	problem := &models.Problem{
 		Platform:          req.Platform,
@@ -55,7 +70,7 @@ func (s *ProblemService) CreateProblem(req *dto.CreateProblemRequest) (*dto.Prob
 	if err != nil {
 		return nil, err
 	}
-	return s.mapProblemToResponse(problem), nil
+	return mapProblemToResponse(problem), nil
 }
 
 // GetProblem retrieves a problem by ID
@@ -67,7 +82,7 @@ func (s *ProblemService) GetProblemByID(id string) (*dto.ProblemResponse, error)
 		}
 		return nil, err
 	}
-	return s.mapProblemToResponse(problem), nil
+	return mapProblemToResponse(problem), nil
 }
 
 // ListProblems retrieves all the problems with filtersss and paginationssss :)
@@ -93,6 +108,9 @@ func (s *ProblemService) ListProblems(filters *dto.ProblemFilterRequest, userID
 	if len(filters.Tags) > 0 {
 		filterMap["tags"] = filters.Tags
 	}
+	if filters.Sort != "" {
+		filterMap["sort"] = filters.Sort
+	}
 
 	problems, total, err := s.problemRepo.FindAll(filterMap, filters.Page, filters.Limit)
 	if err != nil {
@@ -115,7 +133,7 @@ func (s *ProblemService) ListProblems(filters *dto.ProblemFilterRequest, userID
 
 	responses := make([]dto.ProblemResponse, len(problems))
 	for i, problem := range problems {
-		response := s.mapProblemToResponse(&problem)
+		response := mapProblemToResponse(&problem)
 		response.IsSolved = solvedMap[problem.ID]
 		responses[i] = *response
 	}
@@ -165,7 +183,7 @@ func (s *ProblemService) UpdateProblem(id string, req *dto.UpdateProblemRequest)
 	if err := s.problemRepo.Update(problem); err != nil {
 		return nil, err
 	}
-	return s.mapProblemToResponse(problem), nil
+	return mapProblemToResponse(problem), nil
 }
 
 // DeleteProblem deletes a problem by ID
@@ -182,181 +200,326 @@ func (s *ProblemService) DeleteProblem(id string) error {
 }
 
 // mapProblemToResponse converts Problem model to ProblemResponse DTO
-func (s *ProblemService) mapProblemToResponse(problem *models.Problem) *dto.ProblemResponse {
-	return &dto.ProblemResponse{
+func mapProblemToResponse(problem *models.Problem) *dto.ProblemResponse {
+	response := mapProblemSummary(problem)
+	response.Description = problem.Description
+	response.Constraints = problem.Constraints
+	response.Examples = problem.Examples
+	response.Hints = problem.Hints
+	return &response
+}
+
+// mapProblemSummary converts Problem model to ProblemResponse DTO without the statement, for
+// problems listed alongside something else
+func mapProblemSummary(problem *models.Problem) dto.ProblemResponse {
+	return dto.ProblemResponse{
 		ID:                problem.ID,
 		Platform:          problem.Platform,
 		PlatformProblemID: problem.PlatformProblemID,
 		Title:             problem.Title,
 		Slug:              problem.Slug,
 		Difficulty:        problem.Difficulty,
+		Rating:            problem.Rating,
 		Tags:              []string(problem.Tags),
 		AcceptanceRate:    problem.AcceptanceRate,
 		ProblemURL:        problem.ProblemURL,
-		Description:       problem.Description,
-		Constraints:       problem.Constraints,
-		Examples:          problem.Examples,
-		Hints:             problem.Hints,
 		CreatedAt:         problem.CreatedAt,
 	}
 }
 
 // SyncProblems imports problems from external platforms
 func (s *ProblemService) SyncProblems(platform string, limit int) (int, error) {
+	p, ok := scrapper.Get(strings.ToLower(platform))
+	if !ok {
+		return 0, fmt.Errorf("unsupported platform: %s", platform)
+	}
+
+	problems, err := p.FetchProblems(context.Background(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s problems: %w", p.DisplayName(), err)
+	}
+
 	imported := 0
+	for _, info := range problems {
+		// Skip paid-only problems
+		if info.IsPaidOnly {
+			continue
+		}
 
-	switch strings.ToLower(platform) {
-	case "leetcode":
-		problems, _, err := scrapper.FetchLeetCodeProblems(limit, 0)
+		// Check if problem already exists
+		exists, err := s.problemRepo.ExistsByPlatformID(info.Platform, info.PlatformProblemID)
 		if err != nil {
-			return 0, fmt.Errorf("failed to fetch LeetCode problems: %w", err)
+			continue
+		}
+		if exists {
+			continue
 		}
 
-		for _, p := range problems {
-			// Skip paid-only problems
-			if p.IsPaidOnly {
-				continue
-			}
-
-			// Check if problem already exists
-			exists, err := s.problemRepo.ExistsByPlatformID("leetcode", p.QuestionFrontendID)
-			if err != nil {
-				continue
-			}
-			if exists {
-				continue
-			}
+		problem := &models.Problem{
+			Platform:          info.Platform,
+			PlatformProblemID: info.PlatformProblemID,
+			Title:             info.Title,
+			Slug:              info.Slug,
+			Difficulty:        info.Difficulty,
+			Rating:            info.Rating,
+			Tags:              pq.StringArray(info.Tags),
+			AcceptanceRate:    info.AcceptanceRate,
+			ProblemURL:        info.ProblemURL,
+		}
 
-			// Extract tags
-			tags := make([]string, len(p.TopicTags))
-			for i, tag := range p.TopicTags {
-				tags[i] = tag.Name
-			}
+		if err := s.problemRepo.Create(problem); err != nil {
+			continue
+		}
+		imported++
+	}
 
-			// Map difficulty to lowercase
-			difficulty := strings.ToLower(p.Difficulty)
-
-			// Create problem
-			problem := &models.Problem{
-				Platform:          "leetcode",
-				PlatformProblemID: p.QuestionFrontendID,
-				Title:             p.Title,
-				Slug:              p.TitleSlug,
-				Difficulty:        difficulty,
-				Tags:              pq.StringArray(tags),
-				AcceptanceRate:    p.AcRate,
-				ProblemURL:        fmt.Sprintf("https://leetcode.com/problems/%s/", p.TitleSlug),
-			}
+	return imported, nil
+}
 
-			if err := s.problemRepo.Create(problem); err != nil {
-				continue
-			}
-			imported++
+// FetchProblemContent fetches a problem's statement, examples and hints from its platform and
+// stores them, replacing what was there
+func (s *ProblemService) FetchProblemContent(id string) (*dto.ProblemResponse, error) {
+	problem, err := s.problemRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrProblemNotFound
 		}
+		return nil, err
+	}
 
-	case "codeforces":
-		problems, err := scrapper.FetchCodeforcesProblems()
-		if err != nil {
-			return 0, fmt.Errorf("failed to fetch Codeforces problems: %w", err)
-		}
+	p, ok := scrapper.Get(problem.Platform)
+	if !ok {
+		return nil, scrapper.ErrNotSupported
+	}
+	if err := s.storeProblemContent(context.Background(), p, problem); err != nil {
+		return nil, err
+	}
+	return mapProblemToResponse(problem), nil
+}
 
-		// Limit the number of problems
-		if limit > 0 && len(problems) > limit {
-			problems = problems[:limit]
-		}
+// EnrichProblems fetches the statements of up to limit problems on a platform that have none
+// yet. Problems that fail are moved to the back of the queue so they don't block later runs.
+func (s *ProblemService) EnrichProblems(platform string, limit int) (int, error) {
+	p, ok := scrapper.Get(strings.ToLower(platform))
+	if !ok {
+		return 0, fmt.Errorf("unsupported platform: %s", platform)
+	}
 
-		for _, p := range problems {
-			platformID := fmt.Sprintf("%d%s", p.ContestID, p.Index)
+	problems, err := s.problemRepo.FindMissingContent(p.Name(), limit)
+	if err != nil {
+		return 0, err
+	}
 
-			// Check if problem already exists
-			exists, err := s.problemRepo.ExistsByPlatformID("codeforces", platformID)
-			if err != nil {
-				continue
+	enriched := 0
+	for i := range problems {
+		problem := &problems[i]
+		if err := s.storeProblemContent(context.Background(), p, problem); err != nil {
+			if errors.Is(err, scrapper.ErrNotSupported) {
+				return 0, err
 			}
-			if exists {
-				continue
+			fmt.Printf("Warning: Failed to fetch content of %s problem %s: %v\n", p.DisplayName(), problem.Slug, err)
+			if err := s.problemRepo.Touch(problem.ID); err != nil {
+				fmt.Printf("Warning: Failed to requeue problem %s: %v\n", problem.ID, err)
 			}
+			continue
+		}
+		enriched++
+	}
 
-			// Map rating to difficulty
-			difficulty := "medium"
-			if p.Rating > 0 {
-				if p.Rating < 1200 {
-					difficulty = "easy"
-				} else if p.Rating >= 1900 {
-					difficulty = "hard"
-				}
-			}
+	return enriched, nil
+}
 
-			problem := &models.Problem{
-				Platform:          "codeforces",
-				PlatformProblemID: platformID,
-				Title:             p.Name,
-				Slug:              fmt.Sprintf("%d-%s", p.ContestID, strings.ToLower(p.Index)),
-				Difficulty:        difficulty,
-				Tags:              pq.StringArray(p.Tags),
-				AcceptanceRate:    0,
-				ProblemURL:        fmt.Sprintf("https://codeforces.com/problemset/problem/%d/%s", p.ContestID, p.Index),
-			}
+// storeProblemContent fetches a problem's content from its platform and saves it on the problem
+func (s *ProblemService) storeProblemContent(ctx context.Context, p scrapper.Platform, problem *models.Problem) error {
+	content, err := p.FetchProblemContent(ctx, problem.Slug)
+	if err != nil {
+		return err
+	}
+
+	examples := content.Examples
+	if examples == nil {
+		examples = []scrapper.ProblemExample{}
+	}
+	examplesJSON, err := json.Marshal(examples)
+	if err != nil {
+		return err
+	}
+	hints := content.Hints
+	if hints == nil {
+		hints = []string{}
+	}
+	hintsJSON, err := json.Marshal(hints)
+	if err != nil {
+		return err
+	}
+
+	problem.Description = content.Description
+	problem.Constraints = content.Constraints
+	problem.Examples = examplesJSON
+	problem.Hints = hintsJSON
+	return s.problemRepo.UpdateContent(problem)
+}
+
+// MarkProblemSolved marks a problem as solved for a user by logging an accepted submission.
+// An already solved problem gets no second submission, but the solve still counts in the
+// user's running virtual contests, which often replay problems solved before. Progress is
+// derived from the submissions, so marking a problem as unsolved changes nothing.
+func (s *ProblemService) MarkProblemSolved(userID, problemID string, isSolved bool) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	if _, err := uuid.Parse(problemID); err != nil {
+		return fmt.Errorf("invalid problem ID: %w", err)
+	}
 
-			if err := s.problemRepo.Create(problem); err != nil {
-				continue
+	if !isSolved {
+		if _, err := s.problemRepo.FindByID(problemID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrProblemNotFound
 			}
-			imported++
+			return err
 		}
-
-	default:
-		return 0, fmt.Errorf("unsupported platform: %s", platform)
+		return nil
 	}
 
-	return imported, nil
+	progress, err := s.submissionRepo.FindProgress(userID, problemID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if progress != nil && progress.IsSolved {
+		s.recordVirtualContestAttempt(userID, problemID, true)
+		return nil
+	}
+	_, err = s.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{Verdict: models.VerdictAccepted})
+	return err
 }
 
-// MarkProblemSolved marks a problem as solved for a user
-func (s *ProblemService) MarkProblemSolved(userID, problemID string, isSolved bool) error {
+// LogSubmission records an attempt at a problem and updates the user's progress summary
+func (s *ProblemService) LogSubmission(userID, problemID string, req *dto.CreateSubmissionRequest) (*dto.ProgressResponse, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
-		return fmt.Errorf("invalid user ID: %w", err)
+		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	problemUUID, err := uuid.Parse(problemID)
+	problem, err := s.problemRepo.FindByID(problemID)
 	if err != nil {
-		return fmt.Errorf("invalid problem ID: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrProblemNotFound
+		}
+		return nil, err
 	}
 
-	// Check if progress record exists
-	var progress models.UserProblemProgress
-	result := s.problemRepo.GetDB().Where("user_id = ? AND problem_id = ?", userUUID, problemUUID).First(&progress)
-
 	now := time.Now()
+	submittedAt := now
+	if req.SubmittedAt != nil {
+		if req.SubmittedAt.After(now) {
+			return nil, fmt.Errorf("%w: submitted_at is in the future", utils.ErrInvalidInput)
+		}
+		submittedAt = *req.SubmittedAt
+	}
 
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			// Create new progress record
-			progress = models.UserProblemProgress{
-				UserID:    userUUID,
-				ProblemID: problemUUID,
-				IsSolved:  isSolved,
-				Attempts:  1,
-			}
-			if isSolved {
-				progress.SolvedAt = &now
-			}
-			progress.LastAttempt = &now
+	submission := &models.ProblemSubmission{
+		UserID:           userUUID,
+		ProblemID:        problem.ID,
+		Verdict:          req.Verdict,
+		Language:         strings.TrimSpace(req.Language),
+		TimeSpentSeconds: req.TimeSpentSeconds,
+		Code:             req.Code,
+		SubmittedAt:      submittedAt,
+		Source:           models.SubmissionSourceManual,
+	}
+	progress, err := s.submissionRepo.CreateAndSummarize(submission)
+	if err != nil {
+		return nil, err
+	}
 
-			return s.problemRepo.GetDB().Create(&progress).Error
+	isSolved := req.Verdict == models.VerdictAccepted
+	s.recordVirtualContestAttempt(userID, problemID, isSolved)
+	s.scheduleReview(userID, problemID, isSolved)
+
+	return mapProgressToResponse(progress), nil
+}
+
+// GetSubmissionHistory retrieves a user's submissions on a problem with their progress summary
+func (s *ProblemService) GetSubmissionHistory(userID, problemID string, filters *dto.SubmissionFilterRequest) ([]dto.SubmissionResponse, int64, *dto.ProgressResponse, error) {
+	// Default pagination
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.Limit < 1 || filters.Limit > 100 {
+		filters.Limit = 20
+	}
+
+	problem, err := s.problemRepo.FindByID(problemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, nil, utils.ErrProblemNotFound
+		}
+		return nil, 0, nil, err
+	}
+
+	submissions, total, err := s.submissionRepo.FindByUserAndProblem(userID, problemID, filters.Page, filters.Limit)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	progress, err := s.submissionRepo.FindProgress(userID, problemID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, nil, err
+		}
+		progress = &models.UserProblemProgress{ProblemID: problem.ID}
+	}
+
+	responses := make([]dto.SubmissionResponse, len(submissions))
+	for i, submission := range submissions {
+		responses[i] = dto.SubmissionResponse{
+			ID:               submission.ID,
+			ProblemID:        submission.ProblemID,
+			Verdict:          submission.Verdict,
+			Language:         submission.Language,
+			TimeSpentSeconds: submission.TimeSpentSeconds,
+			Code:             submission.Code,
+			SubmittedAt:      submission.SubmittedAt,
 		}
-		return result.Error
 	}
 
-	// Update existing progress
-	progress.IsSolved = isSolved
-	progress.Attempts++
-	progress.LastAttempt = &now
-	if isSolved && progress.SolvedAt == nil {
-		progress.SolvedAt = &now
+	return responses, total, mapProgressToResponse(progress), nil
+}
+
+// mapProgressToResponse converts UserProblemProgress model to ProgressResponse DTO
+func mapProgressToResponse(progress *models.UserProblemProgress) *dto.ProgressResponse {
+	return &dto.ProgressResponse{
+		ProblemID:        progress.ProblemID,
+		IsSolved:         progress.IsSolved,
+		SolvedAt:         progress.SolvedAt,
+		Attempts:         progress.Attempts,
+		LastAttempt:      progress.LastAttempt,
+		LastVerdict:      progress.LastVerdict,
+		TimeSpentSeconds: progress.TimeSpentSeconds,
 	}
+}
 
-	return s.problemRepo.GetDB().Save(&progress).Error
+// recordVirtualContestAttempt counts the attempt in the user's running virtual contests.
+// The submission is already saved, so failures are only logged.
+func (s *ProblemService) recordVirtualContestAttempt(userID, problemID string, isSolved bool) {
+	if s.virtualContestService == nil {
+		return
+	}
+	if err := s.virtualContestService.RecordAttempt(userID, problemID, isSolved); err != nil {
+		fmt.Printf("Warning: failed to record virtual contest attempt for user %s: %v\n", userID, err)
+	}
+}
+
+// scheduleReview queues a solved problem for spaced repetition review.
+// The submission is already saved, so failures are only logged.
+func (s *ProblemService) scheduleReview(userID, problemID string, isSolved bool) {
+	if s.reviewService == nil || !isSolved {
+		return
+	}
+	if err := s.reviewService.ScheduleSolved(userID, problemID); err != nil {
+		fmt.Printf("Warning: failed to schedule review for user %s: %v\n", userID, err)
+	}
 }
 
 // GetUserSolvedCount returns the count of solved problems for a user
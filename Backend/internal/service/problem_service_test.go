@@ -0,0 +1,369 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"dojo/internal/websocket"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB opens a transaction on the Postgres database in DOJO_TEST_DATABASE_URL with the
+// submission tables migrated. Everything is rolled back when the test ends. Tests needing a
+// database are skipped without one.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DOJO_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DOJO_TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get test database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		t.Fatalf("failed to begin transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	err = tx.AutoMigrate(&models.User{}, &models.Problem{}, &models.UserProblemProgress{}, &models.ProblemSubmission{})
+	if err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return tx
+}
+
+// newTestProblemService returns a problem service on db with a user and a problem to log
+// submissions for
+func newTestProblemService(t *testing.T, db *gorm.DB) (*ProblemService, *models.User, *models.Problem) {
+	t.Helper()
+
+	user := &models.User{Email: uuid.NewString() + "@example.com", Username: uuid.NewString()[:20]}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	problem := &models.Problem{Platform: "codeforces", PlatformProblemID: uuid.NewString(), Title: "Watermelon"}
+	if err := db.Create(problem).Error; err != nil {
+		t.Fatalf("failed to create problem: %v", err)
+	}
+
+	service := NewProblemService(repository.NewProblemRepository(db), repository.NewSubmissionRepository(db), nil, nil)
+	return service, user, problem
+}
+
+func TestLogSubmission(t *testing.T) {
+	db := testDB(t)
+	service, user, problem := newTestProblemService(t, db)
+	userID, problemID := user.ID.String(), problem.ID.String()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	progress, err := service.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{
+		Verdict: models.VerdictWrongAnswer, TimeSpentSeconds: 600, SubmittedAt: ptr(start),
+	})
+	if err != nil {
+		t.Fatalf("failed to log wrong answer: %v", err)
+	}
+	if progress.IsSolved || progress.Attempts != 1 || progress.LastVerdict != models.VerdictWrongAnswer {
+		t.Errorf("progress after wrong answer = %+v", progress)
+	}
+
+	progress, err = service.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{
+		Verdict: models.VerdictAccepted, TimeSpentSeconds: 300, SubmittedAt: ptr(start.Add(10 * time.Minute)),
+	})
+	if err != nil {
+		t.Fatalf("failed to log accepted: %v", err)
+	}
+	if !progress.IsSolved || progress.Attempts != 2 || progress.TimeSpentSeconds != 900 ||
+		progress.SolvedAt == nil || !progress.SolvedAt.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("progress after accepted = %+v", progress)
+	}
+
+	// A late-logged earlier solve moves solved_at back but isn't the last attempt
+	progress, err = service.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{
+		Verdict: models.VerdictAccepted, SubmittedAt: ptr(start.Add(-time.Minute)),
+	})
+	if err != nil {
+		t.Fatalf("failed to log earlier accepted: %v", err)
+	}
+	if progress.Attempts != 3 || !progress.SolvedAt.Equal(start.Add(-time.Minute)) ||
+		!progress.LastAttempt.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("progress after earlier accepted = %+v", progress)
+	}
+
+	var rows int64
+	db.Model(&models.UserProblemProgress{}).Where("user_id = ? AND problem_id = ?", user.ID, problem.ID).Count(&rows)
+	if rows != 1 {
+		t.Errorf("progress rows = %d, want 1", rows)
+	}
+
+	_, err = service.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{
+		Verdict: models.VerdictAccepted, SubmittedAt: ptr(time.Now().Add(time.Hour)),
+	})
+	if !errors.Is(err, utils.ErrInvalidInput) {
+		t.Errorf("future submission: got %v, want ErrInvalidInput", err)
+	}
+
+	_, err = service.LogSubmission(userID, uuid.NewString(), &dto.CreateSubmissionRequest{Verdict: models.VerdictAccepted})
+	if !errors.Is(err, utils.ErrProblemNotFound) {
+		t.Errorf("unknown problem: got %v, want ErrProblemNotFound", err)
+	}
+}
+
+func TestGetSubmissionHistory(t *testing.T) {
+	db := testDB(t)
+	service, user, problem := newTestProblemService(t, db)
+	userID, problemID := user.ID.String(), problem.ID.String()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// Nothing logged yet
+	submissions, total, progress, err := service.GetSubmissionHistory(userID, problemID, &dto.SubmissionFilterRequest{})
+	if err != nil {
+		t.Fatalf("failed to get empty history: %v", err)
+	}
+	if len(submissions) != 0 || total != 0 || progress.ProblemID != problem.ID || progress.Attempts != 0 {
+		t.Errorf("empty history = %v, %d, %+v", submissions, total, progress)
+	}
+
+	verdicts := []string{models.VerdictWrongAnswer, models.VerdictTimeLimitExceeded, models.VerdictAccepted}
+	for i, verdict := range verdicts {
+		_, err := service.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{
+			Verdict: verdict, SubmittedAt: ptr(start.Add(time.Duration(i) * time.Minute)),
+		})
+		if err != nil {
+			t.Fatalf("failed to log %s: %v", verdict, err)
+		}
+	}
+
+	filters := &dto.SubmissionFilterRequest{Page: 1, Limit: 2}
+	submissions, total, progress, err = service.GetSubmissionHistory(userID, problemID, filters)
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if total != 3 || len(submissions) != 2 {
+		t.Fatalf("got %d of %d submissions, want 2 of 3", len(submissions), total)
+	}
+	if submissions[0].Verdict != models.VerdictAccepted || submissions[1].Verdict != models.VerdictTimeLimitExceeded {
+		t.Errorf("first page = %s, %s; want newest first", submissions[0].Verdict, submissions[1].Verdict)
+	}
+	if !progress.IsSolved || progress.Attempts != 3 {
+		t.Errorf("progress = %+v", progress)
+	}
+
+	filters = &dto.SubmissionFilterRequest{Page: 2, Limit: 2}
+	submissions, _, _, err = service.GetSubmissionHistory(userID, problemID, filters)
+	if err != nil {
+		t.Fatalf("failed to get second page: %v", err)
+	}
+	if len(submissions) != 1 || submissions[0].Verdict != models.VerdictWrongAnswer {
+		t.Errorf("second page = %v, want the wrong answer", submissions)
+	}
+
+	// Out of range limits fall back to the default
+	filters = &dto.SubmissionFilterRequest{Limit: 1000}
+	if _, _, _, err := service.GetSubmissionHistory(userID, problemID, filters); err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if filters.Page != 1 || filters.Limit != 20 {
+		t.Errorf("filters = %+v, want page 1 and limit 20", filters)
+	}
+
+	_, _, _, err = service.GetSubmissionHistory(userID, uuid.NewString(), &dto.SubmissionFilterRequest{})
+	if !errors.Is(err, utils.ErrProblemNotFound) {
+		t.Errorf("unknown problem: got %v, want ErrProblemNotFound", err)
+	}
+}
+
+func TestMarkProblemSolved(t *testing.T) {
+	db := testDB(t)
+	service, user, problem := newTestProblemService(t, db)
+	userID, problemID := user.ID.String(), problem.ID.String()
+	submissionRepo := repository.NewSubmissionRepository(db)
+
+	if _, err := service.LogSubmission(userID, problemID, &dto.CreateSubmissionRequest{
+		Verdict: models.VerdictWrongAnswer, Code: "int main() {}",
+	}); err != nil {
+		t.Fatalf("failed to log wrong answer: %v", err)
+	}
+	if err := service.MarkProblemSolved(userID, problemID, true); err != nil {
+		t.Fatalf("failed to mark solved: %v", err)
+	}
+	// Already solved, so no second submission
+	if err := service.MarkProblemSolved(userID, problemID, true); err != nil {
+		t.Fatalf("failed to mark solved again: %v", err)
+	}
+	progress, err := submissionRepo.FindProgress(userID, problemID)
+	if err != nil {
+		t.Fatalf("failed to find progress: %v", err)
+	}
+	if !progress.IsSolved || progress.Attempts != 2 {
+		t.Errorf("progress after marking solved = %+v", progress)
+	}
+
+	// Unmarking keeps the submissions and the solve
+	if err := service.MarkProblemSolved(userID, problemID, false); err != nil {
+		t.Fatalf("failed to mark unsolved: %v", err)
+	}
+	progress, err = submissionRepo.FindProgress(userID, problemID)
+	if err != nil {
+		t.Fatalf("failed to find progress: %v", err)
+	}
+	if !progress.IsSolved || progress.Attempts != 2 {
+		t.Errorf("progress after marking unsolved = %+v", progress)
+	}
+	submissions, total, err := submissionRepo.FindByUserAndProblem(userID, problemID, 1, 10)
+	if err != nil {
+		t.Fatalf("failed to find submissions: %v", err)
+	}
+	if total != 2 || len(submissions) != 2 {
+		t.Errorf("got %d submissions after marking unsolved, want 2", total)
+	}
+
+	err = service.MarkProblemSolved(userID, uuid.NewString(), false)
+	if !errors.Is(err, utils.ErrProblemNotFound) {
+		t.Errorf("unknown problem: got %v, want ErrProblemNotFound", err)
+	}
+}
+
+// roomBroadcasts records the messages pushed to rooms
+type roomBroadcasts []uuid.UUID
+
+func (b *roomBroadcasts) BroadcastToRoom(roomID uuid.UUID, messageType websocket.MessageType, data interface{}) error {
+	*b = append(*b, roomID)
+	return nil
+}
+
+func TestMarkProblemSolvedInVirtualContest(t *testing.T) {
+	db := testDB(t)
+	err := db.AutoMigrate(&models.Room{}, &models.VirtualContest{}, &models.VirtualContestProblem{},
+		&models.VirtualContestParticipant{}, &models.VirtualContestSubmission{})
+	if err != nil {
+		t.Fatalf("failed to migrate virtual contests: %v", err)
+	}
+	_, user, problem := newTestProblemService(t, db)
+	userID, problemID := user.ID.String(), problem.ID.String()
+
+	room := &models.Room{Name: "Replay", RoomCode: uuid.NewString()[:20], CreatedBy: &user.ID}
+	if err := db.Create(room).Error; err != nil {
+		t.Fatalf("failed to create room: %v", err)
+	}
+	contest := &models.VirtualContest{
+		Name:            "Replay",
+		CreatedBy:       user.ID,
+		RoomID:          room.ID,
+		DurationMinutes: 120,
+		StartedAt:       ptr(time.Now().Add(-10 * time.Minute)),
+		Problems:        []models.VirtualContestProblem{{ProblemID: problem.ID}},
+		Participants:    []models.VirtualContestParticipant{{UserID: user.ID, Status: models.VirtualParticipantJoined}},
+	}
+	if err := db.Omit("Creator", "SourceContest", "Room").Create(contest).Error; err != nil {
+		t.Fatalf("failed to create virtual contest: %v", err)
+	}
+
+	var broadcasts roomBroadcasts
+	virtualContestService := NewVirtualContestService(repository.NewVirtualContestRepository(db), repository.NewProblemRepository(db),
+		nil, nil, nil, nil, nil, &broadcasts)
+	service := NewProblemService(repository.NewProblemRepository(db), repository.NewSubmissionRepository(db), virtualContestService, nil)
+
+	// Solved long before the contest and imported from the platform
+	_, err = repository.NewSubmissionRepository(db).ImportAndSummarize(user.ID, "codeforces", []models.ProblemSubmission{{
+		ProblemID: problem.ID, Verdict: models.VerdictAccepted, SubmittedAt: time.Now().Add(-30 * 24 * time.Hour), ExternalID: "123",
+	}})
+	if err != nil {
+		t.Fatalf("failed to import the earlier solve: %v", err)
+	}
+
+	if err := service.MarkProblemSolved(userID, problemID, true); err != nil {
+		t.Fatalf("failed to mark solved: %v", err)
+	}
+
+	var submission models.VirtualContestSubmission
+	err = db.Where("virtual_contest_id = ? AND user_id = ? AND problem_id = ?", contest.ID, user.ID, problem.ID).
+		First(&submission).Error
+	if err != nil {
+		t.Fatalf("the solve wasn't recorded in the virtual contest: %v", err)
+	}
+	if submission.SolvedAt == nil {
+		t.Errorf("virtual contest submission = %+v, want solved", submission)
+	}
+	if len(broadcasts) != 1 || broadcasts[0] != room.ID {
+		t.Errorf("broadcasts = %v, want one to room %s", broadcasts, room.ID)
+	}
+
+	var submissions int64
+	db.Model(&models.ProblemSubmission{}).Where("user_id = ? AND problem_id = ?", user.ID, problem.ID).Count(&submissions)
+	if submissions != 1 {
+		t.Errorf("got %d submissions, want only the earlier solve", submissions)
+	}
+}
+
+func TestBackfillFromProgress(t *testing.T) {
+	db := testDB(t)
+	service, user, solved := newTestProblemService(t, db)
+	submissionRepo := repository.NewSubmissionRepository(db)
+	solvedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+
+	attempted := &models.Problem{Platform: "codeforces", PlatformProblemID: uuid.NewString(), Title: "Way Too Long Words"}
+	if err := db.Create(attempted).Error; err != nil {
+		t.Fatalf("failed to create problem: %v", err)
+	}
+
+	// Progress recorded before the submission log
+	legacy := []models.UserProblemProgress{
+		{UserID: user.ID, ProblemID: solved.ID, IsSolved: true, SolvedAt: &solvedAt, Attempts: 5, LastAttempt: &solvedAt},
+		{UserID: user.ID, ProblemID: attempted.ID, Attempts: 3, LastAttempt: &solvedAt},
+	}
+	if err := db.Omit("User", "Problem").Create(&legacy).Error; err != nil {
+		t.Fatalf("failed to create progress: %v", err)
+	}
+
+	backfilled, err := submissionRepo.BackfillFromProgress()
+	if err != nil {
+		t.Fatalf("failed to backfill: %v", err)
+	}
+	if backfilled != 8 {
+		t.Errorf("backfilled %d submissions, want 8", backfilled)
+	}
+	if again, err := submissionRepo.BackfillFromProgress(); err != nil || again != 0 {
+		t.Errorf("second backfill = %d, %v; want nothing", again, err)
+	}
+
+	// Logging another attempt recomputes the summary from the backfilled submissions
+	for _, problem := range []*models.Problem{solved, attempted} {
+		progress, err := service.LogSubmission(user.ID.String(), problem.ID.String(), &dto.CreateSubmissionRequest{Verdict: models.VerdictWrongAnswer})
+		if err != nil {
+			t.Fatalf("failed to log submission: %v", err)
+		}
+
+		want := legacy[0]
+		if problem == attempted {
+			want = legacy[1]
+		}
+		if progress.IsSolved != want.IsSolved || progress.Attempts != want.Attempts+1 {
+			t.Errorf("progress on %s = %+v, want solved %v after %d attempts", problem.Title, progress, want.IsSolved, want.Attempts+1)
+		}
+		if want.IsSolved && !progress.SolvedAt.Equal(solvedAt) {
+			t.Errorf("solved at %s, want %s", progress.SolvedAt, solvedAt)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultRecommendationRating is used when a user has no rating and no rated solves
+	defaultRecommendationRating = 1200
+	// Recommended problems are rated between these offsets above the user's rating
+	recommendationMinOffset = 100
+	recommendationMaxOffset = 300
+	// weakTagCount is how many weak tags recommendations are drawn from
+	weakTagCount = 4
+	// popularTagPool limits weak tags to the most common ones, so rare tags are not suggested
+	popularTagPool = 25
+	// weakTagMinAttempts is how many problems of a tag must be tried before its solve rate counts
+	weakTagMinAttempts = 3
+)
+
+type RecommendationService struct {
+	problemRepo *repository.ProblemRepository
+	userRepo    *repository.UserRepository
+}
+
+func NewRecommendationService(problemRepo *repository.ProblemRepository, userRepo *repository.UserRepository) *RecommendationService {
+	return &RecommendationService{
+		problemRepo: problemRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// GetRecommendations suggests unsolved problems from the user's weakest tags, rated slightly
+// above their current rating. The picks are also returned as a sheet draft the user can save.
+func (s *RecommendationService) GetRecommendations(userID string, req *dto.RecommendationRequest) (*dto.RecommendationResponse, error) {
+	if req.Limit < 1 || req.Limit > 50 {
+		req.Limit = 10
+	}
+
+	rating, source, err := s.userRating(userID)
+	if err != nil {
+		return nil, err
+	}
+	minRating := rating + recommendationMinOffset
+	maxRating := rating + recommendationMaxOffset
+	targetRating := (minRating + maxRating) / 2
+	difficulty := difficultyForRating(targetRating)
+
+	weakTags, err := s.findWeakTags(userID, req.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch candidates per tag, then take them round-robin so every weak tag is represented
+	candidates := make([][]models.Problem, len(weakTags))
+	for i, tag := range weakTags {
+		candidates[i], err = s.problemRepo.FindUnsolvedByTag(userID, tag.Tag, req.Platform, minRating, maxRating, targetRating, difficulty, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	picks := make([]dto.RecommendedProblemResponse, 0, req.Limit)
+	picked := make(map[string]bool)
+	for round := 0; len(picks) < req.Limit; round++ {
+		added := false
+		for i, tag := range weakTags {
+			if round >= len(candidates[i]) || len(picks) == req.Limit {
+				continue
+			}
+			added = true
+			problem := &candidates[i][round]
+			if picked[problem.ID.String()] {
+				continue
+			}
+			picked[problem.ID.String()] = true
+			picks = append(picks, dto.RecommendedProblemResponse{
+				Problem: mapProblemSummary(problem),
+				Tag:     tag.Tag,
+				Reason:  recommendationReason(tag, problem, rating, source),
+			})
+		}
+		if !added {
+			break
+		}
+	}
+
+	tagNames := make([]string, len(weakTags))
+	for i, tag := range weakTags {
+		tagNames[i] = tag.Tag
+	}
+	sheet := dto.CreateSheetRequest{
+		Name:        "Recommended: " + strings.Join(tagNames, ", "),
+		Description: fmt.Sprintf("Practice for your weakest tags around rating %d, generated on %s", targetRating, time.Now().Format("2006-01-02")),
+		Problems:    make([]dto.SheetProblemInput, len(picks)),
+	}
+	if len(tagNames) == 0 {
+		sheet.Name = "Recommended problems"
+	}
+	for i, pick := range picks {
+		sheet.Problems[i] = dto.SheetProblemInput{ProblemID: pick.Problem.ID, Notes: pick.Reason}
+	}
+
+	return &dto.RecommendationResponse{
+		UserRating:   rating,
+		RatingSource: source,
+		TargetRating: targetRating,
+		MinRating:    minRating,
+		MaxRating:    maxRating,
+		WeakTags:     weakTags,
+		Problems:     picks,
+		Sheet:        sheet,
+	}, nil
+}
+
+// userRating returns the user's Codeforces rating, falling back to the average rating of the
+// rated problems they solved and then to a default
+func (s *RecommendationService) userRating(userID string) (int, string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, "", utils.ErrUserNotFound
+		}
+		return 0, "", err
+	}
+	if err := s.userRepo.LoadPlatformStats(user); err != nil {
+		return 0, "", err
+	}
+	for _, stat := range user.PlatformStats {
+		if stat.Platform == "codeforces" && stat.Rating > 0 {
+			return stat.Rating, "codeforces", nil
+		}
+	}
+
+	ratings, err := s.problemRepo.FindSolvedRatings(userID)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(ratings) > 0 {
+		sum := 0
+		for _, r := range ratings {
+			sum += r
+		}
+		return sum / len(ratings), "solved_problems", nil
+	}
+
+	return defaultRecommendationRating, "default", nil
+}
+
+// findWeakTags returns the weakest of the most common tags, see rankWeakTags
+func (s *RecommendationService) findWeakTags(userID, platform string) ([]dto.TagStatResponse, error) {
+	popular, err := s.problemRepo.FindPopularTags(platform, popularTagPool)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.problemRepo.FindUserTagStats(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rankWeakTags(popular, stats), nil
+}
+
+// rankWeakTags picks the weakTagCount weakest of the popular tags, given most common first. Tags
+// tried at least weakTagMinAttempts times rank first, by lowest solve rate; a rate from fewer
+// attempts says little, so the other tags follow by fewest solved.
+func rankWeakTags(popular []string, stats []repository.TagStat) []dto.TagStatResponse {
+	byTag := make(map[string]repository.TagStat, len(stats))
+	for _, stat := range stats {
+		byTag[stat.Tag] = stat
+	}
+
+	tags := make([]dto.TagStatResponse, len(popular))
+	for i, tag := range popular {
+		stat := byTag[tag]
+		tags[i] = dto.TagStatResponse{
+			Tag:       tag,
+			Attempted: stat.Attempted,
+			Solved:    stat.Solved,
+		}
+		if stat.Attempted > 0 {
+			tags[i].SolveRate = float64(stat.Solved) / float64(stat.Attempted)
+		}
+	}
+
+	// Stable, so popularity decides between equally weak tags
+	sort.SliceStable(tags, func(i, j int) bool {
+		a, b := tags[i], tags[j]
+		aRated, bRated := a.Attempted >= weakTagMinAttempts, b.Attempted >= weakTagMinAttempts
+		if aRated != bRated {
+			return aRated
+		}
+		if aRated && a.SolveRate != b.SolveRate {
+			return a.SolveRate < b.SolveRate
+		}
+		return a.Solved < b.Solved
+	})
+
+	if len(tags) > weakTagCount {
+		tags = tags[:weakTagCount]
+	}
+	return tags
+}
+
+// difficultyForRating maps a Codeforces-style rating to the difficulty used for unrated problems
+func difficultyForRating(rating int) string {
+	switch {
+	case rating < 1300:
+		return "easy"
+	case rating < 1900:
+		return "medium"
+	default:
+		return "hard"
+	}
+}
+
+// recommendationReason explains why a problem was recommended
+func recommendationReason(tag dto.TagStatResponse, problem *models.Problem, rating int, source string) string {
+	var reason string
+	switch {
+	case tag.Solved == 0:
+		reason = fmt.Sprintf("You haven't solved any %s problems yet", tag.Tag)
+	case tag.Solved < tag.Attempted:
+		reason = fmt.Sprintf("You've solved only %d of the %d %s problems you tried", tag.Solved, tag.Attempted, tag.Tag)
+	case tag.Solved < 10:
+		reason = fmt.Sprintf("You've solved only %d %s %s", tag.Solved, tag.Tag, pluralize(tag.Solved, "problem"))
+	default:
+		reason = fmt.Sprintf("%s is one of your least practiced tags (%d solved)", tag.Tag, tag.Solved)
+	}
+
+	switch {
+	case problem.Rating == 0:
+		reason += fmt.Sprintf("; %s difficulty fits your level", problem.Difficulty)
+	case source == "default":
+		reason += fmt.Sprintf("; rated %d, a good starting point", problem.Rating)
+	default:
+		reason += fmt.Sprintf("; rated %d, slightly above your %d rating", problem.Rating, rating)
+	}
+	return reason
+}
+
+// pluralize adds an "s" to word unless n is 1
+func pluralize(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"dojo/internal/repository"
+	"reflect"
+	"testing"
+)
+
+func TestRankWeakTags(t *testing.T) {
+	popular := []string{"dp", "greedy", "math", "graphs", "strings", "trees"}
+
+	tests := []struct {
+		name  string
+		stats []repository.TagStat
+		want  []string
+	}{
+		{
+			name: "nothing tried keeps the most common tags",
+			want: []string{"dp", "greedy", "math", "graphs"},
+		},
+		{
+			name: "lowest solve rate first, not fewest solved",
+			stats: []repository.TagStat{
+				{Tag: "dp", Attempted: 40, Solved: 10},
+				{Tag: "greedy", Attempted: 3, Solved: 3},
+				{Tag: "math", Attempted: 10, Solved: 9},
+				{Tag: "graphs", Attempted: 20, Solved: 10},
+				{Tag: "strings", Attempted: 5, Solved: 4},
+				{Tag: "trees", Attempted: 5, Solved: 1},
+			},
+			want: []string{"trees", "dp", "graphs", "strings"},
+		},
+		{
+			name: "rates from too few attempts come after",
+			stats: []repository.TagStat{
+				{Tag: "dp", Attempted: 10, Solved: 9},
+				{Tag: "greedy", Attempted: 1, Solved: 0},
+				{Tag: "math", Attempted: 2, Solved: 0},
+				{Tag: "graphs", Attempted: 3, Solved: 3},
+			},
+			want: []string{"dp", "graphs", "greedy", "math"},
+		},
+		{
+			name: "below the minimum, fewest solved first",
+			stats: []repository.TagStat{
+				{Tag: "dp", Attempted: 2, Solved: 2},
+				{Tag: "greedy", Attempted: 2, Solved: 1},
+				{Tag: "math", Attempted: 1, Solved: 1},
+			},
+			want: []string{"graphs", "strings", "trees", "greedy"},
+		},
+		{
+			name: "equal rates go to fewer solved, then the more common tag",
+			stats: []repository.TagStat{
+				{Tag: "dp", Attempted: 10, Solved: 5},
+				{Tag: "greedy", Attempted: 4, Solved: 2},
+				{Tag: "math", Attempted: 4, Solved: 2},
+				{Tag: "graphs", Attempted: 6, Solved: 3},
+			},
+			want: []string{"greedy", "math", "graphs", "dp"},
+		},
+		{
+			name: "tags outside the popular ones are ignored",
+			stats: []repository.TagStat{
+				{Tag: "fft", Attempted: 10, Solved: 0},
+			},
+			want: []string{"dp", "greedy", "math", "graphs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := rankWeakTags(popular, tt.stats)
+			got := make([]string, len(tags))
+			for i, tag := range tags {
+				got[i] = tag.Tag
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("weak tags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankWeakTagsSolveRate(t *testing.T) {
+	tags := rankWeakTags([]string{"dp", "math"}, []repository.TagStat{{Tag: "dp", Attempted: 8, Solved: 2}})
+
+	if tags[0].Tag != "dp" || tags[0].Attempted != 8 || tags[0].Solved != 2 || tags[0].SolveRate != 0.25 {
+		t.Errorf("dp = %+v, want 2 of 8 solved at rate 0.25", tags[0])
+	}
+	if tags[1].Tag != "math" || tags[1].Attempted != 0 || tags[1].SolveRate != 0 {
+		t.Errorf("math = %+v, want nothing tried", tags[1])
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"bytes"
+	"dojo/internal/config"
+	"dojo/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// ReminderChannel delivers a due contest reminder to its user.
+// Reminders are passed with User and Contest preloaded.
+type ReminderChannel interface {
+	Name() string
+	Send(reminder *models.ContestReminder) error
+}
+
+// InAppReminderChannel stores the reminder as an in-app notification
+type InAppReminderChannel struct {
+	notificationService *NotificationService
+}
+
+func NewInAppReminderChannel(notificationService *NotificationService) *InAppReminderChannel {
+	return &InAppReminderChannel{
+		notificationService: notificationService,
+	}
+}
+
+func (c *InAppReminderChannel) Name() string {
+	return "in_app"
+}
+
+func (c *InAppReminderChannel) Send(reminder *models.ContestReminder) error {
+	return c.notificationService.NotifyContestReminder(reminder)
+}
+
+// EmailReminderChannel sends the reminder to the user's email over SMTP
+type EmailReminderChannel struct {
+	cfg config.SMTPConfig
+}
+
+func NewEmailReminderChannel(cfg config.SMTPConfig) *EmailReminderChannel {
+	return &EmailReminderChannel{
+		cfg: cfg,
+	}
+}
+
+func (c *EmailReminderChannel) Name() string {
+	return "email"
+}
+
+func (c *EmailReminderChannel) Send(reminder *models.ContestReminder) error {
+	if reminder.User.Email == "" {
+		return errors.New("user has no email address")
+	}
+
+	contest := reminder.Contest
+	subject := fmt.Sprintf("Reminder: %s starts in %d minutes", contest.Name, reminder.RemindBeforeMinutes)
+	body := fmt.Sprintf(
+		"Hi %s,\r\n\r\n%s on %s starts at %s.\r\n\r\n%s\r\n",
+		reminder.User.Username,
+		contest.Name,
+		contest.Platform,
+		contest.StartTime.UTC().Format("Mon, 02 Jan 2006 15:04 MST"),
+		contest.ContestURL,
+	)
+
+	var msg strings.Builder
+	msg.WriteString("From: " + c.cfg.From + "\r\n")
+	msg.WriteString("To: " + reminder.User.Email + "\r\n")
+	msg.WriteString("Subject: " + encodeHeader(subject) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	var auth smtp.Auth
+	if c.cfg.Username != "" {
+		auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(c.cfg.Host, c.cfg.Port)
+	return smtp.SendMail(addr, auth, c.cfg.From, []string{reminder.User.Email}, []byte(msg.String()))
+}
+
+// encodeHeader makes text safe for a mail header value. Line breaks (e.g. from a contest name)
+// could otherwise inject headers, so all whitespace runs become one space, and non-ASCII text
+// is encoded as an RFC 2047 encoded-word.
+func encodeHeader(value string) string {
+	return mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(value), " "))
+}
+
+// WebhookReminderChannel POSTs the reminder as JSON to an outbound webhook
+type WebhookReminderChannel struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookReminderChannel(url string) *WebhookReminderChannel {
+	return &WebhookReminderChannel{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *WebhookReminderChannel) Name() string {
+	return "webhook"
+}
+
+func (c *WebhookReminderChannel) Send(reminder *models.ContestReminder) error {
+	payload := map[string]interface{}{
+		"event":                 NotificationTypeContestReminder,
+		"reminder_id":           reminder.ID,
+		"user_id":               reminder.UserID,
+		"username":              reminder.User.Username,
+		"remind_before_minutes": reminder.RemindBeforeMinutes,
+		"contest": map[string]interface{}{
+			"id":               reminder.Contest.ID,
+			"platform":         reminder.Contest.Platform,
+			"name":             reminder.Contest.Name,
+			"start_time":       reminder.Contest.StartTime,
+			"duration_seconds": reminder.Contest.DurationSeconds,
+			"contest_url":      reminder.Contest.ContestURL,
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"mime"
+	"strings"
+	"testing"
+)
+
+func TestEncodeHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "Reminder: Codeforces Round 900 starts in 30 minutes", "Reminder: Codeforces Round 900 starts in 30 minutes"},
+		{"header injection", "Round 1\r\nBcc: victim@example.com", "Round 1 Bcc: victim@example.com"},
+		{"bare line feed", "Round 1\n\nbody", "Round 1 body"},
+		{"tabs and repeated spaces", " Round\t 1  ", "Round 1"},
+		{"non-ascii", "Reminder: Ünïcode Cup 🏆", "Reminder: Ünïcode Cup 🏆"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := encodeHeader(tt.value)
+			if strings.ContainsAny(got, "\r\n") {
+				t.Fatalf("encodeHeader(%q) = %q contains a line break", tt.value, got)
+			}
+			decoded, err := new(mime.WordDecoder).DecodeHeader(got)
+			if err != nil {
+				t.Fatalf("decoding %q: %v", got, err)
+			}
+			if decoded != tt.want {
+				t.Errorf("encodeHeader(%q) decodes to %q, want %q", tt.value, decoded, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"dojo/internal/repository"
+	"log"
+	"time"
+)
+
+// reminderBatchSize caps how many reminders are claimed per tick
+const reminderBatchSize = 100
+
+// ReminderDispatchService periodically delivers due contest reminders
+type ReminderDispatchService struct {
+	contestRepo *repository.ContestRepository
+	channels    []ReminderChannel
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+}
+
+// NewReminderDispatchService creates a new reminder dispatcher delivering through the given channels
+func NewReminderDispatchService(contestRepo *repository.ContestRepository, channels ...ReminderChannel) *ReminderDispatchService {
+	return &ReminderDispatchService{
+		contestRepo: contestRepo,
+		channels:    channels,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins checking for due reminders
+func (s *ReminderDispatchService) Start(interval time.Duration) {
+	log.Println("Starting reminder dispatch service...")
+
+	s.dispatchDueReminders()
+
+	s.ticker = time.NewTicker(interval)
+
+	go func() {
+		for {
+			select {
+			case <-s.ticker.C:
+				s.dispatchDueReminders()
+			case <-s.stopChan:
+				s.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the reminder dispatcher
+func (s *ReminderDispatchService) Stop() {
+	log.Println("Stopping reminder dispatch service...")
+	close(s.stopChan)
+}
+
+// dispatchDueReminders claims every due reminder and sends it through all channels.
+// Reminders are marked notified before delivery, so a failed channel is logged and not retried.
+func (s *ReminderDispatchService) dispatchDueReminders() {
+	now := time.Now()
+
+	expired, err := s.contestRepo.ExpireMissedReminders(now)
+	if err != nil {
+		log.Printf("Error expiring missed reminders: %v\n", err)
+	} else if expired > 0 {
+		log.Printf("Skipped %d reminders for contests that already started\n", expired)
+	}
+
+	for {
+		reminders, err := s.contestRepo.ClaimDueReminders(now, reminderBatchSize)
+		if err != nil {
+			log.Printf("Error claiming due reminders: %v\n", err)
+			return
+		}
+
+		for i := range reminders {
+			for _, channel := range s.channels {
+				if err := channel.Send(&reminders[i]); err != nil {
+					log.Printf("Warning: Failed to send reminder %s via %s: %v\n", reminders[i].ID, channel.Name(), err)
+				}
+			}
+		}
+
+		if len(reminders) > 0 {
+			log.Printf("Dispatched %d contest reminders\n", len(reminders))
+		}
+		if len(reminders) < reminderBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// minEaseFactor is the lowest SM-2 ease factor, so intervals always grow after a good recall
+const minEaseFactor = 1.3
+
+type ReviewService struct {
+	reviewRepo  *repository.ReviewRepository
+	problemRepo *repository.ProblemRepository
+}
+
+func NewReviewService(reviewRepo *repository.ReviewRepository, problemRepo *repository.ProblemRepository) *ReviewService {
+	return &ReviewService{
+		reviewRepo:  reviewRepo,
+		problemRepo: problemRepo,
+	}
+}
+
+// ScheduleSolved queues a freshly solved problem for its first review tomorrow. The solve
+// counts as the first successful recall, so a good review then moves it 6 days out. Problems
+// that are already scheduled keep their schedule.
+func (s *ReviewService) ScheduleSolved(userID, problemID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	problemUUID, err := uuid.Parse(problemID)
+	if err != nil {
+		return fmt.Errorf("invalid problem ID: %w", err)
+	}
+
+	return s.reviewRepo.CreateIfMissing(&models.ProblemReview{
+		UserID:       userUUID,
+		ProblemID:    problemUUID,
+		EaseFactor:   models.DefaultEaseFactor,
+		IntervalDays: 1,
+		Repetitions:  1,
+		DueAt:        time.Now().AddDate(0, 0, 1),
+	})
+}
+
+// ReviewProblem records how well the user recalled a problem and reschedules it with SM-2.
+// A problem without a review yet is scheduled from scratch.
+func (s *ReviewService) ReviewProblem(userID, problemID string, req *dto.ReviewRequest) (*dto.ReviewResponse, error) {
+	if req.Quality == nil || *req.Quality < 0 || *req.Quality > 5 {
+		return nil, fmt.Errorf("%w: quality must be between 0 and 5", utils.ErrInvalidInput)
+	}
+
+	review, err := s.reviewRepo.FindByUserAndProblem(userID, problemID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		problem, err := s.problemRepo.FindByID(problemID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, utils.ErrProblemNotFound
+			}
+			return nil, err
+		}
+		if err := s.ScheduleSolved(userID, problemID); err != nil {
+			return nil, err
+		}
+		if review, err = s.reviewRepo.FindByUserAndProblem(userID, problemID); err != nil {
+			return nil, err
+		}
+		review.Problem = *problem
+	}
+
+	now := time.Now()
+	scheduleReview(review, *req.Quality, now)
+
+	if err := s.reviewRepo.Update(review); err != nil {
+		return nil, err
+	}
+
+	return mapReviewToResponse(review, now), nil
+}
+
+// GetDueReviews retrieves the problems the user should re-solve by the end of today
+func (s *ReviewService) GetDueReviews(userID string, req *dto.DueReviewsRequest) (*dto.DueReviewsResponse, error) {
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 50
+	}
+
+	loc := time.UTC
+	if req.TZ != "" {
+		var err error
+		if loc, err = time.LoadLocation(req.TZ); err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", utils.ErrInvalidInput, req.TZ)
+		}
+	}
+
+	now := time.Now()
+	today := now.In(loc)
+	until := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, loc)
+
+	reviews, total, err := s.reviewRepo.FindDue(userID, until, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := s.reviewRepo.CountScheduled(userID, until)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReviewResponse, len(reviews))
+	for i := range reviews {
+		responses[i] = *mapReviewToResponse(&reviews[i], now)
+	}
+
+	return &dto.DueReviewsResponse{
+		Reviews:   responses,
+		Total:     total,
+		Scheduled: scheduled,
+		Until:     until,
+	}, nil
+}
+
+// RemoveReview takes a problem out of the user's review queue
+func (s *ReviewService) RemoveReview(userID, problemID string) error {
+	deleted, err := s.reviewRepo.Delete(userID, problemID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return utils.ErrReviewNotFound
+	}
+	return nil
+}
+
+// scheduleReview applies one SM-2 step. A failed recall (quality below 3) restarts the
+// interval at one day and, as in classic SM-2, leaves the ease factor alone, so one bad day
+// doesn't shorten every later interval. Otherwise intervals go 1, 6, then grow by the ease
+// factor, which moves up or down with the quality.
+func scheduleReview(review *models.ProblemReview, quality int, now time.Time) {
+	if quality < 3 {
+		review.Repetitions = 0
+		review.IntervalDays = 1
+	} else {
+		switch review.Repetitions {
+		case 0:
+			review.IntervalDays = 1
+		case 1:
+			review.IntervalDays = 6
+		default:
+			review.IntervalDays = int(math.Round(float64(review.IntervalDays) * review.EaseFactor))
+		}
+		review.Repetitions++
+
+		miss := float64(5 - quality)
+		review.EaseFactor += 0.1 - miss*(0.08+miss*0.02)
+		if review.EaseFactor < minEaseFactor {
+			review.EaseFactor = minEaseFactor
+		}
+	}
+
+	review.ReviewCount++
+	review.LastQuality = &quality
+	review.LastReviewedAt = &now
+	review.DueAt = now.AddDate(0, 0, review.IntervalDays)
+}
+
+// mapReviewToResponse converts ProblemReview model to ReviewResponse DTO
+func mapReviewToResponse(review *models.ProblemReview, now time.Time) *dto.ReviewResponse {
+	overdue := 0
+	if now.After(review.DueAt) {
+		overdue = int(now.Sub(review.DueAt).Hours() / 24)
+	}
+
+	// Only solved problems are reviewed
+	problem := mapProblemSummary(&review.Problem)
+	problem.IsSolved = true
+
+	return &dto.ReviewResponse{
+		ID:             review.ID,
+		Problem:        problem,
+		EaseFactor:     math.Round(review.EaseFactor*100) / 100,
+		IntervalDays:   review.IntervalDays,
+		Repetitions:    review.Repetitions,
+		ReviewCount:    review.ReviewCount,
+		LastQuality:    review.LastQuality,
+		LastReviewedAt: review.LastReviewedAt,
+		DueAt:          review.DueAt,
+		OverdueDays:    overdue,
+	}
+}
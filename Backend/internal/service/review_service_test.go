@@ -0,0 +1,93 @@
+package service
+
+import (
+	"dojo/internal/models"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestScheduleReview(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		review          models.ProblemReview
+		quality         int
+		wantInterval    int
+		wantRepetitions int
+		wantEase        float64
+	}{
+		{
+			name:            "first review after the solve",
+			review:          models.ProblemReview{EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
+			quality:         4,
+			wantInterval:    6,
+			wantRepetitions: 2,
+			wantEase:        2.5,
+		},
+		{
+			name:            "interval grows by the ease factor",
+			review:          models.ProblemReview{EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
+			quality:         5,
+			wantInterval:    15,
+			wantRepetitions: 3,
+			wantEase:        2.6,
+		},
+		{
+			name:            "hard recall lowers the ease factor",
+			review:          models.ProblemReview{EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
+			quality:         3,
+			wantInterval:    15,
+			wantRepetitions: 3,
+			wantEase:        2.36,
+		},
+		{
+			name:            "ease factor stays above the minimum",
+			review:          models.ProblemReview{EaseFactor: 1.35, IntervalDays: 10, Repetitions: 4},
+			quality:         3,
+			wantInterval:    14,
+			wantRepetitions: 5,
+			wantEase:        1.3,
+		},
+		{
+			name:            "failed recall restarts without touching the ease factor",
+			review:          models.ProblemReview{EaseFactor: 2.2, IntervalDays: 40, Repetitions: 5},
+			quality:         1,
+			wantInterval:    1,
+			wantRepetitions: 0,
+			wantEase:        2.2,
+		},
+		{
+			name:            "first success after a failure",
+			review:          models.ProblemReview{EaseFactor: 2.2, IntervalDays: 1, Repetitions: 0},
+			quality:         4,
+			wantInterval:    1,
+			wantRepetitions: 1,
+			wantEase:        2.2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review := tt.review
+			scheduleReview(&review, tt.quality, now)
+
+			if review.IntervalDays != tt.wantInterval {
+				t.Errorf("IntervalDays = %d, want %d", review.IntervalDays, tt.wantInterval)
+			}
+			if review.Repetitions != tt.wantRepetitions {
+				t.Errorf("Repetitions = %d, want %d", review.Repetitions, tt.wantRepetitions)
+			}
+			if math.Abs(review.EaseFactor-tt.wantEase) > 1e-9 {
+				t.Errorf("EaseFactor = %v, want %v", review.EaseFactor, tt.wantEase)
+			}
+			if !review.DueAt.Equal(now.AddDate(0, 0, tt.wantInterval)) {
+				t.Errorf("DueAt = %s, want %d days after %s", review.DueAt, tt.wantInterval, now)
+			}
+			if review.ReviewCount != tt.review.ReviewCount+1 || review.LastQuality == nil || *review.LastQuality != tt.quality {
+				t.Errorf("ReviewCount = %d, LastQuality = %v; want the review recorded", review.ReviewCount, review.LastQuality)
+			}
+		})
+	}
+}
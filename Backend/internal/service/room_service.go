@@ -342,22 +342,7 @@ func (s *RoomService) mapCodeSessionToResponse(session *models.CodeSession) *dto
 	}
 
 	if session.Problem != nil {
-		response.Problem = &dto.ProblemResponse{
-			ID:                session.Problem.ID,
-			Platform:          session.Problem.Platform,
-			PlatformProblemID: session.Problem.PlatformProblemID,
-			Title:             session.Problem.Title,
-			Slug:              session.Problem.Slug,
-			Difficulty:        session.Problem.Difficulty,
-			Tags:              session.Problem.Tags,
-			AcceptanceRate:    session.Problem.AcceptanceRate,
-			ProblemURL:        session.Problem.ProblemURL,
-			Description:       session.Problem.Description,
-			Constraints:       session.Problem.Constraints,
-			Examples:          session.Problem.Examples,
-			Hints:             session.Problem.Hints,
-			CreatedAt:         session.Problem.CreatedAt,
-		}
+		response.Problem = mapProblemToResponse(session.Problem)
 	}
 
 	return response
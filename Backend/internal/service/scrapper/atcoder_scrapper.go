@@ -0,0 +1,444 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// atcoderHeaders are sent with every AtCoder and AtCoder Problems request
+var atcoderHeaders = map[string]string{
+	"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Accept":     "application/json",
+}
+
+// AtCoderHistoryEntry is one contest in a user's AtCoder rating history
+type AtCoderHistoryEntry struct {
+	IsRated           bool   `json:"IsRated"`
+	Place             int    `json:"Place"`
+	OldRating         int    `json:"OldRating"`
+	NewRating         int    `json:"NewRating"`
+	Performance       int    `json:"Performance"`
+	ContestName       string `json:"ContestName"`
+	ContestScreenName string `json:"ContestScreenName"`
+	EndTime           string `json:"EndTime"`
+}
+
+// FetchAtCoderStats fetches rating statistics from AtCoder and the solved count from AtCoder Problems
+func FetchAtCoderStats(ctx context.Context, username string) (*PlatformStats, error) {
+	// Extract username from URL if full URL is provided
+	// Examples: "atcoder.jp/users/username" -> "username"
+	//           "https://atcoder.jp/users/username/history" -> "username"
+	if strings.Contains(username, "atcoder.jp") {
+		// Remove protocol if present
+		username = strings.TrimPrefix(username, "https://")
+		username = strings.TrimPrefix(username, "http://")
+		// Remove domain
+		username = strings.TrimPrefix(username, "atcoder.jp/")
+		// Remove users prefix if present
+		username = strings.TrimPrefix(username, "users/")
+		// Remove trailing parts like /history
+		if idx := strings.Index(username, "/"); idx != -1 {
+			username = username[:idx]
+		}
+	}
+
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	url := fmt.Sprintf("%s/users/%s/history/json", endpoints.AtCoder, username)
+
+	resp, err := defaultClient.Do(ctx, "atcoder", Request{
+		URL:    url,
+		Header: atcoderHeaders,
+	})
+	if err != nil {
+		// Network error - return placeholder with verification link
+		return &PlatformStats{
+			Rating:         0,
+			MaxRating:      0,
+			ProblemsSolved: 0,
+		}, fmt.Errorf("AtCoder is currently unavailable. Verify username at: https://atcoder.jp/users/%s", username)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return &PlatformStats{
+			Rating:         0,
+			MaxRating:      0,
+			ProblemsSolved: 0,
+		}, fmt.Errorf("AtCoder denied access (Status %d). Try again later or verify at: https://atcoder.jp/users/%s", resp.StatusCode, username)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("AtCoder user '%s' not found. Verify at: https://atcoder.jp/users/%s", username, username)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &PlatformStats{
+			Rating:         0,
+			MaxRating:      0,
+			ProblemsSolved: 0,
+		}, fmt.Errorf("AtCoder returned status %d. Service may be down. Verify at: https://atcoder.jp/users/%s", resp.StatusCode, username)
+	}
+
+	var history []AtCoderHistoryEntry
+	if err := json.Unmarshal(resp.Body, &history); err != nil {
+		return &PlatformStats{
+			Rating:         0,
+			MaxRating:      0,
+			ProblemsSolved: 0,
+		}, fmt.Errorf("AtCoder response format changed. Verify username at: https://atcoder.jp/users/%s", username)
+	}
+
+	// History is ordered by contest; the last rated contest holds the current rating
+	stats := &PlatformStats{}
+	for _, entry := range history {
+		if !entry.IsRated {
+			continue
+		}
+		stats.ContestsAttended++
+		stats.Rating = entry.NewRating
+		if entry.NewRating > stats.MaxRating {
+			stats.MaxRating = entry.NewRating
+		}
+	}
+
+	// AtCoder has no solved count; AtCoder Problems keeps one. If it's unavailable, still return the ratings.
+	acRankURL := fmt.Sprintf("%s/atcoder-api/v3/user/ac_rank?user=%s", endpoints.AtCoderProblems, username)
+	acRankResp, err := defaultClient.Do(ctx, "atcoder", Request{
+		URL:    acRankURL,
+		Header: atcoderHeaders,
+	})
+	if err != nil || acRankResp.StatusCode != http.StatusOK {
+		return stats, nil
+	}
+
+	var acRank struct {
+		Count int `json:"count"`
+		Rank  int `json:"rank"`
+	}
+	if json.Unmarshal(acRankResp.Body, &acRank) == nil {
+		stats.ProblemsSolved = acRank.Count
+	}
+
+	return stats, nil
+}
+
+// AtCoderProblem is a problem from the AtCoder Problems merged problem list
+type AtCoderProblem struct {
+	ID           string  `json:"id"`
+	ContestID    string  `json:"contest_id"`
+	ProblemIndex string  `json:"problem_index"`
+	Name         string  `json:"name"`
+	Title        string  `json:"title"`
+	SolverCount  int     `json:"solver_count"`
+	Point        float64 `json:"point"`
+	Difficulty   int     `json:"-"` // Estimated rating from the problem models, 0 if unknown
+}
+
+// atcoderCatalogContest is a contest in the AtCoder Problems catalog
+type atcoderCatalogContest struct {
+	ID               string `json:"id"`
+	StartEpochSecond int64  `json:"start_epoch_second"`
+}
+
+// atcoderProblemModel is the difficulty estimate published by AtCoder Problems
+type atcoderProblemModel struct {
+	Difficulty     *float64 `json:"difficulty"`
+	IsExperimental bool     `json:"is_experimental"`
+}
+
+// FetchAtCoderProblems fetches the AtCoder problem catalog with difficulty estimates from AtCoder Problems
+func FetchAtCoderProblems(ctx context.Context) ([]AtCoderProblem, error) {
+	resp, err := defaultClient.Do(ctx, "atcoder", Request{
+		URL:      endpoints.AtCoderProblems + "/resources/merged-problems.json",
+		Header:   atcoderHeaders,
+		CacheTTL: problemCatalogCacheTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AtCoder problems: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AtCoder Problems API returned status %d", resp.StatusCode)
+	}
+
+	var problems []AtCoderProblem
+	if err := json.Unmarshal(resp.Body, &problems); err != nil {
+		return nil, fmt.Errorf("failed to parse AtCoder problems response: %w", err)
+	}
+
+	// Difficulty estimates are optional; without them problems are imported as medium
+	modelsResp, err := defaultClient.Do(ctx, "atcoder", Request{
+		URL:      endpoints.AtCoderProblems + "/resources/problem-models.json",
+		Header:   atcoderHeaders,
+		CacheTTL: problemCatalogCacheTTL,
+	})
+	if err != nil || modelsResp.StatusCode != http.StatusOK {
+		fmt.Printf("Warning: Failed to fetch AtCoder problem difficulties\n")
+		return problems, nil
+	}
+
+	var problemModels map[string]atcoderProblemModel
+	if err := json.Unmarshal(modelsResp.Body, &problemModels); err != nil {
+		fmt.Printf("Warning: Failed to parse AtCoder problem difficulties: %v\n", err)
+		return problems, nil
+	}
+
+	for i := range problems {
+		if model, ok := problemModels[problems[i].ID]; ok && model.Difficulty != nil {
+			problems[i].Difficulty = atcoderClipDifficulty(*model.Difficulty)
+		}
+	}
+
+	return problems, nil
+}
+
+// atcoderClipDifficulty maps a raw difficulty estimate to a positive rating,
+// compressing values below 400 the same way AtCoder does for low ratings
+func atcoderClipDifficulty(difficulty float64) int {
+	if difficulty < 400 {
+		difficulty = 400 / math.Exp(1-difficulty/400)
+	}
+	return int(math.Round(difficulty))
+}
+
+// atcoderDifficulty maps an estimated rating to easy/medium/hard
+func atcoderDifficulty(rating int) string {
+	if rating > 0 {
+		if rating < 800 {
+			return "easy"
+		} else if rating >= 1600 {
+			return "hard"
+		}
+	}
+	return "medium"
+}
+
+var (
+	// atcoderContestTables are the contest tables on the contest list page with their phase
+	atcoderContestTables = []struct {
+		id    string
+		phase string
+	}{
+		{"contest-table-action", "CODING"},
+		{"contest-table-upcoming", "BEFORE"},
+	}
+
+	atcoderRowRe      = regexp.MustCompile(`(?s)<tr>(.*?)</tr>`)
+	atcoderTimeRe     = regexp.MustCompile(`<time[^>]*>([^<]+)</time>`)
+	atcoderContestRe  = regexp.MustCompile(`<a href="/contests/([^"/?]+)">([^<]+)</a>`)
+	atcoderDurationRe = regexp.MustCompile(`<td[^>]*>\s*(\d+):(\d{2})\s*</td>`)
+)
+
+// atcoderContestTypes are the rated contest series imported from AtCoder
+var atcoderContestTypes = []string{"abc", "arc", "agc"}
+
+// FetchAtCoderContests fetches upcoming and ongoing ABC/ARC/AGC contests from the AtCoder contest page
+func FetchAtCoderContests(ctx context.Context) ([]ContestInfo, error) {
+	resp, err := defaultClient.Do(ctx, "atcoder", Request{
+		URL: endpoints.AtCoder + "/contests/?lang=en",
+		Header: map[string]string{
+			"User-Agent": atcoderHeaders["User-Agent"],
+			"Accept":     "text/html",
+		},
+		CacheTTL: contestListCacheTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AtCoder contests: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AtCoder returned status %d", resp.StatusCode)
+	}
+
+	page := string(resp.Body)
+	if !strings.Contains(page, "contest-table-upcoming") {
+		return nil, fmt.Errorf("AtCoder contest page format changed")
+	}
+
+	var contests []ContestInfo
+	now := time.Now()
+
+	for _, table := range atcoderContestTables {
+		section := atcoderSection(page, table.id)
+
+		for _, row := range atcoderRowRe.FindAllStringSubmatch(section, -1) {
+			timeMatch := atcoderTimeRe.FindStringSubmatch(row[1])
+			contestMatch := atcoderContestRe.FindStringSubmatch(row[1])
+			durationMatch := atcoderDurationRe.FindStringSubmatch(row[1])
+			if timeMatch == nil || contestMatch == nil || durationMatch == nil {
+				continue
+			}
+
+			slug := contestMatch[1]
+			if !isAtCoderRatedSeries(slug) {
+				continue
+			}
+
+			startTime, err := time.Parse("2006-01-02 15:04:05-0700", strings.TrimSpace(timeMatch[1]))
+			if err != nil {
+				continue
+			}
+
+			hours, _ := strconv.Atoi(durationMatch[1])
+			minutes, _ := strconv.Atoi(durationMatch[2])
+			durationSeconds := hours*3600 + minutes*60
+			endTime := startTime.Add(time.Duration(durationSeconds) * time.Second)
+
+			// Skip contests that have already ended
+			if endTime.Before(now) {
+				continue
+			}
+
+			contests = append(contests, ContestInfo{
+				PlatformContestID: slug,
+				Name:              strings.TrimSpace(contestMatch[2]),
+				Platform:          "atcoder",
+				StartTime:         startTime.UTC(),
+				EndTime:           endTime.UTC(),
+				Duration:          durationSeconds,
+				ContestURL:        fmt.Sprintf("https://atcoder.jp/contests/%s", slug),
+				IsVirtual:         false,
+				Phase:             table.phase,
+			})
+		}
+	}
+
+	return contests, nil
+}
+
+// fetchAtCoderContestStarts returns the start time in Unix seconds of every contest in the
+// AtCoder Problems catalog, or nil if it can't be fetched
+func fetchAtCoderContestStarts(ctx context.Context) map[string]int64 {
+	resp, err := defaultClient.Do(ctx, "atcoder", Request{
+		URL:      endpoints.AtCoderProblems + "/resources/contests.json",
+		Header:   atcoderHeaders,
+		CacheTTL: problemCatalogCacheTTL,
+	})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		fmt.Printf("Warning: Failed to fetch AtCoder contest dates\n")
+		return nil
+	}
+
+	var contests []atcoderCatalogContest
+	if err := json.Unmarshal(resp.Body, &contests); err != nil {
+		fmt.Printf("Warning: Failed to parse AtCoder contest dates: %v\n", err)
+		return nil
+	}
+
+	starts := make(map[string]int64, len(contests))
+	for _, contest := range contests {
+		starts[contest.ID] = contest.StartEpochSecond
+	}
+	return starts
+}
+
+// sortAtCoderProblems orders problems from the newest contest to the oldest, then by problem
+// ID. Contests without a known start time come last, by contest ID.
+func sortAtCoderProblems(problems []AtCoderProblem, contestStarts map[string]int64) {
+	sort.Slice(problems, func(i, j int) bool {
+		a, b := problems[i], problems[j]
+		if startA, startB := contestStarts[a.ContestID], contestStarts[b.ContestID]; startA != startB {
+			return startA > startB
+		}
+		if a.ContestID != b.ContestID {
+			return a.ContestID > b.ContestID
+		}
+		return a.ID < b.ID
+	})
+}
+
+// atcoderSection returns the HTML of the table following the element with the given id
+func atcoderSection(page, id string) string {
+	start := strings.Index(page, `id="`+id+`"`)
+	if start == -1 {
+		return ""
+	}
+	section := page[start:]
+	if end := strings.Index(section, "</table>"); end != -1 {
+		section = section[:end]
+	}
+	return section
+}
+
+// isAtCoderRatedSeries reports whether a contest slug belongs to ABC, ARC or AGC
+func isAtCoderRatedSeries(slug string) bool {
+	for _, prefix := range atcoderContestTypes {
+		if strings.HasPrefix(slug, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// atcoderPlatform implements Platform for AtCoder
+type atcoderPlatform struct{}
+
+func init() {
+	Register(atcoderPlatform{})
+}
+
+func (atcoderPlatform) Name() string {
+	return "atcoder"
+}
+
+func (atcoderPlatform) DisplayName() string {
+	return "AtCoder"
+}
+
+func (atcoderPlatform) FetchStats(ctx context.Context, username string) (*PlatformStats, error) {
+	return FetchAtCoderStats(ctx, username)
+}
+
+func (atcoderPlatform) FetchProblems(ctx context.Context, limit int) ([]ProblemInfo, error) {
+	problems, err := FetchAtCoderProblems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API always returns the full catalog, in no particular order
+	if limit > 0 && len(problems) > limit {
+		sortAtCoderProblems(problems, fetchAtCoderContestStarts(ctx))
+		problems = problems[:limit]
+	}
+
+	infos := make([]ProblemInfo, 0, len(problems))
+	for _, p := range problems {
+		infos = append(infos, ProblemInfo{
+			Platform:          "atcoder",
+			PlatformProblemID: p.ID,
+			Title:             p.Name,
+			Slug:              p.ID,
+			Difficulty:        atcoderDifficulty(p.Difficulty),
+			Rating:            p.Difficulty,
+			SolvedCount:       p.SolverCount,
+			ProblemURL:        fmt.Sprintf("https://atcoder.jp/contests/%s/tasks/%s", p.ContestID, p.ID),
+		})
+	}
+	return infos, nil
+}
+
+func (atcoderPlatform) FetchContests(ctx context.Context) ([]ContestInfo, error) {
+	return FetchAtCoderContests(ctx)
+}
+
+func (atcoderPlatform) FetchContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (atcoderPlatform) FetchAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	return nil, ErrNotSupported
+}
+
+func (atcoderPlatform) FetchProblemContent(ctx context.Context, slug string) (*ProblemContent, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,317 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchAtCoderStats(t *testing.T) {
+	acRankOK := fixture{http.StatusOK, "kenkoooo/ac_rank_success.json"}
+
+	tests := []struct {
+		name      string
+		username  string
+		routes    map[string]fixture
+		wantStats *PlatformStats
+		wantErr   string
+	}{
+		{
+			name:     "success",
+			username: "chokudai",
+			routes: map[string]fixture{
+				"/atcoder/users/chokudai/history/json":  {http.StatusOK, "atcoder/history_success.json"},
+				"/kenkoooo/atcoder-api/v3/user/ac_rank": acRankOK,
+			},
+			// Unrated contests don't count towards rating or contests attended
+			wantStats: &PlatformStats{Rating: 1290, MaxRating: 1375, ContestsAttended: 3, ProblemsSolved: 523},
+		},
+		{
+			name:     "profile url",
+			username: "https://atcoder.jp/users/chokudai/history",
+			routes: map[string]fixture{
+				"/atcoder/users/chokudai/history/json":  {http.StatusOK, "atcoder/history_success.json"},
+				"/kenkoooo/atcoder-api/v3/user/ac_rank": acRankOK,
+			},
+			wantStats: &PlatformStats{Rating: 1290, MaxRating: 1375, ContestsAttended: 3, ProblemsSolved: 523},
+		},
+		{
+			name:     "solved count unavailable",
+			username: "chokudai",
+			routes: map[string]fixture{
+				"/atcoder/users/chokudai/history/json":  {http.StatusOK, "atcoder/history_success.json"},
+				"/kenkoooo/atcoder-api/v3/user/ac_rank": forbidden,
+			},
+			wantStats: &PlatformStats{Rating: 1290, MaxRating: 1375, ContestsAttended: 3},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			routes: map[string]fixture{
+				"/atcoder/users/no_such_user_42/history/json": {http.StatusNotFound, "atcoder/not_found.html"},
+			},
+			wantErr: "not found",
+		},
+		{
+			name:     "forbidden",
+			username: "chokudai",
+			routes: map[string]fixture{
+				"/atcoder/users/chokudai/history/json": forbidden,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "Status 403",
+		},
+		{
+			name:     "malformed json",
+			username: "chokudai",
+			routes: map[string]fixture{
+				"/atcoder/users/chokudai/history/json": malformedJSON,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+		{
+			name:     "html page",
+			username: "chokudai",
+			routes: map[string]fixture{
+				"/atcoder/users/chokudai/history/json": blockedPage,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, tt.routes)
+
+			stats, err := FetchAtCoderStats(context.Background(), tt.username)
+			assertError(t, err, tt.wantErr)
+			assertStats(t, stats, tt.wantStats)
+		})
+	}
+}
+
+func TestFetchAtCoderProblems(t *testing.T) {
+	tests := []struct {
+		name           string
+		routes         map[string]fixture
+		wantCount      int
+		wantDifficulty bool
+		wantErr        string
+	}{
+		{
+			name: "success",
+			routes: map[string]fixture{
+				"/kenkoooo/resources/merged-problems.json": {http.StatusOK, "kenkoooo/merged_problems.json"},
+				"/kenkoooo/resources/problem-models.json":  {http.StatusOK, "kenkoooo/problem_models.json"},
+			},
+			wantCount:      4,
+			wantDifficulty: true,
+		},
+		{
+			name: "difficulties unavailable",
+			routes: map[string]fixture{
+				"/kenkoooo/resources/merged-problems.json": {http.StatusOK, "kenkoooo/merged_problems.json"},
+				"/kenkoooo/resources/problem-models.json":  forbidden,
+			},
+			wantCount: 4,
+		},
+		{
+			name: "forbidden",
+			routes: map[string]fixture{
+				"/kenkoooo/resources/merged-problems.json": forbidden,
+			},
+			wantErr: "status 403",
+		},
+		{
+			name: "malformed json",
+			routes: map[string]fixture{
+				"/kenkoooo/resources/merged-problems.json": malformedJSON,
+			},
+			wantErr: "failed to parse",
+		},
+		{
+			name: "html page",
+			routes: map[string]fixture{
+				"/kenkoooo/resources/merged-problems.json": blockedPage,
+			},
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, tt.routes)
+
+			problems, err := FetchAtCoderProblems(context.Background())
+			assertError(t, err, tt.wantErr)
+			if len(problems) != tt.wantCount {
+				t.Fatalf("expected %d problems, got %d", tt.wantCount, len(problems))
+			}
+			if tt.wantCount > 0 && (problems[1].Difficulty != 0) != tt.wantDifficulty {
+				t.Errorf("unexpected difficulty %d for %s", problems[1].Difficulty, problems[1].ID)
+			}
+		})
+	}
+}
+
+func TestAtCoderPlatformFetchProblems(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/kenkoooo/resources/merged-problems.json": {http.StatusOK, "kenkoooo/merged_problems.json"},
+		"/kenkoooo/resources/problem-models.json":  {http.StatusOK, "kenkoooo/problem_models.json"},
+	})
+
+	p, ok := Get("atcoder")
+	if !ok {
+		t.Fatal("atcoder platform not registered")
+	}
+
+	problems, err := p.FetchProblems(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		id         string
+		rating     int
+		difficulty string
+	}{
+		// Negative estimates are clipped to a small positive rating
+		{"abc300_a", 9, "easy"},
+		{"abc300_e", 1441, "medium"},
+		{"arc160_f", 3295, "hard"},
+		// Problems without an estimate are imported as medium
+		{"practice_1", 0, "medium"},
+	}
+
+	if len(problems) != len(tests) {
+		t.Fatalf("expected %d problems, got %d", len(tests), len(problems))
+	}
+	for i, tt := range tests {
+		got := problems[i]
+		if got.PlatformProblemID != tt.id || got.Rating != tt.rating || got.Difficulty != tt.difficulty {
+			t.Errorf("expected %s rated %d (%s), got %s rated %d (%s)",
+				tt.id, tt.rating, tt.difficulty, got.PlatformProblemID, got.Rating, got.Difficulty)
+		}
+	}
+
+	if problems[0].ProblemURL != "https://atcoder.jp/contests/abc300/tasks/abc300_a" {
+		t.Errorf("unexpected problem url: %s", problems[0].ProblemURL)
+	}
+	if problems[0].Title != "N-choice question" || problems[0].SolvedCount != 9800 {
+		t.Errorf("unexpected problem: %+v", problems[0])
+	}
+}
+
+func TestAtCoderPlatformFetchProblemsLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		contests fixture
+		limit    int
+		wantIDs  []string
+	}{
+		{
+			name:     "newest contests first",
+			contests: fixture{http.StatusOK, "kenkoooo/contests.json"},
+			limit:    3,
+			wantIDs:  []string{"arc160_f", "abc300_a", "abc300_e"},
+		},
+		{
+			name:     "contest dates unavailable",
+			contests: forbidden,
+			limit:    3,
+			wantIDs:  []string{"practice_1", "arc160_f", "abc300_a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{
+				"/kenkoooo/resources/merged-problems.json": {http.StatusOK, "kenkoooo/merged_problems.json"},
+				"/kenkoooo/resources/problem-models.json":  {http.StatusOK, "kenkoooo/problem_models.json"},
+				"/kenkoooo/resources/contests.json":        tt.contests,
+			})
+
+			p, _ := Get("atcoder")
+			problems, err := p.FetchProblems(context.Background(), tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, problem := range problems {
+				ids = append(ids, problem.PlatformProblemID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestFetchAtCoderContests(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			// Only ABC/ARC/AGC contests that haven't ended are imported
+			name:      "success",
+			response:  fixture{http.StatusOK, "atcoder/contests_page.html"},
+			wantNames: []string{"AtCoder Beginner Contest 9999", "AtCoder Regular Contest 999 (Div. 1)"},
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "unrecognized page",
+			response: blockedPage,
+			wantErr:  "format changed",
+		},
+		{
+			name:     "json instead of html",
+			response: malformedJSON,
+			wantErr:  "format changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/atcoder/contests/": tt.response})
+
+			contests, err := FetchAtCoderContests(context.Background())
+			assertError(t, err, tt.wantErr)
+			assertContestNames(t, contests, tt.wantNames)
+		})
+	}
+}
+
+func TestFetchAtCoderContestsFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/atcoder/contests/": {http.StatusOK, "atcoder/contests_page.html"},
+	})
+
+	contests, err := FetchAtCoderContests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	abc := contests[0]
+	wantStart := time.Date(2100, 1, 2, 12, 0, 0, 0, time.UTC) // 21:00 JST
+	if !abc.StartTime.Equal(wantStart) || abc.Duration != 100*60 {
+		t.Errorf("unexpected schedule: start %v, duration %d", abc.StartTime, abc.Duration)
+	}
+	if !abc.EndTime.Equal(wantStart.Add(100 * time.Minute)) {
+		t.Errorf("unexpected end time: %v", abc.EndTime)
+	}
+	if abc.Platform != "atcoder" || abc.PlatformContestID != "abc9999" || abc.ContestURL != "https://atcoder.jp/contests/abc9999" || abc.Phase != "BEFORE" {
+		t.Errorf("unexpected contest: %+v", abc)
+	}
+}
@@ -1,11 +1,12 @@
 package scrapper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // CodeChefAPIResponse represents CodeChef API response
@@ -13,7 +14,8 @@ type CodeChefAPIResponse struct {
 	Success bool `json:"success"`
 	Result  struct {
 		Data struct {
-			Content struct {
+			// Content is missing when the response format changes
+			Content *struct {
 				Rating        int    `json:"rating"`
 				HighestRating int    `json:"highest_rating"`
 				GlobalRank    int    `json:"global_rank"`
@@ -25,7 +27,7 @@ type CodeChefAPIResponse struct {
 }
 
 // FetchCodeChefStats fetches coding statistics from CodeChef
-func FetchCodeChefStats(username string) (*PlatformStats, error) {
+func FetchCodeChefStats(ctx context.Context, username string) (*PlatformStats, error) {
 	// Extract username from URL if full URL is provided
 	// Examples: "codechef.com/users/username" -> "username"
 	//           "https://www.codechef.com/users/username" -> "username"
@@ -48,18 +50,15 @@ func FetchCodeChefStats(username string) (*PlatformStats, error) {
 	}
 
 	// Try CodeChef API first (if available)
-	url := fmt.Sprintf("https://codechef-api.vercel.app/handle/%s", username)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	url := fmt.Sprintf("%s/handle/%s", endpoints.CodeChefAPI, username)
+
+	resp, err := defaultClient.Do(ctx, "codechef", Request{
+		URL: url,
+		Header: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			"Accept":     "application/json",
+		},
+	})
 	if err != nil {
 		// Network error - return placeholder with helpful message
 		return &PlatformStats{
@@ -69,7 +68,6 @@ func FetchCodeChefStats(username string) (*PlatformStats, error) {
 			ProblemsSolved: 0,
 		}, fmt.Errorf("CodeChef API is currently unavailable. Please verify username '%s' manually at https://www.codechef.com/users/%s", username, username)
 	}
-	defer resp.Body.Close()
 
 	// If API is unavailable or returns error, return basic stats instead of failing
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
@@ -94,13 +92,8 @@ func FetchCodeChefStats(username string) (*PlatformStats, error) {
 		}, fmt.Errorf("CodeChef API returned status %d. Service may be down. Verify at: https://www.codechef.com/users/%s", resp.StatusCode, username)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	var apiResp CodeChefAPIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		// If API parsing fails, return basic stats with helpful message
 		return &PlatformStats{
 			Rating:         0,
@@ -113,12 +106,60 @@ func FetchCodeChefStats(username string) (*PlatformStats, error) {
 		return nil, fmt.Errorf("CodeChef user '%s' not found", username)
 	}
 
+	content := apiResp.Result.Data.Content
+	if content == nil {
+		return &PlatformStats{
+			Rating:         0,
+			MaxRating:      0,
+			ProblemsSolved: 0,
+		}, fmt.Errorf("CodeChef API response format changed. Verify username at: https://www.codechef.com/users/%s", username)
+	}
+
 	stats := &PlatformStats{
-		Rating:         apiResp.Result.Data.Content.Rating,
-		MaxRating:      apiResp.Result.Data.Content.HighestRating,
-		GlobalRank:     apiResp.Result.Data.Content.GlobalRank,
+		Rating:         content.Rating,
+		MaxRating:      content.HighestRating,
+		GlobalRank:     content.GlobalRank,
 		ProblemsSolved: 0, // CodeChef API doesn't provide this directly
 	}
 
 	return stats, nil
 }
+
+// codechefPlatform implements Platform for CodeChef
+type codechefPlatform struct{}
+
+func init() {
+	Register(codechefPlatform{})
+}
+
+func (codechefPlatform) Name() string {
+	return "codechef"
+}
+
+func (codechefPlatform) DisplayName() string {
+	return "CodeChef"
+}
+
+func (codechefPlatform) FetchStats(ctx context.Context, username string) (*PlatformStats, error) {
+	return FetchCodeChefStats(ctx, username)
+}
+
+func (codechefPlatform) FetchProblems(ctx context.Context, limit int) ([]ProblemInfo, error) {
+	return nil, ErrNotSupported
+}
+
+func (codechefPlatform) FetchContests(ctx context.Context) ([]ContestInfo, error) {
+	return FetchCodeChefContests(ctx)
+}
+
+func (codechefPlatform) FetchContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (codechefPlatform) FetchAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	return nil, ErrNotSupported
+}
+
+func (codechefPlatform) FetchProblemContent(ctx context.Context, slug string) (*ProblemContent, error) {
+	return nil, ErrNotSupported
+}
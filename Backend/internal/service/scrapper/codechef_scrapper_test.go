@@ -0,0 +1,88 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchCodeChefStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		username  string
+		routes    map[string]fixture
+		wantStats *PlatformStats
+		wantErr   string
+	}{
+		{
+			name:     "success",
+			username: "gennady.korotkevich",
+			routes: map[string]fixture{
+				"/codechef/handle/gennady.korotkevich": {http.StatusOK, "codechef/handle_success.json"},
+			},
+			wantStats: &PlatformStats{Rating: 3118, MaxRating: 3559, GlobalRank: 12},
+		},
+		{
+			name:     "profile url",
+			username: "https://www.codechef.com/users/gennady.korotkevich",
+			routes: map[string]fixture{
+				"/codechef/handle/gennady.korotkevich": {http.StatusOK, "codechef/handle_success.json"},
+			},
+			wantStats: &PlatformStats{Rating: 3118, MaxRating: 3559, GlobalRank: 12},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			routes: map[string]fixture{
+				"/codechef/handle/no_such_user_42": {http.StatusOK, "codechef/handle_not_found.json"},
+			},
+			wantErr: "not found",
+		},
+		{
+			name:     "format changed",
+			username: "gennady.korotkevich",
+			routes: map[string]fixture{
+				"/codechef/handle/gennady.korotkevich": {http.StatusOK, "codechef/handle_format_changed.json"},
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+		{
+			name:     "forbidden",
+			username: "gennady.korotkevich",
+			routes: map[string]fixture{
+				"/codechef/handle/gennady.korotkevich": forbidden,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "Status 403",
+		},
+		{
+			name:     "malformed json",
+			username: "gennady.korotkevich",
+			routes: map[string]fixture{
+				"/codechef/handle/gennady.korotkevich": malformedJSON,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+		{
+			name:     "html page",
+			username: "gennady.korotkevich",
+			routes: map[string]fixture{
+				"/codechef/handle/gennady.korotkevich": blockedPage,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, tt.routes)
+
+			stats, err := FetchCodeChefStats(context.Background(), tt.username)
+			assertError(t, err, tt.wantErr)
+			assertStats(t, stats, tt.wantStats)
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package scrapper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
+// codeforcesHeaders are sent with every Codeforces API request
+var codeforcesHeaders = map[string]string{
+	"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Accept":     "application/json",
+}
+
 type CodeforcesResponse struct {
 	Status string `json:"status"`
 	Result []struct {
@@ -21,39 +28,19 @@ type CodeforcesResponse struct {
 }
 
 // FetchCodeforcesStats fetches coding statistics from Codeforces
-func FetchCodeforcesStats(username string) (*PlatformStats, error) {
-	// Extract username from URL if full URL is provided
-	// Examples: "codeforces.com/profile/username" -> "username"
-	//           "https://codeforces.com/profile/username" -> "username"
-	if strings.Contains(username, "codeforces.com") {
-		// Remove protocol if present
-		username = strings.TrimPrefix(username, "https://")
-		username = strings.TrimPrefix(username, "http://")
-		// Remove domain
-		username = strings.TrimPrefix(username, "codeforces.com/")
-		// Remove profile prefix if present
-		username = strings.TrimPrefix(username, "profile/")
-		// Remove trailing slashes
-		username = strings.TrimSuffix(username, "/")
-	}
-
+func FetchCodeforcesStats(ctx context.Context, username string) (*PlatformStats, error) {
+	username = codeforcesHandle(username)
 	if username == "" {
 		return nil, fmt.Errorf("username is required")
 	}
 
 	// Fetch user info from Codeforces API
-	url := fmt.Sprintf("https://codeforces.com/api/user.info?handles=%s", username)
+	url := fmt.Sprintf("%s/user.info?handles=%s", endpoints.CodeforcesAPI, username)
 
-	// HTTP GET request with proper headers
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := defaultClient.Do(ctx, "codeforces", Request{
+		URL:    url,
+		Header: codeforcesHeaders,
+	})
 	if err != nil {
 		// Network error - return placeholder with verification link
 		return &PlatformStats{
@@ -62,7 +49,6 @@ func FetchCodeforcesStats(username string) (*PlatformStats, error) {
 			ProblemsSolved: 0,
 		}, fmt.Errorf("Codeforces API is currently unavailable. Verify username at: https://codeforces.com/profile/%s", username)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
 		return &PlatformStats{
@@ -72,7 +58,9 @@ func FetchCodeforcesStats(username string) (*PlatformStats, error) {
 		}, fmt.Errorf("Codeforces API rate limit exceeded (Status %d). Try again later or verify at: https://codeforces.com/profile/%s", resp.StatusCode, username)
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
+	// Unknown handles are reported as 400 with a "not found" comment
+	if resp.StatusCode == http.StatusNotFound ||
+		(resp.StatusCode == http.StatusBadRequest && strings.Contains(string(resp.Body), "not found")) {
 		return nil, fmt.Errorf("Codeforces user '%s' not found. Verify at: https://codeforces.com/profile/%s", username, username)
 	}
 
@@ -84,13 +72,8 @@ func FetchCodeforcesStats(username string) (*PlatformStats, error) {
 		}, fmt.Errorf("Codeforces API returned status %d. Service may be down. Verify at: https://codeforces.com/profile/%s", resp.StatusCode, username)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	var cfResp CodeforcesResponse
-	if err := json.Unmarshal(body, &cfResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &cfResp); err != nil {
 		return &PlatformStats{
 			Rating:         0,
 			MaxRating:      0,
@@ -105,8 +88,7 @@ func FetchCodeforcesStats(username string) (*PlatformStats, error) {
 	user := cfResp.Result[0]
 
 	// Fetch user submissions to count solved problems
-	submissionsURL := fmt.Sprintf("https://codeforces.com/api/user.status?handle=%s&from=1&count=10000", username)
-	submissionsResp, err := http.Get(submissionsURL)
+	submissionsResp, err := fetchCodeforcesUserStatus(ctx, username)
 	if err != nil {
 		// If submissions fetch fails, still return basic stats
 		return &PlatformStats{
@@ -115,27 +97,16 @@ func FetchCodeforcesStats(username string) (*PlatformStats, error) {
 			ProblemsSolved: 0,
 		}, nil
 	}
-	defer submissionsResp.Body.Close()
-
-	submissionsBody, err := io.ReadAll(submissionsResp.Body)
-	if err == nil {
-		var submissionsData struct {
-			Status string `json:"status"`
-			Result []struct {
-				Problem struct {
-					Name  string   `json:"name"`
-					Index string   `json:"index"`
-					Tags  []string `json:"tags"`
-				} `json:"problem"`
-				Verdict string `json:"verdict"`
-			} `json:"result"`
-		}
+
+	submissionsBody := submissionsResp.Body
+	if submissionsResp.StatusCode == http.StatusOK {
+		var submissionsData CodeforcesSubmissionsResponse
 
 		if json.Unmarshal(submissionsBody, &submissionsData) == nil && submissionsData.Status == "OK" {
 			solvedProblems := make(map[string]bool)
 			for _, submission := range submissionsData.Result {
 				if submission.Verdict == "OK" {
-					problemKey := submission.Problem.Name + submission.Problem.Index
+					problemKey := fmt.Sprintf("%d%s", submission.Problem.ContestID, submission.Problem.Index)
 					solvedProblems[problemKey] = true
 				}
 			}
@@ -155,3 +126,106 @@ func FetchCodeforcesStats(username string) (*PlatformStats, error) {
 	}
 	return stats, nil
 }
+
+// codeforcesHandle extracts the handle if a profile URL such as
+// "https://codeforces.com/profile/username" is given
+func codeforcesHandle(username string) string {
+	if strings.Contains(username, "codeforces.com") {
+		// Remove protocol if present
+		username = strings.TrimPrefix(username, "https://")
+		username = strings.TrimPrefix(username, "http://")
+		// Remove domain
+		username = strings.TrimPrefix(username, "codeforces.com/")
+		// Remove profile prefix if present
+		username = strings.TrimPrefix(username, "profile/")
+		// Remove trailing slashes
+		username = strings.TrimSuffix(username, "/")
+	}
+	return username
+}
+
+// codeforcesPlatform implements Platform for Codeforces
+type codeforcesPlatform struct{}
+
+func init() {
+	Register(codeforcesPlatform{})
+}
+
+func (codeforcesPlatform) Name() string {
+	return "codeforces"
+}
+
+func (codeforcesPlatform) DisplayName() string {
+	return "Codeforces"
+}
+
+func (codeforcesPlatform) FetchStats(ctx context.Context, username string) (*PlatformStats, error) {
+	return FetchCodeforcesStats(ctx, username)
+}
+
+func (codeforcesPlatform) FetchProblems(ctx context.Context, limit int) ([]ProblemInfo, error) {
+	problems, err := FetchCodeforcesProblems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API always returns the full problemset
+	if limit > 0 && len(problems) > limit {
+		problems = problems[:limit]
+	}
+
+	infos := make([]ProblemInfo, 0, len(problems))
+	for _, p := range problems {
+		infos = append(infos, codeforcesProblemInfo(p))
+	}
+	return infos, nil
+}
+
+func (codeforcesPlatform) FetchContests(ctx context.Context) ([]ContestInfo, error) {
+	return FetchCodeforcesContests(ctx)
+}
+
+func (codeforcesPlatform) FetchContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	return FetchCodeforcesContestHistory(ctx, username)
+}
+
+func (codeforcesPlatform) FetchAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	return FetchCodeforcesAcceptedSubmissions(ctx, username, since)
+}
+
+func (codeforcesPlatform) FetchProblemContent(ctx context.Context, slug string) (*ProblemContent, error) {
+	return nil, ErrNotSupported
+}
+
+// codeforcesProblemInfo converts a Codeforces problem to a ProblemInfo. Gym contests have IDs
+// from 100000 and their problems live under /gym.
+func codeforcesProblemInfo(p CodeforcesProblem) ProblemInfo {
+	problemURL := fmt.Sprintf("https://codeforces.com/problemset/problem/%d/%s", p.ContestID, p.Index)
+	if p.ContestID >= 100000 {
+		problemURL = fmt.Sprintf("https://codeforces.com/gym/%d/problem/%s", p.ContestID, p.Index)
+	}
+
+	return ProblemInfo{
+		Platform:          "codeforces",
+		PlatformProblemID: fmt.Sprintf("%d%s", p.ContestID, p.Index),
+		Title:             p.Name,
+		Slug:              fmt.Sprintf("%d-%s", p.ContestID, strings.ToLower(p.Index)),
+		Difficulty:        codeforcesDifficulty(p.Rating),
+		Rating:            p.Rating,
+		Tags:              p.Tags,
+		SolvedCount:       p.SolvedCount,
+		ProblemURL:        problemURL,
+	}
+}
+
+// codeforcesDifficulty maps a problem rating to easy/medium/hard
+func codeforcesDifficulty(rating int) string {
+	if rating > 0 {
+		if rating < 1200 {
+			return "easy"
+		} else if rating >= 1900 {
+			return "hard"
+		}
+	}
+	return "medium"
+}
@@ -0,0 +1,123 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchCodeforcesStats(t *testing.T) {
+	userStatusOK := fixture{http.StatusOK, "codeforces/user_status_success.json"}
+
+	tests := []struct {
+		name      string
+		username  string
+		routes    map[string]fixture
+		wantStats *PlatformStats
+		wantErr   string
+	}{
+		{
+			name:     "success",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.info":   {http.StatusOK, "codeforces/user_info_success.json"},
+				"/codeforces/user.status": userStatusOK,
+			},
+			// Two distinct problems accepted; repeated and rejected submissions don't count
+			wantStats: &PlatformStats{Rating: 3757, MaxRating: 4229, ProblemsSolved: 2},
+		},
+		{
+			name:     "profile url",
+			username: "https://codeforces.com/profile/tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.info":   {http.StatusOK, "codeforces/user_info_success.json"},
+				"/codeforces/user.status": userStatusOK,
+			},
+			wantStats: &PlatformStats{Rating: 3757, MaxRating: 4229, ProblemsSolved: 2},
+		},
+		{
+			name:     "submissions unavailable",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.info":   {http.StatusOK, "codeforces/user_info_success.json"},
+				"/codeforces/user.status": forbidden,
+			},
+			wantStats: &PlatformStats{Rating: 3757, MaxRating: 4229},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			routes: map[string]fixture{
+				"/codeforces/user.info": {http.StatusBadRequest, "codeforces/user_info_not_found.json"},
+			},
+			wantErr: "not found",
+		},
+		{
+			name:     "forbidden",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.info": forbidden,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "Status 403",
+		},
+		{
+			name:     "malformed json",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.info": malformedJSON,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+		{
+			name:     "html page",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.info": blockedPage,
+			},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, tt.routes)
+
+			stats, err := FetchCodeforcesStats(context.Background(), tt.username)
+			assertError(t, err, tt.wantErr)
+			assertStats(t, stats, tt.wantStats)
+		})
+	}
+}
+
+func TestCodeforcesPlatformFetchProblems(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/codeforces/problemset.problems": {http.StatusOK, "codeforces/problemset_success.json"},
+	})
+
+	p, ok := Get("codeforces")
+	if !ok {
+		t.Fatal("codeforces platform not registered")
+	}
+
+	problems, err := p.FetchProblems(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %d", len(problems))
+	}
+
+	watermelon := problems[0]
+	if watermelon.PlatformProblemID != "4A" || watermelon.Difficulty != "easy" || watermelon.Rating != 800 {
+		t.Errorf("unexpected problem: %+v", watermelon)
+	}
+	if watermelon.SolvedCount != 380000 {
+		t.Errorf("expected solved count 380000, got %d", watermelon.SolvedCount)
+	}
+	if problems[1].Difficulty != "hard" {
+		t.Errorf("expected rating 2400 to map to hard, got %s", problems[1].Difficulty)
+	}
+}
@@ -0,0 +1,274 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContestResult is a user's result in a finished rated contest
+type ContestResult struct {
+	Platform          string
+	PlatformContestID string // Same identifier as ContestInfo.PlatformContestID
+	ContestName       string
+	ContestURL        string
+	StartTime         time.Time
+	Duration          int // in seconds
+	Rank              int
+	OldRating         int
+	NewRating         int
+	ProblemsSolved    int
+	TotalProblems     int // 0 if unknown
+}
+
+// leetcodeContestDuration is the length of LeetCode weekly and biweekly contests
+const leetcodeContestDuration = 90 * 60
+
+// leetcodeInitialRating is the rating LeetCode starts every user at
+const leetcodeInitialRating = 1500
+
+// CodeforcesRatingResponse represents Codeforces user.rating API response
+type CodeforcesRatingResponse struct {
+	Status string `json:"status"`
+	Result []struct {
+		ContestID               int    `json:"contestId"`
+		ContestName             string `json:"contestName"`
+		Rank                    int    `json:"rank"`
+		RatingUpdateTimeSeconds int64  `json:"ratingUpdateTimeSeconds"`
+		OldRating               int    `json:"oldRating"`
+		NewRating               int    `json:"newRating"`
+	} `json:"result"`
+}
+
+// LeetCodeContestHistoryResponse represents LeetCode userContestRankingHistory response
+type LeetCodeContestHistoryResponse struct {
+	Data struct {
+		// UserContestRankingHistory is null when the user does not exist
+		UserContestRankingHistory *[]struct {
+			Attended       bool    `json:"attended"`
+			Rating         float64 `json:"rating"`
+			Ranking        int     `json:"ranking"`
+			ProblemsSolved int     `json:"problemsSolved"`
+			TotalProblems  int     `json:"totalProblems"`
+			Contest        struct {
+				Title     string `json:"title"`
+				StartTime int64  `json:"startTime"`
+			} `json:"contest"`
+		} `json:"userContestRankingHistory"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// FetchCodeforcesContestHistory fetches a user's rated Codeforces contests. Start times and
+// durations come from the contest list, and problems solved counts accepted submissions made
+// during the contest (left at 0 if submissions can't be fetched).
+func FetchCodeforcesContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	username = codeforcesHandle(username)
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	resp, err := defaultClient.Do(ctx, "codeforces", Request{
+		URL:    fmt.Sprintf("%s/user.rating?handle=%s", endpoints.CodeforcesAPI, username),
+		Header: codeforcesHeaders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Codeforces rating history: %w", err)
+	}
+
+	// Unknown handles are reported as 400 with a "not found" comment
+	if resp.StatusCode == http.StatusNotFound ||
+		(resp.StatusCode == http.StatusBadRequest && strings.Contains(string(resp.Body), "not found")) {
+		return nil, fmt.Errorf("Codeforces user '%s' not found", username)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Codeforces API returned status %d", resp.StatusCode)
+	}
+
+	var ratingResp CodeforcesRatingResponse
+	if err := json.Unmarshal(resp.Body, &ratingResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Codeforces response: %w", err)
+	}
+	if ratingResp.Status != "OK" {
+		return nil, fmt.Errorf("Codeforces API error: status %s", ratingResp.Status)
+	}
+	if len(ratingResp.Result) == 0 {
+		return nil, nil
+	}
+
+	schedules, err := fetchCodeforcesSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	solved := fetchCodeforcesContestSolves(ctx, username)
+
+	results := make([]ContestResult, 0, len(ratingResp.Result))
+	for _, entry := range ratingResp.Result {
+		schedule, ok := schedules[entry.ContestID]
+		if !ok {
+			// Contest too new to be listed, picked up on the next sync
+			continue
+		}
+
+		results = append(results, ContestResult{
+			Platform:          "codeforces",
+			PlatformContestID: strconv.Itoa(entry.ContestID),
+			ContestName:       entry.ContestName,
+			ContestURL:        fmt.Sprintf("https://codeforces.com/contest/%d", entry.ContestID),
+			StartTime:         schedule.start,
+			Duration:          schedule.duration,
+			Rank:              entry.Rank,
+			OldRating:         entry.OldRating,
+			NewRating:         entry.NewRating,
+			ProblemsSolved:    solved[entry.ContestID],
+		})
+	}
+
+	return results, nil
+}
+
+// codeforcesSchedule is a contest's start time and duration in seconds
+type codeforcesSchedule struct {
+	start    time.Time
+	duration int
+}
+
+// fetchCodeforcesSchedules maps every Codeforces contest ID to its schedule
+func fetchCodeforcesSchedules(ctx context.Context) (map[int]codeforcesSchedule, error) {
+	resp, err := defaultClient.Do(ctx, "codeforces", Request{
+		URL:      endpoints.CodeforcesAPI + "/contest.list",
+		Header:   codeforcesHeaders,
+		CacheTTL: contestListCacheTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Codeforces contests: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Codeforces API returned status %d", resp.StatusCode)
+	}
+
+	var cfResp CodeforcesContestResponse
+	if err := json.Unmarshal(resp.Body, &cfResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Codeforces response: %w", err)
+	}
+	if cfResp.Status != "OK" {
+		return nil, fmt.Errorf("Codeforces API error: status %s", cfResp.Status)
+	}
+
+	schedules := make(map[int]codeforcesSchedule, len(cfResp.Result))
+	for _, contest := range cfResp.Result {
+		schedules[contest.ID] = codeforcesSchedule{
+			start:    time.Unix(contest.StartTimeSeconds, 0),
+			duration: contest.DurationSeconds,
+		}
+	}
+	return schedules, nil
+}
+
+// fetchCodeforcesContestSolves counts the distinct problems a user got accepted during each
+// contest they took part in. Returns an empty map if submissions can't be fetched.
+func fetchCodeforcesContestSolves(ctx context.Context, username string) map[int]int {
+	solved := make(map[int]int)
+
+	resp, err := fetchCodeforcesUserStatus(ctx, username)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return solved
+	}
+
+	var submissions CodeforcesSubmissionsResponse
+	if json.Unmarshal(resp.Body, &submissions) != nil || submissions.Status != "OK" {
+		return solved
+	}
+
+	seen := make(map[string]bool)
+	for _, submission := range submissions.Result {
+		if submission.Verdict != "OK" || submission.Author.ParticipantType != "CONTESTANT" {
+			continue
+		}
+		key := fmt.Sprintf("%d%s", submission.Problem.ContestID, submission.Problem.Index)
+		if !seen[key] {
+			seen[key] = true
+			solved[submission.Problem.ContestID]++
+		}
+	}
+	return solved
+}
+
+// FetchLeetCodeContestHistory fetches the LeetCode contests a user attended
+func FetchLeetCodeContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	username = leetcodeUsername(username)
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     "query userContestRankingHistory($username: String!) {userContestRankingHistory(username: $username) {attended rating ranking problemsSolved totalProblems contest {title startTime}}}",
+		"variables": map[string]string{"username": username},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := defaultClient.Do(ctx, "leetcode", Request{
+		Method: http.MethodPost,
+		URL:    endpoints.LeetCodeGraphQL,
+		Body:   body,
+		Header: leetcodeHeaders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch LeetCode contest history: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LeetCode API returned status %d", resp.StatusCode)
+	}
+
+	var historyResp LeetCodeContestHistoryResponse
+	if err := json.Unmarshal(resp.Body, &historyResp); err != nil {
+		return nil, fmt.Errorf("failed to parse LeetCode response: %w", err)
+	}
+
+	history := historyResp.Data.UserContestRankingHistory
+	if history == nil {
+		if len(historyResp.Errors) > 0 {
+			return nil, fmt.Errorf("LeetCode user '%s' not found: %s", username, historyResp.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("LeetCode user '%s' not found", username)
+	}
+
+	// The history lists every contest since the user joined, carrying the rating forward
+	// through the ones they skipped
+	var results []ContestResult
+	previousRating := leetcodeInitialRating
+	for _, entry := range *history {
+		rating := int(math.Round(entry.Rating))
+		if !entry.Attended {
+			previousRating = rating
+			continue
+		}
+
+		slug := strings.ToLower(strings.ReplaceAll(entry.Contest.Title, " ", "-"))
+		results = append(results, ContestResult{
+			Platform:          "leetcode",
+			PlatformContestID: slug,
+			ContestName:       entry.Contest.Title,
+			ContestURL:        fmt.Sprintf("https://leetcode.com/contest/%s", slug),
+			StartTime:         time.Unix(entry.Contest.StartTime, 0),
+			Duration:          leetcodeContestDuration,
+			Rank:              entry.Ranking,
+			OldRating:         previousRating,
+			NewRating:         rating,
+			ProblemsSolved:    entry.ProblemsSolved,
+			TotalProblems:     entry.TotalProblems,
+		})
+		previousRating = rating
+	}
+
+	return results, nil
+}
@@ -0,0 +1,202 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFetchCodeforcesContestHistory(t *testing.T) {
+	contestListOK := fixture{http.StatusOK, "codeforces/contest_list_success.json"}
+
+	tests := []struct {
+		name       string
+		username   string
+		routes     map[string]fixture
+		wantSolved int
+		wantCount  int
+		wantErr    string
+	}{
+		{
+			// Round 4 is missing from the contest list and is skipped
+			name:     "success",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.rating":  {http.StatusOK, "codeforces/user_rating_success.json"},
+				"/codeforces/contest.list": contestListOK,
+				"/codeforces/user.status":  {http.StatusOK, "codeforces/user_status_success.json"},
+			},
+			wantCount:  1,
+			wantSolved: 1,
+		},
+		{
+			name:     "profile url",
+			username: "https://codeforces.com/profile/tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.rating":  {http.StatusOK, "codeforces/user_rating_success.json"},
+				"/codeforces/contest.list": contestListOK,
+				"/codeforces/user.status":  {http.StatusOK, "codeforces/user_status_success.json"},
+			},
+			wantCount:  1,
+			wantSolved: 1,
+		},
+		{
+			name:     "submissions unavailable",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.rating":  {http.StatusOK, "codeforces/user_rating_success.json"},
+				"/codeforces/contest.list": contestListOK,
+				"/codeforces/user.status":  forbidden,
+			},
+			wantCount: 1,
+		},
+		{
+			name:     "contest list unavailable",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.rating":  {http.StatusOK, "codeforces/user_rating_success.json"},
+				"/codeforces/contest.list": forbidden,
+			},
+			wantErr: "status 403",
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			routes: map[string]fixture{
+				"/codeforces/user.rating": {http.StatusBadRequest, "codeforces/user_info_not_found.json"},
+			},
+			wantErr: "not found",
+		},
+		{
+			name:     "malformed json",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.rating": malformedJSON,
+			},
+			wantErr: "failed to parse",
+		},
+		{
+			name:     "html page",
+			username: "tourist",
+			routes: map[string]fixture{
+				"/codeforces/user.rating": blockedPage,
+			},
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, tt.routes)
+
+			results, err := FetchCodeforcesContestHistory(context.Background(), tt.username)
+			assertError(t, err, tt.wantErr)
+			if len(results) != tt.wantCount {
+				t.Fatalf("expected %d results, got %d: %+v", tt.wantCount, len(results), results)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+
+			round := results[0]
+			if round.PlatformContestID != "1" || round.Rank != 5 || round.OldRating != 0 || round.NewRating != 1500 {
+				t.Errorf("unexpected result: %+v", round)
+			}
+			if !round.StartTime.Equal(time.Unix(1266588000, 0)) || round.Duration != 7200 {
+				t.Errorf("unexpected schedule: start %v, duration %d", round.StartTime, round.Duration)
+			}
+			// Only accepted submissions made as a contestant count
+			if round.ProblemsSolved != tt.wantSolved {
+				t.Errorf("expected %d problems solved, got %d", tt.wantSolved, round.ProblemsSolved)
+			}
+		})
+	}
+}
+
+func TestFetchLeetCodeContestHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			// Contests the user skipped are not results
+			name:      "success",
+			response:  fixture{http.StatusOK, "leetcode/contest_history_success.json"},
+			wantNames: []string{"Weekly Contest 400", "Biweekly Contest 130"},
+		},
+		{
+			name:     "user not found",
+			response: fixture{http.StatusOK, "leetcode/contest_history_not_found.json"},
+			wantErr:  "not found",
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/leetcode/graphql": tt.response})
+
+			results, err := FetchLeetCodeContestHistory(context.Background(), "neal_wu")
+			assertError(t, err, tt.wantErr)
+			if len(results) != len(tt.wantNames) {
+				t.Fatalf("expected %d results, got %d: %+v", len(tt.wantNames), len(results), results)
+			}
+			for i, result := range results {
+				if result.ContestName != tt.wantNames[i] {
+					t.Errorf("result %d: expected %q, got %q", i, tt.wantNames[i], result.ContestName)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchLeetCodeContestHistoryFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/leetcode/graphql": {http.StatusOK, "leetcode/contest_history_success.json"},
+	})
+
+	results, err := FetchLeetCodeContestHistory(context.Background(), "neal_wu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Rating changes are relative to the last known rating, including skipped contests
+	tests := []struct {
+		slug      string
+		oldRating int
+		newRating int
+		rank      int
+		solved    int
+	}{
+		{"weekly-contest-400", 1500, 1550, 1000, 3},
+		{"biweekly-contest-130", 1550, 1530, 2000, 2},
+	}
+	for i, tt := range tests {
+		got := results[i]
+		if got.PlatformContestID != tt.slug || got.OldRating != tt.oldRating || got.NewRating != tt.newRating ||
+			got.Rank != tt.rank || got.ProblemsSolved != tt.solved || got.TotalProblems != 4 {
+			t.Errorf("result %d: unexpected %+v", i, got)
+		}
+	}
+
+	if results[0].ContestURL != "https://leetcode.com/contest/weekly-contest-400" || results[0].Duration != 90*60 {
+		t.Errorf("unexpected contest: %+v", results[0])
+	}
+}
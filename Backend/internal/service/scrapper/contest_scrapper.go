@@ -1,24 +1,30 @@
 package scrapper
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// contestListCacheTTL is how long contest list responses are reused
+const contestListCacheTTL = 10 * time.Minute
+
 // ContestInfo represents a contest from any platform
 type ContestInfo struct {
-	Name       string
-	Platform   string
-	StartTime  time.Time
-	EndTime    time.Time
-	Duration   int // in seconds
-	ContestURL string
-	IsVirtual  bool
-	Phase      string // BEFORE, CODING, FINISHED
+	PlatformContestID string // The platform's own contest identifier, stable across reschedules
+	Name              string
+	Platform          string
+	StartTime         time.Time
+	EndTime           time.Time
+	Duration          int // in seconds
+	ContestURL        string
+	IsVirtual         bool
+	Phase             string // BEFORE, CODING, FINISHED
 }
 
 // CodeforcesContestResponse represents Codeforces contest API response
@@ -49,22 +55,24 @@ type LeetCodeContestResponse struct {
 }
 
 // FetchCodeforcesContests fetches upcoming and ongoing contests from Codeforces
-func FetchCodeforcesContests() ([]ContestInfo, error) {
-	url := "https://codeforces.com/api/contest.list"
-
-	resp, err := http.Get(url)
+func FetchCodeforcesContests(ctx context.Context) ([]ContestInfo, error) {
+	url := endpoints.CodeforcesAPI + "/contest.list"
+
+	resp, err := defaultClient.Do(ctx, "codeforces", Request{
+		URL:      url,
+		Header:   codeforcesHeaders,
+		CacheTTL: contestListCacheTTL,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch Codeforces contests: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Codeforces API returned status %d", resp.StatusCode)
 	}
 
 	var cfResp CodeforcesContestResponse
-	if err := json.Unmarshal(body, &cfResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &cfResp); err != nil {
 		return nil, fmt.Errorf("failed to parse Codeforces response: %w", err)
 	}
 
@@ -90,14 +98,15 @@ func FetchCodeforcesContests() ([]ContestInfo, error) {
 		}
 
 		contestInfo := ContestInfo{
-			Name:       contest.Name,
-			Platform:   "codeforces",
-			StartTime:  startTime,
-			EndTime:    endTime,
-			Duration:   contest.DurationSeconds,
-			ContestURL: fmt.Sprintf("https://codeforces.com/contest/%d", contest.ID),
-			IsVirtual:  contest.Type == "ICPC",
-			Phase:      contest.Phase,
+			PlatformContestID: strconv.Itoa(contest.ID),
+			Name:              contest.Name,
+			Platform:          "codeforces",
+			StartTime:         startTime,
+			EndTime:           endTime,
+			Duration:          contest.DurationSeconds,
+			ContestURL:        fmt.Sprintf("https://codeforces.com/contest/%d", contest.ID),
+			IsVirtual:         contest.Type == "ICPC",
+			Phase:             contest.Phase,
 		}
 
 		contests = append(contests, contestInfo)
@@ -107,37 +116,28 @@ func FetchCodeforcesContests() ([]ContestInfo, error) {
 }
 
 // FetchLeetCodeContests fetches upcoming contests from LeetCode
-func FetchLeetCodeContests() ([]ContestInfo, error) {
+func FetchLeetCodeContests(ctx context.Context) ([]ContestInfo, error) {
 	query := `{
 		"query": "{topTwoContests {title titleSlug startTime duration}}"
 	}`
 
-	req, err := http.NewRequest("POST", "https://leetcode.com/graphql", strings.NewReader(query))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Referer", "https://leetcode.com")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := defaultClient.Do(ctx, "leetcode", Request{
+		Method:   http.MethodPost,
+		URL:      endpoints.LeetCodeGraphQL,
+		Body:     []byte(query),
+		Header:   leetcodeHeaders,
+		CacheTTL: contestListCacheTTL,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch LeetCode contests: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("LeetCode API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	var lcResp LeetCodeContestResponse
-	if err := json.Unmarshal(body, &lcResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &lcResp); err != nil {
 		return nil, fmt.Errorf("failed to parse LeetCode response: %w", err)
 	}
 
@@ -158,14 +158,15 @@ func FetchLeetCodeContests() ([]ContestInfo, error) {
 		}
 
 		contestInfo := ContestInfo{
-			Name:       contest.Title,
-			Platform:   "leetcode",
-			StartTime:  startTime,
-			EndTime:    endTime,
-			Duration:   durationSeconds,
-			ContestURL: fmt.Sprintf("https://leetcode.com/contest/%s", contest.TitleSlug),
-			IsVirtual:  false,
-			Phase:      phase,
+			PlatformContestID: contest.TitleSlug,
+			Name:              contest.Title,
+			Platform:          "leetcode",
+			StartTime:         startTime,
+			EndTime:           endTime,
+			Duration:          durationSeconds,
+			ContestURL:        fmt.Sprintf("https://leetcode.com/contest/%s", contest.TitleSlug),
+			IsVirtual:         false,
+			Phase:             phase,
 		}
 
 		contests = append(contests, contestInfo)
@@ -174,26 +175,221 @@ func FetchLeetCodeContests() ([]ContestInfo, error) {
 	return contests, nil
 }
 
-// FetchAllContests fetches contests from all supported platforms
-func FetchAllContests() ([]ContestInfo, error) {
-	var allContests []ContestInfo
+// istLocation is India Standard Time, used by CodeChef and GFG for contest times
+var istLocation = time.FixedZone("IST", 5*60*60+30*60)
 
-	// Fetch from Codeforces
-	cfContests, err := FetchCodeforcesContests()
+// CodeChefContest is a contest in the CodeChef contest list
+type CodeChefContest struct {
+	ContestCode         string `json:"contest_code"`
+	ContestName         string `json:"contest_name"`
+	ContestStartDate    string `json:"contest_start_date"`
+	ContestStartDateISO string `json:"contest_start_date_iso"`
+	ContestDuration     string `json:"contest_duration"` // in minutes
+}
+
+// CodeChefContestResponse represents CodeChef contest list API response
+type CodeChefContestResponse struct {
+	Status          string            `json:"status"`
+	PresentContests []CodeChefContest `json:"present_contests"`
+	FutureContests  []CodeChefContest `json:"future_contests"`
+}
+
+// FetchCodeChefContests fetches upcoming and ongoing Starters and Long contests from CodeChef
+func FetchCodeChefContests(ctx context.Context) ([]ContestInfo, error) {
+	url := endpoints.CodeChef + "/api/list/contests/all?sort_by=START&sorting_order=asc&offset=0&mode=all"
+
+	resp, err := defaultClient.Do(ctx, "codechef", Request{
+		URL: url,
+		Header: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			"Accept":     "application/json",
+		},
+		CacheTTL: contestListCacheTTL,
+	})
 	if err != nil {
-		// Log error but continue with other platforms
-		fmt.Printf("Warning: Failed to fetch Codeforces contests: %v\n", err)
-	} else {
-		allContests = append(allContests, cfContests...)
+		return nil, fmt.Errorf("failed to fetch CodeChef contests: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CodeChef API returned status %d", resp.StatusCode)
 	}
 
-	// Fetch from LeetCode
-	lcContests, err := FetchLeetCodeContests()
+	var ccResp CodeChefContestResponse
+	if err := json.Unmarshal(resp.Body, &ccResp); err != nil {
+		return nil, fmt.Errorf("failed to parse CodeChef response: %w", err)
+	}
+
+	if ccResp.Status != "success" {
+		return nil, fmt.Errorf("CodeChef API error: status %s", ccResp.Status)
+	}
+
+	var contests []ContestInfo
+	now := time.Now()
+
+	for _, contest := range append(ccResp.PresentContests, ccResp.FutureContests...) {
+		if !isCodeChefRatedSeries(contest) {
+			continue
+		}
+
+		startTime, err := parseCodeChefTime(contest)
+		if err != nil {
+			fmt.Printf("Warning: Skipping CodeChef contest %s: %v\n", contest.ContestCode, err)
+			continue
+		}
+
+		durationMinutes, err := strconv.Atoi(strings.TrimSpace(contest.ContestDuration))
+		if err != nil {
+			fmt.Printf("Warning: Skipping CodeChef contest %s: invalid duration %q\n", contest.ContestCode, contest.ContestDuration)
+			continue
+		}
+		durationSeconds := durationMinutes * 60
+		endTime := startTime.Add(time.Duration(durationSeconds) * time.Second)
+
+		// Determine phase
+		phase := "BEFORE"
+		if now.After(startTime) && now.Before(endTime) {
+			phase = "CODING"
+		} else if now.After(endTime) {
+			continue // Skip finished contests
+		}
+
+		contests = append(contests, ContestInfo{
+			PlatformContestID: contest.ContestCode,
+			Name:              contest.ContestName,
+			Platform:          "codechef",
+			StartTime:         startTime.UTC(),
+			EndTime:           endTime.UTC(),
+			Duration:          durationSeconds,
+			ContestURL:        fmt.Sprintf("https://www.codechef.com/%s", contest.ContestCode),
+			IsVirtual:         false,
+			Phase:             phase,
+		})
+	}
+
+	return contests, nil
+}
+
+// isCodeChefRatedSeries reports whether a contest is a Starters or Long contest
+func isCodeChefRatedSeries(contest CodeChefContest) bool {
+	code := strings.ToUpper(contest.ContestCode)
+	name := strings.ToLower(contest.ContestName)
+	return strings.HasPrefix(code, "START") || strings.HasPrefix(code, "LONG") ||
+		strings.Contains(name, "starters") || strings.Contains(name, "long challenge")
+}
+
+// parseCodeChefTime parses a contest start time, falling back to the IST date string
+// when the ISO timestamp is missing
+func parseCodeChefTime(contest CodeChefContest) (time.Time, error) {
+	if contest.ContestStartDateISO != "" {
+		return time.Parse(time.RFC3339, contest.ContestStartDateISO)
+	}
+	// e.g. "26 Jun 2024  20:00:00" (note the double space)
+	return time.ParseInLocation("2 Jan 2006 15:04:05", strings.Join(strings.Fields(contest.ContestStartDate), " "), istLocation)
+}
+
+// GFGEvent is a contest in the GeeksforGeeks events list
+type GFGEvent struct {
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	StartTime string `json:"start_time"` // IST without offset, e.g. "2024-06-30T19:00:00"
+	EndTime   string `json:"end_time"`
+}
+
+// GFGEventsResponse represents GeeksforGeeks events API response
+type GFGEventsResponse struct {
+	Results *struct {
+		Upcoming []GFGEvent `json:"upcoming"`
+	} `json:"results"`
+}
+
+// FetchGFGContests fetches upcoming and ongoing weekly contests from GeeksforGeeks
+func FetchGFGContests(ctx context.Context) ([]ContestInfo, error) {
+	url := endpoints.GFGAPI + "/vr/events/?page_number=1&sub_type=all&type=contest"
+
+	resp, err := defaultClient.Do(ctx, "gfg", Request{
+		URL: url,
+		Header: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			"Accept":     "application/json",
+			"Referer":    "https://www.geeksforgeeks.org",
+		},
+		CacheTTL: contestListCacheTTL,
+	})
 	if err != nil {
-		// Log error but continue
-		fmt.Printf("Warning: Failed to fetch LeetCode contests: %v\n", err)
-	} else {
-		allContests = append(allContests, lcContests...)
+		return nil, fmt.Errorf("failed to fetch GFG contests: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GFG API returned status %d", resp.StatusCode)
+	}
+
+	var gfgResp GFGEventsResponse
+	if err := json.Unmarshal(resp.Body, &gfgResp); err != nil {
+		return nil, fmt.Errorf("failed to parse GFG response: %w", err)
+	}
+
+	if gfgResp.Results == nil {
+		return nil, fmt.Errorf("GFG events response format changed")
+	}
+
+	var contests []ContestInfo
+	now := time.Now()
+
+	for _, event := range gfgResp.Results.Upcoming {
+		if !strings.Contains(strings.ToLower(event.Slug+" "+event.Name), "weekly") {
+			continue
+		}
+
+		startTime, err := time.ParseInLocation("2006-01-02T15:04:05", event.StartTime, istLocation)
+		if err != nil {
+			fmt.Printf("Warning: Skipping GFG contest %s: %v\n", event.Slug, err)
+			continue
+		}
+		endTime, err := time.ParseInLocation("2006-01-02T15:04:05", event.EndTime, istLocation)
+		if err != nil || !endTime.After(startTime) {
+			fmt.Printf("Warning: Skipping GFG contest %s: invalid end time %q\n", event.Slug, event.EndTime)
+			continue
+		}
+
+		// Determine phase
+		phase := "BEFORE"
+		if now.After(startTime) && now.Before(endTime) {
+			phase = "CODING"
+		} else if now.After(endTime) {
+			continue // Skip finished contests
+		}
+
+		contests = append(contests, ContestInfo{
+			PlatformContestID: event.Slug,
+			Name:              event.Name,
+			Platform:          "gfg",
+			StartTime:         startTime.UTC(),
+			EndTime:           endTime.UTC(),
+			Duration:          int(endTime.Sub(startTime).Seconds()),
+			ContestURL:        fmt.Sprintf("https://practice.geeksforgeeks.org/contest/%s", event.Slug),
+			IsVirtual:         false,
+			Phase:             phase,
+		})
+	}
+
+	return contests, nil
+}
+
+// FetchAllContests fetches contests from all registered platforms
+func FetchAllContests(ctx context.Context) ([]ContestInfo, error) {
+	var allContests []ContestInfo
+
+	for _, platform := range All() {
+		contests, err := platform.FetchContests(ctx)
+		if errors.Is(err, ErrNotSupported) {
+			continue
+		}
+		if err != nil {
+			// Log error but continue with other platforms
+			fmt.Printf("Warning: Failed to fetch %s contests: %v\n", platform.DisplayName(), err)
+			continue
+		}
+		allContests = append(allContests, contests...)
 	}
 
 	return allContests, nil
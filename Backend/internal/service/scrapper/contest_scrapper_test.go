@@ -0,0 +1,295 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFetchCodeforcesContests(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:      "success",
+			response:  fixture{http.StatusOK, "codeforces/contest_list_success.json"},
+			wantNames: []string{"Codeforces Round 9999 (Div. 2)", "ICPC Practice 9998"},
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/codeforces/contest.list": tt.response})
+
+			contests, err := FetchCodeforcesContests(context.Background())
+			assertError(t, err, tt.wantErr)
+			assertContestNames(t, contests, tt.wantNames)
+		})
+	}
+}
+
+func TestFetchCodeforcesContestsFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/codeforces/contest.list": {http.StatusOK, "codeforces/contest_list_success.json"},
+	})
+
+	contests, err := FetchCodeforcesContests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	round := contests[0]
+	if !round.StartTime.Equal(time.Unix(4102444800, 0)) || round.Duration != 7200 {
+		t.Errorf("unexpected schedule: start %v, duration %d", round.StartTime, round.Duration)
+	}
+	if !round.EndTime.Equal(round.StartTime.Add(2 * time.Hour)) {
+		t.Errorf("unexpected end time: %v", round.EndTime)
+	}
+	if round.PlatformContestID != "9999" || round.ContestURL != "https://codeforces.com/contest/9999" || round.Phase != "BEFORE" {
+		t.Errorf("unexpected contest: %+v", round)
+	}
+	if round.IsVirtual || !contests[1].IsVirtual {
+		t.Errorf("expected only the ICPC contest to be virtual")
+	}
+}
+
+func TestFetchLeetCodeContests(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			// Finished contests are skipped
+			name:      "success",
+			response:  fixture{http.StatusOK, "leetcode/contests_success.json"},
+			wantNames: []string{"Weekly Contest 9999"},
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/leetcode/graphql": tt.response})
+
+			contests, err := FetchLeetCodeContests(context.Background())
+			assertError(t, err, tt.wantErr)
+			assertContestNames(t, contests, tt.wantNames)
+
+			if len(contests) > 0 && contests[0].Duration != 90*60 {
+				t.Errorf("expected duration in seconds, got %d", contests[0].Duration)
+			}
+		})
+	}
+}
+
+func TestFetchCodeChefContests(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			// Finished contests and contests other than Starters/Long are skipped
+			name:      "success",
+			response:  fixture{http.StatusOK, "codechef/contests_success.json"},
+			wantNames: []string{"Starters 9999", "Long Challenge 9999"},
+		},
+		{
+			name:     "error status",
+			response: fixture{http.StatusOK, "codechef/contests_format_changed.json"},
+			wantErr:  "CodeChef API error",
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/codechef-site/api/list/contests/all": tt.response})
+
+			contests, err := FetchCodeChefContests(context.Background())
+			assertError(t, err, tt.wantErr)
+			assertContestNames(t, contests, tt.wantNames)
+		})
+	}
+}
+
+func TestFetchCodeChefContestsFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/codechef-site/api/list/contests/all": {http.StatusOK, "codechef/contests_success.json"},
+	})
+
+	contests, err := FetchCodeChefContests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	starters := contests[0]
+	wantStart := time.Date(2100, 1, 2, 14, 30, 0, 0, time.UTC) // 20:00 IST
+	if !starters.StartTime.Equal(wantStart) || starters.Duration != 120*60 {
+		t.Errorf("unexpected schedule: start %v, duration %d", starters.StartTime, starters.Duration)
+	}
+	if !starters.EndTime.Equal(wantStart.Add(2 * time.Hour)) {
+		t.Errorf("unexpected end time: %v", starters.EndTime)
+	}
+	if starters.PlatformContestID != "START9999" || starters.ContestURL != "https://www.codechef.com/START9999" || starters.Phase != "BEFORE" {
+		t.Errorf("unexpected contest: %+v", starters)
+	}
+
+	// Without an ISO timestamp the IST date string is used
+	long := contests[1]
+	if !long.StartTime.Equal(time.Date(2100, 1, 5, 9, 30, 0, 0, time.UTC)) || long.Duration != 10*24*60*60 {
+		t.Errorf("unexpected schedule: start %v, duration %d", long.StartTime, long.Duration)
+	}
+}
+
+func TestFetchGFGContests(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			// Only weekly contests with valid times are imported
+			name:      "success",
+			response:  fixture{http.StatusOK, "gfg/events_success.json"},
+			wantNames: []string{"GFG Weekly Coding Contest - 9999"},
+		},
+		{
+			name:     "format changed",
+			response: fixture{http.StatusOK, "gfg/events_format_changed.json"},
+			wantErr:  "format changed",
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/gfg/vr/events/": tt.response})
+
+			contests, err := FetchGFGContests(context.Background())
+			assertError(t, err, tt.wantErr)
+			assertContestNames(t, contests, tt.wantNames)
+		})
+	}
+}
+
+func TestFetchGFGContestsFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/gfg/vr/events/": {http.StatusOK, "gfg/events_success.json"},
+	})
+
+	contests, err := FetchGFGContests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	weekly := contests[0]
+	wantStart := time.Date(2100, 1, 3, 13, 30, 0, 0, time.UTC) // 19:00 IST
+	if !weekly.StartTime.Equal(wantStart) || weekly.Duration != 90*60 {
+		t.Errorf("unexpected schedule: start %v, duration %d", weekly.StartTime, weekly.Duration)
+	}
+	if weekly.PlatformContestID != "gfg-weekly-coding-contest-9999" || weekly.Platform != "gfg" ||
+		weekly.ContestURL != "https://practice.geeksforgeeks.org/contest/gfg-weekly-coding-contest-9999" {
+		t.Errorf("unexpected contest: %+v", weekly)
+	}
+}
+
+func TestFetchAllContestsSkipsFailingPlatforms(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/codeforces/contest.list":             {http.StatusOK, "codeforces/contest_list_success.json"},
+		"/codechef-site/api/list/contests/all": {http.StatusOK, "codechef/contests_success.json"},
+		"/gfg/vr/events/":                      {http.StatusOK, "gfg/events_success.json"},
+		"/leetcode/graphql":                    forbidden,
+		"/atcoder/contests/":                   malformedJSON,
+	})
+
+	contests, err := FetchAllContests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertContestNames(t, contests, []string{
+		"Starters 9999",
+		"Long Challenge 9999",
+		"Codeforces Round 9999 (Div. 2)",
+		"ICPC Practice 9998",
+		"GFG Weekly Coding Contest - 9999",
+	})
+}
+
+func assertContestNames(t *testing.T, contests []ContestInfo, want []string) {
+	t.Helper()
+
+	if len(contests) != len(want) {
+		t.Fatalf("expected %d contests, got %d: %+v", len(want), len(contests), contests)
+	}
+	for i, contest := range contests {
+		if contest.Name != want[i] {
+			t.Errorf("contest %d: expected %q, got %q", i, want[i], contest.Name)
+		}
+	}
+}
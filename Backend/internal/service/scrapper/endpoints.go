@@ -0,0 +1,37 @@
+package scrapper
+
+// Endpoints holds the base URLs of the upstream APIs the scrapers call
+type Endpoints struct {
+	LeetCodeGraphQL string // LeetCode GraphQL endpoint
+	CodeforcesAPI   string // Codeforces API base, method names are appended
+	CodeChefAPI     string // Unofficial CodeChef API base
+	CodeChef        string // CodeChef site (contest list API)
+	GFGAPI          string // GeeksforGeeks practice API base
+	AtCoder         string // AtCoder site (user history and contest pages)
+	AtCoderProblems string // AtCoder Problems (kenkoooo) base for problem data and solved counts
+}
+
+// DefaultEndpoints returns the production API base URLs
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		LeetCodeGraphQL: "https://leetcode.com/graphql",
+		CodeforcesAPI:   "https://codeforces.com/api",
+		CodeChefAPI:     "https://codechef-api.vercel.app",
+		CodeChef:        "https://www.codechef.com",
+		GFGAPI:          "https://practiceapi.geeksforgeeks.org/api",
+		AtCoder:         "https://atcoder.jp",
+		AtCoderProblems: "https://kenkoooo.com/atcoder",
+	}
+}
+
+// endpoints is read by every scraper request
+var endpoints = DefaultEndpoints()
+
+// SetEndpoints overrides the upstream base URLs (e.g. to point the scrapers at a local
+// test server) and returns a function that restores the previous ones.
+// It must not be called while scrapers are running.
+func SetEndpoints(e Endpoints) (restore func()) {
+	previous := endpoints
+	endpoints = e
+	return func() { endpoints = previous }
+}
@@ -0,0 +1,106 @@
+package scrapper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixture is a recorded upstream response served from testdata
+type fixture struct {
+	status int
+	file   string // path relative to testdata
+}
+
+// Fixtures shared by every platform
+var (
+	blockedPage   = fixture{http.StatusOK, "common/blocked.html"}
+	forbidden     = fixture{http.StatusForbidden, "common/blocked.html"}
+	malformedJSON = fixture{http.StatusOK, "common/malformed.json"}
+)
+
+// serveFixtures points every scraper at a local server answering each request path with
+// its fixture. Every platform has its own path prefix (/leetcode, /codeforces, ...).
+// The shared HTTP client is replaced so that cache and circuit breakers start empty.
+func serveFixtures(t *testing.T, routes map[string]fixture) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join("testdata", f.file))
+		if err != nil {
+			t.Errorf("failed to read fixture %s: %v", f.file, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if strings.HasSuffix(f.file, ".html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(f.status)
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	restore := SetEndpoints(Endpoints{
+		LeetCodeGraphQL: server.URL + "/leetcode/graphql",
+		CodeforcesAPI:   server.URL + "/codeforces",
+		CodeChefAPI:     server.URL + "/codechef",
+		CodeChef:        server.URL + "/codechef-site",
+		GFGAPI:          server.URL + "/gfg",
+		AtCoder:         server.URL + "/atcoder",
+		AtCoderProblems: server.URL + "/kenkoooo",
+	})
+	t.Cleanup(restore)
+
+	previousClient := defaultClient
+	defaultClient = NewHTTPClient()
+	t.Cleanup(func() { defaultClient = previousClient })
+}
+
+// assertError checks that err is set exactly when wantErr is non-empty and contains it
+func assertError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("expected error containing %q, got nil", wantErr)
+	}
+	if !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("expected error containing %q, got %q", wantErr, err.Error())
+	}
+}
+
+// assertStats compares fetched stats against the expected ones (nil means no stats)
+func assertStats(t *testing.T, got, want *PlatformStats) {
+	t.Helper()
+
+	if want == nil {
+		if got != nil {
+			t.Fatalf("expected no stats, got %+v", *got)
+		}
+		return
+	}
+	if got == nil {
+		t.Fatalf("expected stats %+v, got nil", *want)
+	}
+	if *got != *want {
+		t.Fatalf("stats mismatch:\n got  %+v\n want %+v", *got, *want)
+	}
+}
@@ -1,17 +1,18 @@
 package scrapper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FetchGFGStats fetches coding statistics from GeeksforGeeks
-func FetchGFGStats(username string) (*PlatformStats, error) {
+func FetchGFGStats(ctx context.Context, username string) (*PlatformStats, error) {
 	fmt.Printf("DEBUG GFG: Original input: %s\n", username)
 
 	// Extract username from URL if full URL is provided
@@ -54,26 +55,22 @@ func FetchGFGStats(username string) (*PlatformStats, error) {
 	}
 
 	// Try GFG API endpoint
-	url := fmt.Sprintf("https://practiceapi.geeksforgeeks.org/api/vr/user-profile-stats/?handle=%s", username)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Referer", "https://www.geeksforgeeks.org")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	url := fmt.Sprintf("%s/vr/user-profile-stats/?handle=%s", endpoints.GFGAPI, username)
+
+	resp, err := defaultClient.Do(ctx, "gfg", Request{
+		URL: url,
+		Header: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			"Accept":     "application/json",
+			"Referer":    "https://www.geeksforgeeks.org",
+		},
+	})
 	if err != nil {
 		// Network error - return placeholder with verification link
 		return &PlatformStats{
 			ProblemsSolved: 0,
 		}, fmt.Errorf("GeeksforGeeks API is currently unavailable. Verify username at: https://auth.geeksforgeeks.org/user/%s/practice", username)
 	}
-	defer resp.Body.Close()
 
 	// Handle auth errors gracefully
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
@@ -92,11 +89,7 @@ func FetchGFGStats(username string) (*PlatformStats, error) {
 		}, fmt.Errorf("GFG API returned status %d. Service may be down. Verify at: https://auth.geeksforgeeks.org/user/%s/practice", resp.StatusCode, username)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
+	body := resp.Body
 	bodyStr := string(body)
 
 	// Try parsing as JSON first
@@ -112,6 +105,11 @@ func FetchGFGStats(username string) (*PlatformStats, error) {
 			stats.ProblemsSolved = int(totalSolved)
 		} else if totalSolved, ok := jsonData["totalProblemsSolved"].(float64); ok {
 			stats.ProblemsSolved = int(totalSolved)
+		} else if message, ok := jsonData["message"].(string); ok && strings.Contains(strings.ToLower(message), "not found") {
+			return nil, fmt.Errorf("GeeksforGeeks user '%s' not found. Check: https://auth.geeksforgeeks.org/user/%s/practice", username, username)
+		} else {
+			// None of the known fields are present, so the response format changed
+			return stats, fmt.Errorf("GeeksforGeeks API response format changed. Verify username at: https://auth.geeksforgeeks.org/user/%s/practice", username)
 		}
 
 		// Try to extract score/ranking
@@ -140,10 +138,47 @@ func FetchGFGStats(username string) (*PlatformStats, error) {
 		return nil, fmt.Errorf("user not found on GeeksforGeeks")
 	}
 
-	// Return basic stats if we can't parse
-	stats := &PlatformStats{
+	// Neither JSON nor a recognizable profile page
+	return &PlatformStats{
 		ProblemsSolved: 0,
-	}
+	}, fmt.Errorf("GeeksforGeeks API response format changed. Verify username at: https://auth.geeksforgeeks.org/user/%s/practice", username)
+}
+
+// gfgPlatform implements Platform for GeeksforGeeks
+type gfgPlatform struct{}
+
+func init() {
+	Register(gfgPlatform{})
+}
+
+func (gfgPlatform) Name() string {
+	return "gfg"
+}
+
+func (gfgPlatform) DisplayName() string {
+	return "GFG"
+}
+
+func (gfgPlatform) FetchStats(ctx context.Context, username string) (*PlatformStats, error) {
+	return FetchGFGStats(ctx, username)
+}
+
+func (gfgPlatform) FetchProblems(ctx context.Context, limit int) ([]ProblemInfo, error) {
+	return nil, ErrNotSupported
+}
+
+func (gfgPlatform) FetchContests(ctx context.Context) ([]ContestInfo, error) {
+	return FetchGFGContests(ctx)
+}
+
+func (gfgPlatform) FetchContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (gfgPlatform) FetchAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	return nil, ErrNotSupported
+}
 
-	return stats, nil
+func (gfgPlatform) FetchProblemContent(ctx context.Context, slug string) (*ProblemContent, error) {
+	return nil, ErrNotSupported
 }
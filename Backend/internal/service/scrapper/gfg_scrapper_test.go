@@ -0,0 +1,88 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchGFGStats(t *testing.T) {
+	const profilePath = "/gfg/vr/user-profile-stats/"
+
+	tests := []struct {
+		name      string
+		username  string
+		response  fixture
+		wantStats *PlatformStats
+		wantErr   string
+	}{
+		{
+			name:      "success",
+			username:  "geek_coder",
+			response:  fixture{http.StatusOK, "gfg/profile_success.json"},
+			wantStats: &PlatformStats{ProblemsSolved: 412, GlobalRank: 1280},
+		},
+		{
+			name:      "profile url",
+			username:  "https://auth.geeksforgeeks.org/user/geek_coder/practice/",
+			response:  fixture{http.StatusOK, "gfg/profile_success.json"},
+			wantStats: &PlatformStats{ProblemsSolved: 412, GlobalRank: 1280},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			response: fixture{http.StatusOK, "gfg/profile_not_found.json"},
+			wantErr:  "not found",
+		},
+		{
+			name:     "user not found status",
+			username: "no_such_user_42",
+			response: fixture{http.StatusNotFound, "gfg/profile_not_found.json"},
+			wantErr:  "not found",
+		},
+		{
+			name:      "format changed",
+			username:  "geek_coder",
+			response:  fixture{http.StatusOK, "gfg/profile_format_changed.json"},
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+		{
+			name:      "forbidden",
+			username:  "geek_coder",
+			response:  forbidden,
+			wantStats: &PlatformStats{},
+			wantErr:   "Status 403",
+		},
+		{
+			name:      "malformed json",
+			username:  "geek_coder",
+			response:  malformedJSON,
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+		{
+			name:      "html profile fallback",
+			username:  "geek_coder",
+			response:  fixture{http.StatusOK, "gfg/profile_page.html"},
+			wantStats: &PlatformStats{ProblemsSolved: 412},
+		},
+		{
+			name:      "unrecognized html page",
+			username:  "geek_coder",
+			response:  blockedPage,
+			wantStats: &PlatformStats{},
+			wantErr:   "format changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{profilePath: tt.response})
+
+			stats, err := FetchGFGStats(context.Background(), tt.username)
+			assertError(t, err, tt.wantErr)
+			assertStats(t, stats, tt.wantStats)
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package scrapper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRequestTimeout applies to hosts without an entry in hostTimeouts
+	defaultRequestTimeout = 15 * time.Second
+	// maxRetries is how many times a request is retried after a 429/5xx or network error
+	maxRetries = 3
+	// baseRetryDelay is the first retry delay; it doubles on every retry
+	baseRetryDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the delay between retries (including Retry-After)
+	maxRetryDelay = 10 * time.Second
+	// maxResponseSize guards against unexpectedly large upstream responses
+	maxResponseSize = 32 << 20
+
+	// breakerFailureThreshold consecutive failures open a platform's circuit
+	breakerFailureThreshold = 5
+	// breakerCooldown is how long an open circuit rejects requests before a trial request
+	breakerCooldown = 30 * time.Second
+)
+
+// hostTimeouts overrides the per-attempt timeout for slow hosts
+var hostTimeouts = map[string]time.Duration{
+	"codeforces.com":                30 * time.Second, // user.status responses can be large
+	"codechef-api.vercel.app":       20 * time.Second,
+	"practiceapi.geeksforgeeks.org": 20 * time.Second,
+	"kenkoooo.com":                  30 * time.Second, // the merged problem list is several MB
+}
+
+// ErrCircuitOpen is returned while a platform's circuit breaker is open
+var ErrCircuitOpen = errors.New("platform temporarily unavailable (circuit open)")
+
+// Request describes an outgoing scraper request
+type Request struct {
+	Method   string
+	URL      string
+	Body     []byte
+	Header   map[string]string
+	CacheTTL time.Duration // > 0 caches successful (200) responses
+}
+
+// Response is a fully read upstream response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// HTTPClient is the shared client used by every scraper. It applies per-host timeouts,
+// retries 429/5xx responses with exponential backoff, keeps a circuit breaker per
+// platform and caches responses on request.
+type HTTPClient struct {
+	client *http.Client
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+	cache    map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	response  *Response
+	expiresAt time.Time
+}
+
+// NewHTTPClient creates a new scraper HTTP client
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{
+		// Timeouts are applied per attempt through the request context
+		client:   &http.Client{},
+		breakers: make(map[string]*circuitBreaker),
+		cache:    make(map[string]cachedResponse),
+	}
+}
+
+// defaultClient is shared by all scrapers
+var defaultClient = NewHTTPClient()
+
+// BreakerStates reports the circuit breaker state of every platform contacted so far
+func BreakerStates() map[string]string {
+	return defaultClient.BreakerStates()
+}
+
+// BreakerStates reports the circuit breaker state of every platform contacted so far
+func (c *HTTPClient) BreakerStates() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	states := make(map[string]string, len(c.breakers))
+	for platform, b := range c.breakers {
+		states[platform] = b.state()
+	}
+	return states
+}
+
+// Do sends a request on behalf of a platform. Any HTTP status is returned as a Response;
+// an error means the request could not be completed (network error, open circuit, cancelled context).
+func (c *HTTPClient) Do(ctx context.Context, platform string, req Request) (*Response, error) {
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+
+	cacheKey := ""
+	if req.CacheTTL > 0 {
+		cacheKey = requestCacheKey(req)
+		if resp, ok := c.cached(cacheKey); ok {
+			return resp, nil
+		}
+	}
+
+	breaker := c.breaker(platform)
+	if !breaker.allow() {
+		return nil, fmt.Errorf("%s: %w", platform, ErrCircuitOpen)
+	}
+
+	var resp *Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		resp, err = c.attempt(ctx, req)
+
+		retryable := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt >= maxRetries || ctx.Err() != nil {
+			break
+		}
+
+		delay := retryDelay(attempt, resp)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	switch {
+	case err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500:
+		breaker.recordSuccess()
+	case ctx.Err() != nil:
+		// A cancelled caller says nothing about the platform's health
+		breaker.release()
+	default:
+		breaker.recordFailure()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheKey != "" && resp.StatusCode == http.StatusOK {
+		c.store(cacheKey, resp, req.CacheTTL)
+	}
+	return resp, nil
+}
+
+// attempt performs a single request with the host's timeout
+func (c *HTTPClient) attempt(ctx context.Context, req Request) (*Response, error) {
+	timeout := defaultRequestTimeout
+	if u, err := url.Parse(req.URL); err == nil {
+		if t, ok := hostTimeouts[u.Hostname()]; ok {
+			timeout = t
+		}
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(attemptCtx, req.Method, req.URL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range req.Header {
+		httpReq.Header.Set(key, value)
+	}
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return &Response{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+		Body:       respBody,
+	}, nil
+}
+
+// retryDelay returns the jittered exponential backoff for an attempt, honouring Retry-After
+func retryDelay(attempt int, resp *Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			delay := time.Duration(seconds) * time.Second
+			if delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+			return delay
+		}
+	}
+
+	delay := baseRetryDelay << attempt
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func requestCacheKey(req Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL + "\n"))
+	hash.Write(req.Body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *HTTPClient) cached(key string) (*Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.cache, key)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+func (c *HTTPClient) store(key string, resp *Response, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	// Drop expired entries so the cache doesn't grow without bound
+	for k, entry := range c.cache {
+		if now.After(entry.expiresAt) {
+			delete(c.cache, k)
+		}
+	}
+	c.cache[key] = cachedResponse{response: resp, expiresAt: now.Add(ttl)}
+}
+
+func (c *HTTPClient) breaker(platform string) *circuitBreaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[platform]
+	if !ok {
+		b = &circuitBreaker{}
+		c.breakers[platform] = b
+	}
+	return b
+}
+
+// Circuit breaker states
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+)
+
+// circuitBreaker stops calling a platform after repeated failures and lets a single
+// trial request through once the cooldown has passed
+type circuitBreaker struct {
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial request is in flight
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerFailureThreshold {
+		return true
+	}
+	if time.Since(b.openedAt) < breakerCooldown || b.trial {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= breakerFailureThreshold {
+		b.openedAt = time.Now()
+	}
+	b.trial = false
+}
+
+// release ends a trial request without recording an outcome
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+func (b *circuitBreaker) state() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerFailureThreshold {
+		return breakerClosed
+	}
+	if time.Since(b.openedAt) < breakerCooldown {
+		return breakerOpen
+	}
+	return breakerHalfOpen
+}
@@ -1,17 +1,25 @@
 package scrapper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
+// leetcodeHeaders are sent with every LeetCode GraphQL request
+var leetcodeHeaders = map[string]string{
+	"Content-Type": "application/json",
+	"Referer":      "https://leetcode.com",
+}
+
 // LeetCodeResponse represents the response from LeetCode GraphQL API
 type LeetCodeResponse struct {
 	Data struct {
-		MatchedUser struct {
+		// MatchedUser is null when the user does not exist
+		MatchedUser *struct {
 			Username string `json:"username"`
 			Profile  struct {
 				Ranking int `json:"ranking"`
@@ -24,82 +32,64 @@ type LeetCodeResponse struct {
 			} `json:"submitStats"`
 		} `json:"matchedUser"`
 	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
 }
 
 // FetchLeetCodeStats fetches coding statistics from LeetCode
-func FetchLeetCodeStats(username string) (*PlatformStats, error) {
-	// Extract username from URL if full URL is provided
-	// Examples: "leetcode.com/u/username/" -> "username"
-	//           "https://leetcode.com/username/" -> "username"
-	if strings.Contains(username, "leetcode.com") {
-		// Remove protocol if present
-		username = strings.TrimPrefix(username, "https://")
-		username = strings.TrimPrefix(username, "http://")
-		// Remove domain
-		username = strings.TrimPrefix(username, "leetcode.com/")
-		// Remove /u/ prefix if present
-		username = strings.TrimPrefix(username, "u/")
-		// Remove trailing slashes
-		username = strings.TrimSuffix(username, "/")
-	}
+func FetchLeetCodeStats(ctx context.Context, username string) (*PlatformStats, error) {
+	username = leetcodeUsername(username)
 
-	fmt.Printf("DEBUG SCRAPER: Fetching stats for LeetCode username: %s\n", username)
-
-	// GraphQL query for LeetCode API
-	query := fmt.Sprintf(`{
-        "query": "{matchedUser(username: \"%s\") {username profile {ranking} submitStats {acSubmissionNum {difficulty count}}}}"
-    }`, username)
-
-	fmt.Printf("DEBUG SCRAPER: GraphQL Query: %s\n", query)
-
-	// Make HTTP request to LeetCode GraphQL API
-	req, err := http.NewRequest("POST", "https://leetcode.com/graphql", strings.NewReader(query))
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     "query userStats($username: String!) {matchedUser(username: $username) {username profile {ranking} submitStats {acSubmissionNum {difficulty count}}}}",
+		"variables": map[string]string{"username": username},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Referer", "https://leetcode.com")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// Make HTTP request to LeetCode GraphQL API
+	resp, err := defaultClient.Do(ctx, "leetcode", Request{
+		Method: http.MethodPost,
+		URL:    endpoints.LeetCodeGraphQL,
+		Body:   body,
+		Header: leetcodeHeaders,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch LeetCode data: %w", err)
 	}
-	defer resp.Body.Close()
-
-	fmt.Printf("DEBUG SCRAPER: LeetCode API Status Code: %d\n", resp.StatusCode)
-
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("LeetCode API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	fmt.Printf("DEBUG SCRAPER: LeetCode API Response: %s\n", string(body))
-
 	var leetCodeResp LeetCodeResponse
-	if err := json.Unmarshal(body, &leetCodeResp); err != nil {
-		fmt.Printf("DEBUG SCRAPER: JSON Parse Error: %v\n", err)
+	if err := json.Unmarshal(resp.Body, &leetCodeResp); err != nil {
 		return nil, fmt.Errorf("failed to parse LeetCode response: %w", err)
 	}
 
-	fmt.Printf("DEBUG SCRAPER: Parsed MatchedUser: %+v\n", leetCodeResp.Data.MatchedUser)
-	fmt.Printf("DEBUG SCRAPER: Profile Ranking: %d\n", leetCodeResp.Data.MatchedUser.Profile.Ranking)
-	fmt.Printf("DEBUG SCRAPER: AcSubmissionNum count: %d\n", len(leetCodeResp.Data.MatchedUser.SubmitStats.AcSubmissionNum))
+	user := leetCodeResp.Data.MatchedUser
+	if user == nil {
+		if len(leetCodeResp.Errors) > 0 {
+			return nil, fmt.Errorf("LeetCode user '%s' not found: %s", username, leetCodeResp.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("LeetCode user '%s' not found", username)
+	}
+	// A missing submission breakdown means the response format changed
+	if len(user.SubmitStats.AcSubmissionNum) == 0 {
+		return nil, fmt.Errorf("LeetCode API response format changed. Verify username at: https://leetcode.com/u/%s/", username)
+	}
 
 	// Parse submission stats
 	stats := &PlatformStats{
-		GlobalRank: leetCodeResp.Data.MatchedUser.Profile.Ranking,
+		GlobalRank: user.Profile.Ranking,
 	}
 
-	for _, stat := range leetCodeResp.Data.MatchedUser.SubmitStats.AcSubmissionNum {
-		fmt.Printf("DEBUG SCRAPER: Processing difficulty: %s, count: %d\n", stat.Difficulty, stat.Count)
-		stats.ProblemsSolved += stat.Count
+	total := -1
+	for _, stat := range user.SubmitStats.AcSubmissionNum {
 		switch stat.Difficulty {
+		case "All":
+			total = stat.Count
 		case "Easy":
 			stats.EasyProblemsSolved = stat.Count
 		case "Medium":
@@ -108,9 +98,91 @@ func FetchLeetCodeStats(username string) (*PlatformStats, error) {
 			stats.HardProblemsSolved = stat.Count
 		}
 	}
-
-	fmt.Printf("DEBUG SCRAPER: Final stats - Rank: %d, Total: %d, Easy: %d, Medium: %d, Hard: %d\n",
-		stats.GlobalRank, stats.ProblemsSolved, stats.EasyProblemsSolved, stats.MedProblemsSolved, stats.HardProblemsSolved)
+	// "All" is the total; without it the total is the sum of the difficulties
+	if total < 0 {
+		total = stats.EasyProblemsSolved + stats.MedProblemsSolved + stats.HardProblemsSolved
+	}
+	stats.ProblemsSolved = total
 
 	return stats, nil
 }
+
+// leetcodeUsername extracts the username if a profile URL such as
+// "https://leetcode.com/u/username/" or "leetcode.com/username/" is given
+func leetcodeUsername(username string) string {
+	if strings.Contains(username, "leetcode.com") {
+		// Remove protocol if present
+		username = strings.TrimPrefix(username, "https://")
+		username = strings.TrimPrefix(username, "http://")
+		// Remove domain
+		username = strings.TrimPrefix(username, "leetcode.com/")
+		// Remove /u/ prefix if present
+		username = strings.TrimPrefix(username, "u/")
+		// Remove trailing slashes
+		username = strings.TrimSuffix(username, "/")
+	}
+	return username
+}
+
+// leetcodePlatform implements Platform for LeetCode
+type leetcodePlatform struct{}
+
+func init() {
+	Register(leetcodePlatform{})
+}
+
+func (leetcodePlatform) Name() string {
+	return "leetcode"
+}
+
+func (leetcodePlatform) DisplayName() string {
+	return "LeetCode"
+}
+
+func (leetcodePlatform) FetchStats(ctx context.Context, username string) (*PlatformStats, error) {
+	return FetchLeetCodeStats(ctx, username)
+}
+
+func (leetcodePlatform) FetchProblems(ctx context.Context, limit int) ([]ProblemInfo, error) {
+	problems, _, err := FetchLeetCodeProblems(ctx, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]ProblemInfo, 0, len(problems))
+	for _, p := range problems {
+		tags := make([]string, len(p.TopicTags))
+		for i, tag := range p.TopicTags {
+			tags[i] = tag.Name
+		}
+
+		infos = append(infos, ProblemInfo{
+			Platform:          "leetcode",
+			PlatformProblemID: p.QuestionFrontendID,
+			Title:             p.Title,
+			Slug:              p.TitleSlug,
+			Difficulty:        strings.ToLower(p.Difficulty),
+			Tags:              tags,
+			AcceptanceRate:    p.AcRate,
+			ProblemURL:        fmt.Sprintf("https://leetcode.com/problems/%s/", p.TitleSlug),
+			IsPaidOnly:        p.IsPaidOnly,
+		})
+	}
+	return infos, nil
+}
+
+func (leetcodePlatform) FetchContests(ctx context.Context) ([]ContestInfo, error) {
+	return FetchLeetCodeContests(ctx)
+}
+
+func (leetcodePlatform) FetchContestHistory(ctx context.Context, username string) ([]ContestResult, error) {
+	return FetchLeetCodeContestHistory(ctx, username)
+}
+
+func (leetcodePlatform) FetchAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	return FetchLeetCodeAcceptedSubmissions(ctx, username, since)
+}
+
+func (leetcodePlatform) FetchProblemContent(ctx context.Context, slug string) (*ProblemContent, error) {
+	return FetchLeetCodeProblemContent(ctx, slug)
+}
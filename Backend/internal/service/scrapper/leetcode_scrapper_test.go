@@ -0,0 +1,128 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchLeetCodeStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		username  string
+		response  fixture
+		wantStats *PlatformStats
+		wantErr   string
+	}{
+		{
+			// "All" is the total, not another bucket to add
+			name:     "success",
+			username: "neal_wu",
+			response: fixture{http.StatusOK, "leetcode/stats_success.json"},
+			wantStats: &PlatformStats{
+				GlobalRank:         1523,
+				ProblemsSolved:     1000,
+				EasyProblemsSolved: 310,
+				MedProblemsSolved:  540,
+				HardProblemsSolved: 150,
+			},
+		},
+		{
+			name:     "profile url",
+			username: "https://leetcode.com/u/neal_wu/",
+			response: fixture{http.StatusOK, "leetcode/stats_success.json"},
+			wantStats: &PlatformStats{
+				GlobalRank:         1523,
+				ProblemsSolved:     1000,
+				EasyProblemsSolved: 310,
+				MedProblemsSolved:  540,
+				HardProblemsSolved: 150,
+			},
+		},
+		{
+			name:     "without total",
+			username: "neal_wu",
+			response: fixture{http.StatusOK, "leetcode/stats_without_total.json"},
+			wantStats: &PlatformStats{
+				GlobalRank:         1523,
+				ProblemsSolved:     1000,
+				EasyProblemsSolved: 310,
+				MedProblemsSolved:  540,
+				HardProblemsSolved: 150,
+			},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			response: fixture{http.StatusOK, "leetcode/stats_not_found.json"},
+			wantErr:  "not found",
+		},
+		{
+			name:     "format changed",
+			username: "neal_wu",
+			response: fixture{http.StatusOK, "leetcode/stats_format_changed.json"},
+			wantErr:  "format changed",
+		},
+		{
+			name:     "forbidden",
+			username: "neal_wu",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			username: "neal_wu",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			username: "neal_wu",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/leetcode/graphql": tt.response})
+
+			stats, err := FetchLeetCodeStats(context.Background(), tt.username)
+			assertError(t, err, tt.wantErr)
+			assertStats(t, stats, tt.wantStats)
+		})
+	}
+}
+
+func TestLeetCodePlatformFetchProblems(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/leetcode/graphql": {http.StatusOK, "leetcode/problems_success.json"},
+	})
+
+	p, ok := Get("leetcode")
+	if !ok {
+		t.Fatal("leetcode platform not registered")
+	}
+
+	problems, err := p.FetchProblems(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %d", len(problems))
+	}
+
+	twoSum := problems[0]
+	if twoSum.PlatformProblemID != "1" || twoSum.Slug != "two-sum" || twoSum.Difficulty != "easy" {
+		t.Errorf("unexpected problem: %+v", twoSum)
+	}
+	if twoSum.ProblemURL != "https://leetcode.com/problems/two-sum/" {
+		t.Errorf("unexpected problem url: %s", twoSum.ProblemURL)
+	}
+	if len(twoSum.Tags) != 2 || twoSum.Tags[0] != "Array" || twoSum.Tags[1] != "Hash Table" {
+		t.Errorf("unexpected tags: %v", twoSum.Tags)
+	}
+	if !problems[1].IsPaidOnly {
+		t.Errorf("expected %s to be paid only", problems[1].Slug)
+	}
+}
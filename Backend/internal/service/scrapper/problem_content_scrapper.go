@@ -0,0 +1,173 @@
+package scrapper
+
+import (
+	"context"
+	"dojo/internal/utils"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ProblemContent is a problem's statement, converted to sanitized markdown
+type ProblemContent struct {
+	Description string
+	Constraints string
+	Examples    []ProblemExample
+	Hints       []string
+}
+
+// ProblemExample is one worked example from a problem statement
+type ProblemExample struct {
+	Input       string `json:"input"`
+	Output      string `json:"output"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// LeetCodeQuestionContentResponse represents LeetCode question GraphQL response
+type LeetCodeQuestionContentResponse struct {
+	Data struct {
+		// Question is null when no problem has the slug
+		Question *struct {
+			Content    *string  `json:"content"` // Null for premium problems
+			Hints      []string `json:"hints"`
+			IsPaidOnly bool     `json:"isPaidOnly"`
+		} `json:"question"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+var (
+	// leetcodeExampleHeadingRe matches the "Example 1:" heading of each example
+	leetcodeExampleHeadingRe = regexp.MustCompile(`(?i)<strong[^>]*>\s*Example\s*\d*\s*:?\s*</strong>`)
+	// leetcodeConstraintsHeadingRe matches the heading of the constraints list
+	leetcodeConstraintsHeadingRe = regexp.MustCompile(`(?i)<strong[^>]*>\s*Constraints\s*:?\s*</strong>`)
+	// leetcodeFollowUpRe matches the start of the follow-up question after the constraints
+	leetcodeFollowUpRe = regexp.MustCompile(`(?i)<strong[^>]*>\s*Follow[\s-]*up`)
+	// leetcodeExampleRe splits an example's text into its labeled parts
+	leetcodeExampleRe = regexp.MustCompile(`(?s)Input:\s*(.*?)\s*Output:\s*(.*?)\s*(?:Explanation:\s*(.*))?$`)
+	// htmlLineBreakRe matches the tags that end a line of text
+	htmlLineBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li)>`)
+	// htmlAnyTagRe matches any HTML tag
+	htmlAnyTagRe = regexp.MustCompile(`<[^>]*>`)
+)
+
+// FetchLeetCodeProblemContent fetches the statement, examples and hints of a LeetCode problem
+func FetchLeetCodeProblemContent(ctx context.Context, slug string) (*ProblemContent, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return nil, fmt.Errorf("slug is required")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     "query questionContent($titleSlug: String!) {question(titleSlug: $titleSlug) {content hints isPaidOnly}}",
+		"variables": map[string]string{"titleSlug": slug},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := defaultClient.Do(ctx, "leetcode", Request{
+		Method: http.MethodPost,
+		URL:    endpoints.LeetCodeGraphQL,
+		Body:   body,
+		Header: leetcodeHeaders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch LeetCode problem: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LeetCode API returned status %d", resp.StatusCode)
+	}
+
+	var contentResp LeetCodeQuestionContentResponse
+	if err := json.Unmarshal(resp.Body, &contentResp); err != nil {
+		return nil, fmt.Errorf("failed to parse LeetCode response: %w", err)
+	}
+
+	question := contentResp.Data.Question
+	if question == nil {
+		return nil, fmt.Errorf("LeetCode problem '%s' not found", slug)
+	}
+	if question.Content == nil || *question.Content == "" {
+		if question.IsPaidOnly {
+			return nil, fmt.Errorf("LeetCode problem '%s' is premium only", slug)
+		}
+		return nil, fmt.Errorf("LeetCode problem '%s' has no content", slug)
+	}
+
+	content := parseLeetCodeContent(*question.Content)
+	for _, hint := range question.Hints {
+		if hint = utils.HTMLToMarkdown(hint); hint != "" {
+			content.Hints = append(content.Hints, hint)
+		}
+	}
+	return content, nil
+}
+
+// parseLeetCodeContent splits a LeetCode statement into the description, examples and
+// constraints. The follow-up question after the constraints is kept in the description.
+func parseLeetCodeContent(content string) *ProblemContent {
+	end := len(content)
+	followUp := ""
+	if loc := leetcodeFollowUpRe.FindStringIndex(content); loc != nil {
+		followUp = content[loc[0]:]
+		end = loc[0]
+	}
+
+	constraints := ""
+	if loc := leetcodeConstraintsHeadingRe.FindStringIndex(content[:end]); loc != nil {
+		constraints = content[loc[1]:end]
+		end = loc[0]
+	}
+
+	var examples []ProblemExample
+	headings := leetcodeExampleHeadingRe.FindAllStringIndex(content[:end], -1)
+	for i, loc := range headings {
+		blockEnd := end
+		if i+1 < len(headings) {
+			blockEnd = headings[i+1][0]
+		}
+		if example, ok := parseLeetCodeExample(content[loc[1]:blockEnd]); ok {
+			examples = append(examples, example)
+		}
+	}
+	if len(headings) > 0 {
+		end = headings[0][0]
+	}
+
+	description := utils.HTMLToMarkdown(content[:end])
+	if followUp != "" {
+		description = strings.TrimSpace(description + "\n\n" + utils.HTMLToMarkdown(followUp))
+	}
+
+	return &ProblemContent{
+		Description: description,
+		Constraints: utils.HTMLToMarkdown(constraints),
+		Examples:    examples,
+	}
+}
+
+// parseLeetCodeExample reads the input, output and explanation of an example. Both the
+// <pre> blocks of older problems and the example blocks of newer ones are plain text once
+// their tags are dropped.
+func parseLeetCodeExample(block string) (ProblemExample, bool) {
+	text := htmlLineBreakRe.ReplaceAllString(block, "\n")
+	text = strings.ReplaceAll(text, "<sup>", "^")
+	text = html.UnescapeString(htmlAnyTagRe.ReplaceAllString(text, ""))
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+
+	match := leetcodeExampleRe.FindStringSubmatch(text)
+	if match == nil {
+		return ProblemExample{}, false
+	}
+	return ProblemExample{
+		Input:       strings.TrimSpace(match[1]),
+		Output:      strings.TrimSpace(match[2]),
+		Explanation: strings.TrimSpace(match[3]),
+	}, true
+}
@@ -0,0 +1,120 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchLeetCodeProblemContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     fixture
+		wantExamples int
+		wantErr      string
+	}{
+		{
+			name:         "success",
+			response:     fixture{http.StatusOK, "leetcode/question_content_success.json"},
+			wantExamples: 2,
+		},
+		{
+			// Newer problems put examples in example blocks instead of <pre>
+			name:         "example blocks",
+			response:     fixture{http.StatusOK, "leetcode/question_content_example_block.json"},
+			wantExamples: 1,
+		},
+		{
+			name:     "premium",
+			response: fixture{http.StatusOK, "leetcode/question_content_premium.json"},
+			wantErr:  "premium only",
+		},
+		{
+			name:     "not found",
+			response: fixture{http.StatusOK, "leetcode/question_content_not_found.json"},
+			wantErr:  "not found",
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/leetcode/graphql": tt.response})
+
+			content, err := FetchLeetCodeProblemContent(context.Background(), "two-sum")
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			if len(content.Examples) != tt.wantExamples {
+				t.Fatalf("expected %d examples, got %d: %+v", tt.wantExamples, len(content.Examples), content.Examples)
+			}
+		})
+	}
+}
+
+func TestFetchLeetCodeProblemContentFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/leetcode/graphql": {http.StatusOK, "leetcode/question_content_success.json"},
+	})
+
+	content, err := FetchLeetCodeProblemContent(context.Background(), "two-sum")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The follow-up stays in the description; scripts and unsafe links are dropped
+	wantDescription := "Given an array of integers `nums` and an integer `target`, return *indices of the two numbers such that they add up to `target`*.\n\n" +
+		"You may assume that each input would have ***exactly* one solution**, and you may not use the *same* element twice.\n\n" +
+		"**Follow-up:** Can you come up with an algorithm that is less than `O(n^2)` time complexity?x"
+	if content.Description != wantDescription {
+		t.Errorf("unexpected description:\n%s", content.Description)
+	}
+
+	wantConstraints := "- `2 <= nums.length <= 10^4`\n- `-10^9 <= nums[i] <= 10^9`\n- **Only one valid answer exists.**"
+	if content.Constraints != wantConstraints {
+		t.Errorf("unexpected constraints:\n%s", content.Constraints)
+	}
+
+	wantExamples := []ProblemExample{
+		{Input: "nums = [2,7,11,15], target = 9", Output: "[0,1]", Explanation: "Because nums[0] + nums[1] == 9, we return [0, 1]."},
+		{Input: "nums = [3,2,4], target = 6", Output: "[1,2]"},
+	}
+	for i, want := range wantExamples {
+		if content.Examples[i] != want {
+			t.Errorf("example %d: expected %+v, got %+v", i, want, content.Examples[i])
+		}
+	}
+
+	if len(content.Hints) != 2 || content.Hints[1] != "Try a **hash map**: `target - x`" {
+		t.Errorf("unexpected hints: %q", content.Hints)
+	}
+}
+
+func TestParseLeetCodeContentExampleBlock(t *testing.T) {
+	content := parseLeetCodeContent(`<p>Count <code>s</code>.</p>
+<p><strong class="example">Example 1:</strong></p>
+<div class="example-block">
+<p><strong>Input:</strong> <span class="example-io">s = &quot;abc&quot;</span></p>
+<p><strong>Output:</strong> <span class="example-io">3</span></p>
+<p><strong>Explanation:</strong></p>
+<p>Each character is distinct.</p>
+</div>`)
+
+	if content.Description != "Count `s`." || content.Constraints != "" {
+		t.Errorf("unexpected content: %+v", content)
+	}
+	want := ProblemExample{Input: `s = "abc"`, Output: "3", Explanation: "Each character is distinct."}
+	if len(content.Examples) != 1 || content.Examples[0] != want {
+		t.Errorf("unexpected examples: %+v", content.Examples)
+	}
+}
@@ -1,13 +1,16 @@
 package scrapper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
+	"time"
 )
 
+// problemCatalogCacheTTL is how long problem catalog responses are reused
+const problemCatalogCacheTTL = time.Hour
+
 // LeetCodeProblem represents a single problem from LeetCode
 type LeetCodeProblem struct {
 	QuestionID         string `json:"questionId"`
@@ -34,7 +37,7 @@ type LeetCodeProblemsResponse struct {
 }
 
 // FetchLeetCodeProblems fetches a list of problems from LeetCode
-func FetchLeetCodeProblems(limit int, skip int) ([]LeetCodeProblem, int, error) {
+func FetchLeetCodeProblems(ctx context.Context, limit int, skip int) ([]LeetCodeProblem, int, error) {
 	if limit <= 0 {
 		limit = 50
 	}
@@ -44,32 +47,23 @@ func FetchLeetCodeProblems(limit int, skip int) ([]LeetCodeProblem, int, error)
 		"query": "{problemsetQuestionList(categorySlug: \"\", limit: %d, skip: %d, filters: {}) {total questions {questionId questionFrontendId title titleSlug difficulty paidOnly topicTags {name slug} acRate}}}"
 	}`, limit, skip)
 
-	req, err := http.NewRequest("POST", "https://leetcode.com/graphql", strings.NewReader(query))
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Referer", "https://leetcode.com")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := defaultClient.Do(ctx, "leetcode", Request{
+		Method:   http.MethodPost,
+		URL:      endpoints.LeetCodeGraphQL,
+		Body:     []byte(query),
+		Header:   leetcodeHeaders,
+		CacheTTL: problemCatalogCacheTTL,
+	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to fetch LeetCode problems: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, 0, fmt.Errorf("LeetCode API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	var problemsResp LeetCodeProblemsResponse
-	if err := json.Unmarshal(body, &problemsResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &problemsResp); err != nil {
 		return nil, 0, fmt.Errorf("failed to parse LeetCode response: %w", err)
 	}
 
@@ -107,24 +101,22 @@ type CodeforcesProblem struct {
 }
 
 // FetchCodeforcesProblems fetches problems from Codeforces API
-func FetchCodeforcesProblems() ([]CodeforcesProblem, error) {
-	resp, err := http.Get("https://codeforces.com/api/problemset.problems")
+func FetchCodeforcesProblems(ctx context.Context) ([]CodeforcesProblem, error) {
+	resp, err := defaultClient.Do(ctx, "codeforces", Request{
+		URL:      endpoints.CodeforcesAPI + "/problemset.problems",
+		Header:   codeforcesHeaders,
+		CacheTTL: problemCatalogCacheTTL,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch Codeforces problems: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Codeforces API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	var cfResp CodeforcesProblemsResponse
-	if err := json.Unmarshal(body, &cfResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &cfResp); err != nil {
 		return nil, fmt.Errorf("failed to parse Codeforces response: %w", err)
 	}
 
@@ -0,0 +1,96 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchLeetCodeProblems(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantCount int
+		wantTotal int
+		wantErr   string
+	}{
+		{
+			name:      "success",
+			response:  fixture{http.StatusOK, "leetcode/problems_success.json"},
+			wantCount: 2,
+			wantTotal: 3120,
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/leetcode/graphql": tt.response})
+
+			problems, total, err := FetchLeetCodeProblems(context.Background(), 2, 0)
+			assertError(t, err, tt.wantErr)
+			if len(problems) != tt.wantCount || total != tt.wantTotal {
+				t.Fatalf("expected %d problems of %d, got %d of %d", tt.wantCount, tt.wantTotal, len(problems), total)
+			}
+		})
+	}
+}
+
+func TestFetchCodeforcesProblems(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  fixture
+		wantCount int
+		wantErr   string
+	}{
+		{
+			name:      "success",
+			response:  fixture{http.StatusOK, "codeforces/problemset_success.json"},
+			wantCount: 3,
+		},
+		{
+			name:     "forbidden",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			response: malformedJSON,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "html page",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/codeforces/problemset.problems": tt.response})
+
+			problems, err := FetchCodeforcesProblems(context.Background())
+			assertError(t, err, tt.wantErr)
+			if len(problems) != tt.wantCount {
+				t.Fatalf("expected %d problems, got %d", tt.wantCount, len(problems))
+			}
+			if tt.wantCount > 0 && problems[2].Rating != 0 {
+				t.Errorf("expected unrated problem to have rating 0, got %d", problems[2].Rating)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package scrapper
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Platform)
+)
+
+// Register makes a platform available by name. Platforms register themselves in init.
+func Register(p Platform) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[p.Name()]; exists {
+		panic(fmt.Sprintf("scrapper: platform %q registered twice", p.Name()))
+	}
+	registry[p.Name()] = p
+}
+
+// Get looks up a platform by name
+func Get(name string) (Platform, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	p, ok := registry[name]
+	return p, ok
+}
+
+// All returns every registered platform, sorted by name
+func All() []Platform {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	platforms := make([]Platform, 0, len(registry))
+	for _, p := range registry {
+		platforms = append(platforms, p)
+	}
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].Name() < platforms[j].Name()
+	})
+	return platforms
+}
+
+// Names returns the names of every registered platform, sorted
+func Names() []string {
+	platforms := All()
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name()
+	}
+	return names
+}
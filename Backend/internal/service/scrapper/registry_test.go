@@ -0,0 +1,15 @@
+package scrapper
+
+import (
+	"dojo/internal/models"
+	"testing"
+)
+
+// Every registered platform needs a profile column to link handles in
+func TestEveryPlatformHasUsernameColumn(t *testing.T) {
+	for _, platform := range Names() {
+		if _, ok := models.PlatformUsernameColumn(platform); !ok {
+			t.Errorf("UserProfile has no column for %s", platform)
+		}
+	}
+}
@@ -1,5 +1,15 @@
 package scrapper
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotSupported is returned when a platform does not offer a capability
+// (e.g. no public problem catalog)
+var ErrNotSupported = errors.New("not supported by this platform")
+
 // PlatformStats represents statistics for a specific platform
 type PlatformStats struct {
 	Rating             int
@@ -11,3 +21,42 @@ type PlatformStats struct {
 	ContestsAttended   int
 	GlobalRank         int
 }
+
+// ProblemInfo represents a problem from any platform's catalog
+type ProblemInfo struct {
+	Platform          string
+	PlatformProblemID string // e.g. "1" on LeetCode, "1700A" on Codeforces
+	Title             string
+	Slug              string
+	Difficulty        string // easy, medium, hard
+	Rating            int    // Platform difficulty rating, 0 if unknown
+	Tags              []string
+	AcceptanceRate    float64
+	SolvedCount       int
+	ProblemURL        string
+	IsPaidOnly        bool
+}
+
+// Platform is implemented by every supported coding site.
+// Capabilities a site does not offer return ErrNotSupported.
+type Platform interface {
+	// Name is the identifier stored in the database ("leetcode", "codeforces", ...)
+	Name() string
+	// DisplayName is the human readable name used in messages
+	DisplayName() string
+	// FetchStats fetches a user's statistics. Stats may be returned alongside an
+	// error when only part of them could be fetched.
+	FetchStats(ctx context.Context, username string) (*PlatformStats, error)
+	// FetchProblems fetches up to limit problems from the catalog (limit <= 0 means the default)
+	FetchProblems(ctx context.Context, limit int) ([]ProblemInfo, error)
+	// FetchContests fetches upcoming and ongoing contests
+	FetchContests(ctx context.Context) ([]ContestInfo, error)
+	// FetchContestHistory fetches a user's results in past rated contests
+	FetchContestHistory(ctx context.Context, username string) ([]ContestResult, error)
+	// FetchAcceptedSubmissions fetches a user's accepted submissions made after since
+	// (zero for all), oldest first
+	FetchAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error)
+	// FetchProblemContent fetches the statement of a problem in the catalog, identified by
+	// its slug
+	FetchProblemContent(ctx context.Context, slug string) (*ProblemContent, error)
+}
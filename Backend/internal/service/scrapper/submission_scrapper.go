@@ -0,0 +1,201 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AcceptedSubmission is a user's accepted submission on a platform
+type AcceptedSubmission struct {
+	Problem      ProblemInfo // Enough to create the problem if it isn't stored yet
+	SubmissionID string      // Platform submission ID, unique per platform
+	Language     string
+	SubmittedAt  time.Time
+}
+
+// codeforcesUserStatusCacheTTL lets one sync read user.status for stats, contest history and
+// solved problems with a single request
+const codeforcesUserStatusCacheTTL = time.Minute
+
+// CodeforcesSubmissionsResponse represents Codeforces user.status API response
+type CodeforcesSubmissionsResponse struct {
+	Status string `json:"status"`
+	Result []struct {
+		ID                  int64 `json:"id"`
+		CreationTimeSeconds int64 `json:"creationTimeSeconds"`
+		Problem             struct {
+			ContestID int      `json:"contestId"`
+			Index     string   `json:"index"`
+			Name      string   `json:"name"`
+			Rating    int      `json:"rating"`
+			Tags      []string `json:"tags"`
+		} `json:"problem"`
+		Author struct {
+			ParticipantType string `json:"participantType"`
+		} `json:"author"`
+		ProgrammingLanguage string `json:"programmingLanguage"`
+		Verdict             string `json:"verdict"`
+	} `json:"result"`
+}
+
+// fetchCodeforcesUserStatus fetches a user's submissions, newest first
+func fetchCodeforcesUserStatus(ctx context.Context, username string) (*Response, error) {
+	return defaultClient.Do(ctx, "codeforces", Request{
+		URL:      fmt.Sprintf("%s/user.status?handle=%s&from=1&count=10000", endpoints.CodeforcesAPI, username),
+		Header:   codeforcesHeaders,
+		CacheTTL: codeforcesUserStatusCacheTTL,
+	})
+}
+
+// FetchCodeforcesAcceptedSubmissions fetches a user's accepted Codeforces submissions made
+// after since (zero for all), oldest first
+func FetchCodeforcesAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	username = codeforcesHandle(username)
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	resp, err := fetchCodeforcesUserStatus(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Codeforces submissions: %w", err)
+	}
+
+	// Unknown handles are reported as 400 with a "not found" comment
+	if resp.StatusCode == http.StatusNotFound ||
+		(resp.StatusCode == http.StatusBadRequest && strings.Contains(string(resp.Body), "not found")) {
+		return nil, fmt.Errorf("Codeforces user '%s' not found", username)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Codeforces API returned status %d", resp.StatusCode)
+	}
+
+	var cfResp CodeforcesSubmissionsResponse
+	if err := json.Unmarshal(resp.Body, &cfResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Codeforces response: %w", err)
+	}
+	if cfResp.Status != "OK" {
+		return nil, fmt.Errorf("Codeforces API error: status %s", cfResp.Status)
+	}
+
+	var accepted []AcceptedSubmission
+	// The API lists newest first
+	for i := len(cfResp.Result) - 1; i >= 0; i-- {
+		submission := cfResp.Result[i]
+		submittedAt := time.Unix(submission.CreationTimeSeconds, 0)
+		// Problems outside contests (e.g. acmsguru) have no contest ID to identify them
+		if submission.Verdict != "OK" || submission.Problem.ContestID == 0 || !submittedAt.After(since) {
+			continue
+		}
+
+		p := submission.Problem
+		accepted = append(accepted, AcceptedSubmission{
+			Problem: codeforcesProblemInfo(CodeforcesProblem{
+				ContestID: p.ContestID,
+				Index:     p.Index,
+				Name:      p.Name,
+				Rating:    p.Rating,
+				Tags:      p.Tags,
+			}),
+			SubmissionID: strconv.FormatInt(submission.ID, 10),
+			Language:     submission.ProgrammingLanguage,
+			SubmittedAt:  submittedAt,
+		})
+	}
+
+	return accepted, nil
+}
+
+// leetcodeRecentACLimit is the most recent accepted submissions LeetCode shows on a public profile
+const leetcodeRecentACLimit = 20
+
+// LeetCodeRecentACResponse represents LeetCode recentAcSubmissionList GraphQL response
+type LeetCodeRecentACResponse struct {
+	Data struct {
+		// RecentAcSubmissionList is null when the user does not exist
+		RecentAcSubmissionList *[]struct {
+			ID        string `json:"id"`
+			Title     string `json:"title"`
+			TitleSlug string `json:"titleSlug"`
+			Timestamp string `json:"timestamp"` // Unix seconds
+		} `json:"recentAcSubmissionList"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// FetchLeetCodeAcceptedSubmissions fetches a user's recent accepted LeetCode submissions made
+// after since (zero for all), oldest first. LeetCode only exposes the last few, so problems are
+// identified by slug alone and must already be in the catalogue.
+func FetchLeetCodeAcceptedSubmissions(ctx context.Context, username string, since time.Time) ([]AcceptedSubmission, error) {
+	username = leetcodeUsername(username)
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     "query recentAcSubmissions($username: String!, $limit: Int!) {recentAcSubmissionList(username: $username, limit: $limit) {id title titleSlug timestamp}}",
+		"variables": map[string]interface{}{"username": username, "limit": leetcodeRecentACLimit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := defaultClient.Do(ctx, "leetcode", Request{
+		Method: http.MethodPost,
+		URL:    endpoints.LeetCodeGraphQL,
+		Body:   body,
+		Header: leetcodeHeaders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch LeetCode submissions: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LeetCode API returned status %d", resp.StatusCode)
+	}
+
+	var acResp LeetCodeRecentACResponse
+	if err := json.Unmarshal(resp.Body, &acResp); err != nil {
+		return nil, fmt.Errorf("failed to parse LeetCode response: %w", err)
+	}
+
+	list := acResp.Data.RecentAcSubmissionList
+	if list == nil {
+		if len(acResp.Errors) > 0 {
+			return nil, fmt.Errorf("LeetCode user '%s' not found: %s", username, acResp.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("LeetCode user '%s' not found", username)
+	}
+
+	var accepted []AcceptedSubmission
+	// The API lists newest first
+	for i := len(*list) - 1; i >= 0; i-- {
+		submission := (*list)[i]
+		seconds, err := strconv.ParseInt(submission.Timestamp, 10, 64)
+		if err != nil || submission.TitleSlug == "" {
+			continue
+		}
+		submittedAt := time.Unix(seconds, 0)
+		if !submittedAt.After(since) {
+			continue
+		}
+
+		accepted = append(accepted, AcceptedSubmission{
+			Problem: ProblemInfo{
+				Platform:   "leetcode",
+				Title:      submission.Title,
+				Slug:       submission.TitleSlug,
+				ProblemURL: fmt.Sprintf("https://leetcode.com/problems/%s/", submission.TitleSlug),
+			},
+			SubmissionID: submission.ID,
+			SubmittedAt:  submittedAt,
+		})
+	}
+
+	return accepted, nil
+}
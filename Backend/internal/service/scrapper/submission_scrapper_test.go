@@ -0,0 +1,183 @@
+package scrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFetchCodeforcesAcceptedSubmissions(t *testing.T) {
+	userStatusOK := fixture{http.StatusOK, "codeforces/user_status_accepted.json"}
+
+	tests := []struct {
+		name     string
+		username string
+		since    time.Time
+		routes   map[string]fixture
+		wantIDs  []string // Submission IDs, oldest first
+		wantErr  string
+	}{
+		{
+			// Rejected submissions and problems without a contest are skipped
+			name:     "success",
+			username: "tourist",
+			routes:   map[string]fixture{"/codeforces/user.status": userStatusOK},
+			wantIDs:  []string{"202", "203", "205"},
+		},
+		{
+			name:     "since",
+			username: "https://codeforces.com/profile/tourist",
+			since:    time.Unix(1700000300, 0),
+			routes:   map[string]fixture{"/codeforces/user.status": userStatusOK},
+			wantIDs:  []string{"205"},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			routes: map[string]fixture{
+				"/codeforces/user.status": {http.StatusBadRequest, "codeforces/user_info_not_found.json"},
+			},
+			wantErr: "not found",
+		},
+		{
+			name:     "forbidden",
+			username: "tourist",
+			routes:   map[string]fixture{"/codeforces/user.status": forbidden},
+			wantErr:  "status 403",
+		},
+		{
+			name:     "malformed json",
+			username: "tourist",
+			routes:   map[string]fixture{"/codeforces/user.status": malformedJSON},
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, tt.routes)
+
+			submissions, err := FetchCodeforcesAcceptedSubmissions(context.Background(), tt.username, tt.since)
+			assertError(t, err, tt.wantErr)
+			if len(submissions) != len(tt.wantIDs) {
+				t.Fatalf("expected %d submissions, got %d: %+v", len(tt.wantIDs), len(submissions), submissions)
+			}
+			for i, id := range tt.wantIDs {
+				if submissions[i].SubmissionID != id {
+					t.Errorf("submission %d: expected ID %s, got %s", i, id, submissions[i].SubmissionID)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchCodeforcesAcceptedSubmissionsFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/codeforces/user.status": {http.StatusOK, "codeforces/user_status_accepted.json"},
+	})
+
+	submissions, err := FetchCodeforcesAcceptedSubmissions(context.Background(), "tourist", time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := submissions[0]
+	if first.Problem.PlatformProblemID != "1A" || first.Problem.Title != "Theatre Square" ||
+		first.Problem.Rating != 1000 || first.Problem.Difficulty != "easy" {
+		t.Errorf("unexpected problem: %+v", first.Problem)
+	}
+	if first.Problem.ProblemURL != "https://codeforces.com/problemset/problem/1/A" {
+		t.Errorf("unexpected problem URL: %s", first.Problem.ProblemURL)
+	}
+	if first.Language != "GNU C++17" || !first.SubmittedAt.Equal(time.Unix(1700000200, 0)) {
+		t.Errorf("unexpected submission: %+v", first)
+	}
+
+	gym := submissions[2]
+	if gym.Problem.ProblemURL != "https://codeforces.com/gym/100001/problem/B" {
+		t.Errorf("unexpected gym problem URL: %s", gym.Problem.ProblemURL)
+	}
+}
+
+func TestFetchLeetCodeAcceptedSubmissions(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		since    time.Time
+		response fixture
+		wantIDs  []string // Submission IDs, oldest first
+		wantErr  string
+	}{
+		{
+			name:     "success",
+			username: "neal_wu",
+			response: fixture{http.StatusOK, "leetcode/recent_ac_success.json"},
+			wantIDs:  []string{"1300000001", "1300000002", "1300000003"},
+		},
+		{
+			name:     "since",
+			username: "https://leetcode.com/u/neal_wu/",
+			since:    time.Unix(1700000300, 0),
+			response: fixture{http.StatusOK, "leetcode/recent_ac_success.json"},
+			wantIDs:  []string{"1300000003"},
+		},
+		{
+			name:     "user not found",
+			username: "no_such_user_42",
+			response: fixture{http.StatusOK, "leetcode/recent_ac_not_found.json"},
+			wantErr:  "not found",
+		},
+		{
+			name:     "forbidden",
+			username: "neal_wu",
+			response: forbidden,
+			wantErr:  "status 403",
+		},
+		{
+			name:     "html page",
+			username: "neal_wu",
+			response: blockedPage,
+			wantErr:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serveFixtures(t, map[string]fixture{"/leetcode/graphql": tt.response})
+
+			submissions, err := FetchLeetCodeAcceptedSubmissions(context.Background(), tt.username, tt.since)
+			assertError(t, err, tt.wantErr)
+			if len(submissions) != len(tt.wantIDs) {
+				t.Fatalf("expected %d submissions, got %d: %+v", len(tt.wantIDs), len(submissions), submissions)
+			}
+			for i, id := range tt.wantIDs {
+				if submissions[i].SubmissionID != id {
+					t.Errorf("submission %d: expected ID %s, got %s", i, id, submissions[i].SubmissionID)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchLeetCodeAcceptedSubmissionsFields(t *testing.T) {
+	serveFixtures(t, map[string]fixture{
+		"/leetcode/graphql": {http.StatusOK, "leetcode/recent_ac_success.json"},
+	})
+
+	submissions, err := FetchLeetCodeAcceptedSubmissions(context.Background(), "neal_wu", time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// LeetCode submissions only identify the problem by slug
+	first := submissions[0]
+	if first.Problem.Platform != "leetcode" || first.Problem.Slug != "two-sum" ||
+		first.Problem.PlatformProblemID != "" || first.Problem.Title != "Two Sum" {
+		t.Errorf("unexpected problem: %+v", first.Problem)
+	}
+	if first.Problem.ProblemURL != "https://leetcode.com/problems/two-sum/" ||
+		!first.SubmittedAt.Equal(time.Unix(1700000100, 0)) {
+		t.Errorf("unexpected submission: %+v", first)
+	}
+}
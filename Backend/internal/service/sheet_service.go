@@ -6,6 +6,7 @@ import (
 	"dojo/internal/repository"
 	"dojo/internal/utils"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -37,11 +38,52 @@ func (s *SheetService) CreateSheet(userID string, req *dto.CreateSheetRequest) (
 		IsPublic:    req.IsPublic,
 	}
 
+	if len(req.Problems) == 0 {
+		if err := s.sheetRepo.Create(sheet); err != nil {
+			return nil, err
+		}
+		return s.mapSheetToResponse(sheet), nil
+	}
+
+	if len(req.Problems) > 100 {
+		return nil, fmt.Errorf("%w: a sheet can start with at most 100 problems", utils.ErrInvalidInput)
+	}
+
+	// Initial problems are created with the sheet, skipping duplicates
+	ids := make([]uuid.UUID, 0, len(req.Problems))
+	seen := make(map[uuid.UUID]bool)
+	for _, p := range req.Problems {
+		if seen[p.ProblemID] {
+			continue
+		}
+		seen[p.ProblemID] = true
+		ids = append(ids, p.ProblemID)
+		sheet.SheetProblems = append(sheet.SheetProblems, models.SheetProblem{
+			ProblemID: p.ProblemID,
+			Position:  len(sheet.SheetProblems) + 1,
+			Notes:     p.Notes,
+		})
+	}
+
+	problems, err := s.problemRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) != len(ids) {
+		return nil, utils.ErrProblemNotFound
+	}
+
 	if err := s.sheetRepo.Create(sheet); err != nil {
 		return nil, err
 	}
 
-	return s.mapSheetToResponse(sheet), nil
+	// Reload with problem data
+	sheet, err = s.sheetRepo.FindByID(sheet.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapSheetToResponseWithProblems(sheet), nil
 }
 
 // GetSheetByID retrieves a sheet by ID
@@ -59,7 +101,28 @@ func (s *SheetService) GetSheetByID(userID, sheetID string) (*dto.SheetResponse,
 		return nil, utils.ErrSheetAccessDenied
 	}
 
-	return s.mapSheetToResponseWithProblems(sheet), nil
+	response := s.mapSheetToResponseWithProblems(sheet)
+
+	// Mark the problems the viewer has solved, including ones imported from platforms
+	if len(sheet.SheetProblems) > 0 {
+		problemIDs := make([]uuid.UUID, len(sheet.SheetProblems))
+		for i, sp := range sheet.SheetProblems {
+			problemIDs[i] = sp.ProblemID
+		}
+		solved, err := s.problemRepo.FindSolvedProblemIDs(userID, problemIDs)
+		if err != nil {
+			return nil, err
+		}
+		solvedMap := make(map[uuid.UUID]bool, len(solved))
+		for _, id := range solved {
+			solvedMap[id] = true
+		}
+		for i := range response.Problems {
+			response.Problems[i].Problem.IsSolved = solvedMap[response.Problems[i].Problem.ID]
+		}
+	}
+
+	return response, nil
 }
 
 // GetUserSheets retrieves all sheets for a user
@@ -290,23 +353,8 @@ func (s *SheetService) mapSheetToResponseWithProblems(sheet *models.ProblemSheet
 // mapSheetProblemToResponse converts SheetProblem to SheetProblemResponse
 func (s *SheetService) mapSheetProblemToResponse(sp *models.SheetProblem) *dto.SheetProblemResponse {
 	return &dto.SheetProblemResponse{
-		ID: sp.ID,
-		Problem: dto.ProblemResponse{
-			ID:                sp.Problem.ID,
-			Platform:          sp.Problem.Platform,
-			PlatformProblemID: sp.Problem.PlatformProblemID,
-			Title:             sp.Problem.Title,
-			Slug:              sp.Problem.Slug,
-			Difficulty:        sp.Problem.Difficulty,
-			Tags:              []string(sp.Problem.Tags),
-			AcceptanceRate:    sp.Problem.AcceptanceRate,
-			ProblemURL:        sp.Problem.ProblemURL,
-			Description:       sp.Problem.Description,
-			Constraints:       sp.Problem.Constraints,
-			Examples:          sp.Problem.Examples,
-			Hints:             sp.Problem.Hints,
-			CreatedAt:         sp.Problem.CreatedAt,
-		},
+		ID:       sp.ID,
+		Problem:  *mapProblemToResponse(&sp.Problem),
 		Position: sp.Position,
 		IsSolved: sp.IsSolved,
 		Notes:    sp.Notes,
@@ -6,20 +6,23 @@ import (
 	"dojo/internal/repository"
 	"dojo/internal/utils"
 	"errors"
+	"log"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type SocialService struct {
-	socialRepo *repository.SocialRepository
-	userRepo   *repository.UserRepository
+	socialRepo          *repository.SocialRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
 }
 
-func NewSocialService(socialRepo *repository.SocialRepository, userRepo *repository.UserRepository) *SocialService {
+func NewSocialService(socialRepo *repository.SocialRepository, userRepo *repository.UserRepository, notificationService *NotificationService) *SocialService {
 	return &SocialService{
-		socialRepo: socialRepo,
-		userRepo:   userRepo,
+		socialRepo:          socialRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
 	}
 }
 
@@ -80,6 +83,11 @@ func (s *SocialService) SendFriendRequest(senderID string, req *dto.SendFriendRe
 		return nil, err
 	}
 
+	// Let the receiver know (a failed notification shouldn't fail the request)
+	if err := s.notificationService.NotifyFriendRequest(friendRequest); err != nil {
+		log.Printf("Warning: failed to notify user %s about friend request: %v", friendRequest.ReceiverID, err)
+	}
+
 	return s.mapFriendRequestToResponse(friendRequest), nil
 }
 
@@ -144,7 +152,17 @@ func (s *SocialService) RespondToFriendRequest(userID, requestID string, req *dt
 		friendRequest.Status = "rejected"
 	}
 
-	return s.socialRepo.UpdateFriendRequest(friendRequest)
+	if err := s.socialRepo.UpdateFriendRequest(friendRequest); err != nil {
+		return err
+	}
+
+	if friendRequest.Status == "accepted" {
+		if err := s.notificationService.NotifyFriendRequestAccepted(friendRequest); err != nil {
+			log.Printf("Warning: failed to notify user %s about accepted request: %v", friendRequest.SenderID, err)
+		}
+	}
+
+	return nil
 }
 
 // CancelFriendRequest cancels a sent friend request
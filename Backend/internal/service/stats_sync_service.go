@@ -1 +1,232 @@
 package service
+
+import (
+	"context"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/service/scrapper"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// statsSyncLogRetentionDays is how long sync history is kept
+const statsSyncLogRetentionDays = 30
+
+// defaultPlatformRequestInterval is the minimum delay between two requests to the same platform
+const defaultPlatformRequestInterval = 2 * time.Second
+
+// platformRequestIntervals overrides the request interval for slower platforms
+var platformRequestIntervals = map[string]time.Duration{
+	"codechef": 3 * time.Second,
+	"gfg":      3 * time.Second,
+}
+
+// platformLimiter spaces out requests to a single platform
+type platformLimiter struct {
+	mu       sync.Mutex
+	next     time.Time
+	interval time.Duration
+}
+
+// Wait blocks until the next request slot is free or ctx is done, returning ctx's error then
+func (l *platformLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// statsSyncJob is one user handle to refresh
+type statsSyncJob struct {
+	userID   string
+	platform string
+	username string
+}
+
+// StatsSyncService periodically refreshes the platform stats of every linked handle
+type StatsSyncService struct {
+	userService *UserService
+	userRepo    *repository.UserRepository
+	workers     int
+	limiters    map[string]*platformLimiter
+	ticker      *time.Ticker
+	ctx         context.Context // cancelled on Stop to end the loop and abort in-flight requests
+	cancel      context.CancelFunc
+	running     sync.WaitGroup // the sync loop, waited for on Stop
+}
+
+// NewStatsSyncService creates a new stats sync service running the given number of workers
+func NewStatsSyncService(userService *UserService, userRepo *repository.UserRepository, workers int) *StatsSyncService {
+	if workers < 1 {
+		workers = 1
+	}
+
+	limiters := make(map[string]*platformLimiter)
+	for _, platform := range scrapper.Names() {
+		interval, ok := platformRequestIntervals[platform]
+		if !ok {
+			interval = defaultPlatformRequestInterval
+		}
+		limiters[platform] = &platformLimiter{interval: interval}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &StatsSyncService{
+		ctx:         ctx,
+		cancel:      cancel,
+		userService: userService,
+		userRepo:    userRepo,
+		workers:     workers,
+		limiters:    limiters,
+	}
+}
+
+// Start begins the periodic stats synchronization
+func (s *StatsSyncService) Start(interval time.Duration) {
+	log.Println("Starting stats sync service...")
+
+	s.syncAll(interval)
+
+	s.ticker = time.NewTicker(interval)
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		for {
+			select {
+			case <-s.ticker.C:
+				s.syncAll(interval)
+			case <-s.ctx.Done():
+				s.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic synchronization, aborting a running sync, and returns once it has
+// finished so the database can be closed
+func (s *StatsSyncService) Stop() {
+	log.Println("Stopping stats sync service...")
+	s.cancel()
+	s.running.Wait()
+}
+
+// syncAll refreshes every linked handle not synced within the last half interval
+func (s *StatsSyncService) syncAll(interval time.Duration) {
+	jobs, err := s.collectJobs(time.Now().Add(-interval / 2))
+	if err != nil {
+		log.Printf("Error collecting stats sync jobs: %v\n", err)
+		return
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	log.Printf("Syncing platform stats for %d handles...\n", len(jobs))
+
+	jobChan := make(chan statsSyncJob)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failed := 0
+
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobChan {
+				if !s.syncJob(job) {
+					mu.Lock()
+					failed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		if s.ctx.Err() != nil {
+			break
+		}
+		jobChan <- job
+	}
+	close(jobChan)
+	wg.Wait()
+
+	log.Printf("Stats sync finished: %d handles, %d failed\n", len(jobs), failed)
+
+	if deleted, err := s.userRepo.DeleteOldSyncLogs(statsSyncLogRetentionDays); err != nil {
+		log.Printf("Warning: Failed to delete old sync logs: %v\n", err)
+	} else if deleted > 0 {
+		log.Printf("Cleaned up %d old sync logs\n", deleted)
+	}
+}
+
+// collectJobs lists linked handles, skipping those refreshed after freshSince (e.g. by a manual sync)
+func (s *StatsSyncService) collectJobs(freshSince time.Time) ([]statsSyncJob, error) {
+	profiles, err := s.userRepo.FindLinkedProfiles(scrapper.Names())
+	if err != nil {
+		return nil, err
+	}
+
+	fresh, err := s.userRepo.FindPlatformStatsSyncedSince(freshSince)
+	if err != nil {
+		return nil, err
+	}
+	isFresh := make(map[string]bool, len(fresh))
+	for _, stat := range fresh {
+		isFresh[stat.UserID.String()+":"+stat.Platform] = true
+	}
+
+	var jobs []statsSyncJob
+	for i := range profiles {
+		userID := profiles[i].UserID.String()
+		for platform := range s.limiters {
+			username := profiles[i].PlatformUsername(platform)
+			if username == "" || isFresh[userID+":"+platform] {
+				continue
+			}
+			jobs = append(jobs, statsSyncJob{userID: userID, platform: platform, username: username})
+		}
+	}
+
+	// Shuffle so that one platform's requests are spread over the whole run
+	rand.Shuffle(len(jobs), func(i, j int) { jobs[i], jobs[j] = jobs[j], jobs[i] })
+	return jobs, nil
+}
+
+// syncJob refreshes one handle. Transient failures are already retried by the scrapper HTTP
+// client, and the rest (e.g. an unknown handle) wouldn't succeed on a retry, so a failed job
+// waits for the next cycle. Returns false if the sync failed.
+func (s *StatsSyncService) syncJob(job statsSyncJob) bool {
+	started := time.Now()
+
+	if err := s.limiters[job.platform].Wait(s.ctx); err != nil {
+		return false
+	}
+	status, err := s.userService.FetchAndSavePlatformStats(s.ctx, job.userID, job.platform, job.username)
+
+	s.userService.RecordSyncResult(job.userID, job.platform, job.username, SyncTriggerScheduled, status, err, time.Since(started))
+
+	if status == models.SyncStatusFailed {
+		log.Printf("Warning: Failed to sync %s stats for user %s: %v\n", job.platform, job.userID, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPlatformLimiterWait(t *testing.T) {
+	limiter := &platformLimiter{interval: time.Hour}
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("first request waited: %v", err)
+	}
+
+	// The next slot is an hour away; cancelling must not wait for it
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	started := time.Now()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait = %v, want context.Canceled", err)
+	}
+	if waited := time.Since(started); waited > time.Second {
+		t.Errorf("Wait returned after %s, want right after the cancel", waited)
+	}
+}
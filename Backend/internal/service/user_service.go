@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"dojo/internal/dto"
 	"dojo/internal/models"
 	"dojo/internal/repository"
@@ -8,18 +9,26 @@ import (
 	"dojo/internal/utils"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
-	userRepo *repository.UserRepository
+	userRepo       *repository.UserRepository
+	contestRepo    *repository.ContestRepository
+	problemRepo    *repository.ProblemRepository
+	submissionRepo *repository.SubmissionRepository
 }
 
-func NewUserService(userRepo *repository.UserRepository) *UserService {
+func NewUserService(userRepo *repository.UserRepository, contestRepo *repository.ContestRepository, problemRepo *repository.ProblemRepository, submissionRepo *repository.SubmissionRepository) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		contestRepo:    contestRepo,
+		problemRepo:    problemRepo,
+		submissionRepo: submissionRepo,
 	}
 }
 
@@ -72,6 +81,7 @@ func (s *UserService) UpdateProfile(userID string, req *dto.UpdateProfileRequest
 	profile.CodeforcesUsername = req.CodeforcesUsername
 	profile.CodechefUsername = req.CodechefUsername
 	profile.GFGUsername = req.GFGUsername
+	profile.AtcoderUsername = req.AtcoderUsername
 
 	// Update in database
 	if err := s.userRepo.UpdateProfile(profile); err != nil {
@@ -166,6 +176,12 @@ func (s *UserService) ChangePassword(userID string, req *dto.ChangePasswordReque
 	return nil
 }
 
+// Sync triggers recorded in PlatformSyncLog.Trigger
+const (
+	SyncTriggerManual    = "manual"
+	SyncTriggerScheduled = "scheduled"
+)
+
 // SyncPlatformStats syncs platform statistics for the user from external platforms
 func (s *UserService) SyncPlatformStats(userID string, platforms []string) (map[string]interface{}, error) {
 	user, err := s.userRepo.FindByID(userID)
@@ -190,117 +206,408 @@ func (s *UserService) SyncPlatformStats(userID string, platforms []string) (map[
 	fmt.Printf("DEBUG: Starting sync for platforms: %v\n", platforms)
 
 	for _, platform := range platforms {
-		switch platform {
-		case "leetcode":
-			if user.Profile.LeetcodeUsername != "" {
-				fmt.Printf("DEBUG: Fetching LeetCode stats for username: %s\n", user.Profile.LeetcodeUsername)
-				stats, err := scrapper.FetchLeetCodeStats(user.Profile.LeetcodeUsername)
-				if err != nil {
-					fmt.Printf("DEBUG: LeetCode fetch error: %v\n", err)
-					results[platform] = map[string]string{"error": err.Error()}
-					continue
-				}
-				fmt.Printf("DEBUG: LeetCode stats fetched: Rank=%d, Solved=%d, Easy=%d, Medium=%d, Hard=%d\n",
-					stats.GlobalRank, stats.ProblemsSolved, stats.EasyProblemsSolved, stats.MedProblemsSolved, stats.HardProblemsSolved)
-				// Save or update stats in database
-				if err := s.savePlatformStats(userID, platform, stats); err != nil {
-					fmt.Printf("DEBUG: Failed to save LeetCode stats: %v\n", err)
-					results[platform] = map[string]string{"error": err.Error()}
-					continue
-				}
-				fmt.Printf("DEBUG: LeetCode stats saved successfully\n")
-				results[platform] = map[string]string{"status": "success", "message": "Stats synced successfully"}
-			} else {
-				results[platform] = map[string]string{"error": "LeetCode username not set"}
-			}
+		p, supported := scrapper.Get(platform)
+		if !supported {
+			results[platform] = map[string]string{"error": "unsupported platform"}
+			continue
+		}
 
-		case "codeforces":
-			if user.Profile.CodeforcesUsername != "" {
-				stats, err := scrapper.FetchCodeforcesStats(user.Profile.CodeforcesUsername)
-				if err != nil {
-					// Even on error, try to save partial stats if available
-					if stats != nil && (stats.Rating > 0 || stats.ProblemsSolved > 0) {
-						if saveErr := s.savePlatformStats(userID, platform, stats); saveErr == nil {
-							results[platform] = map[string]string{
-								"status":  "partial",
-								"warning": err.Error(),
-								"message": "Saved available stats. Full sync failed.",
-							}
-							continue
-						}
-					}
-					results[platform] = map[string]string{"error": err.Error()}
-					continue
-				}
-				if err := s.savePlatformStats(userID, platform, stats); err != nil {
-					results[platform] = map[string]string{"error": "Failed to save stats: " + err.Error()}
-					continue
-				}
-				results[platform] = map[string]string{"status": "success", "message": "Stats synced successfully"}
-			} else {
-				results[platform] = map[string]string{"error": "Codeforces username not set"}
-			}
+		username := user.Profile.PlatformUsername(platform)
+		if username == "" {
+			results[platform] = map[string]string{"error": p.DisplayName() + " username not set"}
+			continue
+		}
 
-		case "codechef":
-			if user.Profile.CodechefUsername != "" {
-				stats, err := scrapper.FetchCodeChefStats(user.Profile.CodechefUsername)
-				if err != nil {
-					// Even on error, try to save partial stats if available
-					if stats != nil && (stats.Rating > 0 || stats.ProblemsSolved > 0) {
-						if saveErr := s.savePlatformStats(userID, platform, stats); saveErr == nil {
-							results[platform] = map[string]string{
-								"status":  "partial",
-								"warning": err.Error(),
-								"message": "Saved available stats. Full sync failed.",
-							}
-							continue
-						}
-					}
-					results[platform] = map[string]string{"error": err.Error()}
-					continue
-				}
-				if err := s.savePlatformStats(userID, platform, stats); err != nil {
-					results[platform] = map[string]string{"error": "Failed to save stats: " + err.Error()}
-					continue
-				}
-				results[platform] = map[string]string{"status": "success", "message": "Stats synced successfully"}
-			} else {
-				results[platform] = map[string]string{"error": "CodeChef username not set"}
+		started := time.Now()
+		status, err := s.FetchAndSavePlatformStats(context.Background(), userID, platform, username)
+		s.RecordSyncResult(userID, platform, username, SyncTriggerManual, status, err, time.Since(started))
+
+		switch status {
+		case models.SyncStatusSuccess:
+			results[platform] = map[string]string{"status": "success", "message": "Stats synced successfully"}
+		case models.SyncStatusPartial:
+			results[platform] = map[string]string{
+				"status":  "partial",
+				"warning": err.Error(),
+				"message": "Saved available stats. Full sync failed.",
 			}
+		default:
+			results[platform] = map[string]string{"error": err.Error()}
+		}
+	}
 
-		case "gfg":
-			if user.Profile.GFGUsername != "" {
-				stats, err := scrapper.FetchGFGStats(user.Profile.GFGUsername)
-				if err != nil {
-					// Even on error, try to save partial stats if available
-					if stats != nil && (stats.Rating > 0 || stats.ProblemsSolved > 0) {
-						if saveErr := s.savePlatformStats(userID, platform, stats); saveErr == nil {
-							results[platform] = map[string]string{
-								"status":  "partial",
-								"warning": err.Error(),
-								"message": "Saved available stats. Full sync failed.",
-							}
-							continue
-						}
-					}
-					results[platform] = map[string]string{"error": err.Error()}
-					continue
-				}
-				if err := s.savePlatformStats(userID, platform, stats); err != nil {
-					results[platform] = map[string]string{"error": "Failed to save stats: " + err.Error()}
-					continue
-				}
-				results[platform] = map[string]string{"status": "success", "message": "Stats synced successfully"}
-			} else {
-				results[platform] = map[string]string{"error": "GFG username not set"}
+	return results, nil
+}
+
+// FetchAndSavePlatformStats fetches a user's stats from a platform and stores them.
+// If the fetch fails but returned usable stats, they are saved and the status is partial.
+func (s *UserService) FetchAndSavePlatformStats(ctx context.Context, userID, platform, username string) (string, error) {
+	p, ok := scrapper.Get(platform)
+	if !ok {
+		return models.SyncStatusFailed, fmt.Errorf("unsupported platform: %s", platform)
+	}
+
+	stats, err := p.FetchStats(ctx, username)
+	if err != nil {
+		// Even on error, try to save partial stats if available
+		if stats != nil && (stats.Rating > 0 || stats.ProblemsSolved > 0) {
+			if saveErr := s.savePlatformStats(userID, platform, stats); saveErr == nil {
+				return models.SyncStatusPartial, err
 			}
+		}
+		return models.SyncStatusFailed, err
+	}
 
-		default:
-			results[platform] = map[string]string{"error": "unsupported platform"}
+	if err := s.savePlatformStats(userID, platform, stats); err != nil {
+		return models.SyncStatusFailed, fmt.Errorf("failed to save stats: %w", err)
+	}
+
+	// Contest results and solved problems are best effort and don't affect the sync status
+	if err := s.syncContestHistory(ctx, userID, p, username); err != nil {
+		fmt.Printf("Warning: Failed to sync %s contest history for user %s: %v\n", platform, userID, err)
+	}
+	if err := s.syncSolvedProblems(ctx, userID, p, username); err != nil {
+		fmt.Printf("Warning: Failed to import %s solved problems for user %s: %v\n", platform, userID, err)
+	}
+	return models.SyncStatusSuccess, nil
+}
+
+// syncSolvedProblems imports the user's accepted submissions on a platform made since the last
+// import, creating the problems that aren't stored yet where the platform ID allows it.
+// Submissions to problems that can't be created are kept pending and retried on every sync,
+// since the next import starts after them. Progress is recomputed from the submissions, so
+// solved_at is the time of the first accepted submission.
+func (s *UserService) syncSolvedProblems(ctx context.Context, userID string, p scrapper.Platform, username string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	latest, err := s.submissionRepo.FindLatestImport(userID, p.Name())
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		// Overlap a little; already imported submissions are skipped by ID
+		since = latest.Add(-time.Hour)
+	}
+
+	accepted, err := p.FetchAcceptedSubmissions(ctx, username, since)
+	if err != nil {
+		if errors.Is(err, scrapper.ErrNotSupported) {
+			return nil
 		}
+		return err
 	}
 
-	return results, nil
+	pending, err := s.submissionRepo.FindPending(userID, p.Name())
+	if err != nil {
+		return err
+	}
+	for _, submission := range pending {
+		accepted = append(accepted, scrapper.AcceptedSubmission{
+			Problem:      scrapper.ProblemInfo{Platform: p.Name(), Slug: submission.ProblemSlug},
+			SubmissionID: submission.ExternalID,
+			Language:     submission.Language,
+			SubmittedAt:  submission.SubmittedAt,
+		})
+	}
+	if len(accepted) == 0 {
+		return nil
+	}
+
+	problemIDs, err := s.findOrCreateProblems(p.Name(), accepted)
+	if err != nil {
+		return err
+	}
+
+	submissions := make([]models.ProblemSubmission, 0, len(accepted))
+	var unmatched []models.PendingSubmission
+	for _, submission := range accepted {
+		language := submission.Language
+		if len(language) > 50 {
+			language = language[:50]
+		}
+		problemID, ok := problemIDs[acceptedProblemKey(submission.Problem)]
+		if !ok {
+			unmatched = append(unmatched, models.PendingSubmission{
+				UserID:      userUUID,
+				Source:      p.Name(),
+				ExternalID:  submission.SubmissionID,
+				ProblemSlug: submission.Problem.Slug,
+				Language:    language,
+				SubmittedAt: submission.SubmittedAt,
+			})
+			continue
+		}
+		submissions = append(submissions, models.ProblemSubmission{
+			ProblemID:   problemID,
+			Verdict:     models.VerdictAccepted,
+			Language:    language,
+			SubmittedAt: submission.SubmittedAt,
+			ExternalID:  submission.SubmissionID,
+		})
+	}
+	if len(unmatched) > 0 {
+		if err := s.submissionRepo.SavePending(unmatched); err != nil {
+			return fmt.Errorf("failed to keep submissions pending: %w", err)
+		}
+		fmt.Printf("Warning: %d %s submissions for user %s are pending until their problems are synced\n", len(unmatched), p.DisplayName(), userID)
+	}
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	imported, err := s.submissionRepo.ImportAndSummarize(userUUID, p.Name(), submissions)
+	if err != nil {
+		return err
+	}
+	if imported > 0 {
+		fmt.Printf("Imported %d %s accepted submissions for user %s\n", imported, p.DisplayName(), userID)
+	}
+	return nil
+}
+
+// acceptedProblemKey identifies a submitted problem by its platform ID, or by its slug when the
+// platform's submissions don't carry the ID (LeetCode)
+func acceptedProblemKey(info scrapper.ProblemInfo) string {
+	if info.PlatformProblemID != "" {
+		return info.PlatformProblemID
+	}
+	return "slug:" + info.Slug
+}
+
+// findOrCreateProblems maps the submitted problems to problem IDs, keyed by acceptedProblemKey.
+// Problems identified by platform ID are stored if they aren't in the catalogue yet; problems
+// identified only by slug can't be stored without their ID and are left out.
+func (s *UserService) findOrCreateProblems(platform string, submissions []scrapper.AcceptedSubmission) (map[string]uuid.UUID, error) {
+	infos := make(map[string]scrapper.ProblemInfo)
+	var platformIDs, slugs []string
+	for _, submission := range submissions {
+		key := acceptedProblemKey(submission.Problem)
+		if _, ok := infos[key]; ok {
+			continue
+		}
+		infos[key] = submission.Problem
+		if submission.Problem.PlatformProblemID != "" {
+			platformIDs = append(platformIDs, submission.Problem.PlatformProblemID)
+		} else {
+			slugs = append(slugs, submission.Problem.Slug)
+		}
+	}
+
+	ids := make(map[string]uuid.UUID, len(infos))
+	if len(slugs) > 0 {
+		existing, err := s.problemRepo.FindByPlatformSlugs(platform, slugs)
+		if err != nil {
+			return nil, err
+		}
+		for _, problem := range existing {
+			ids["slug:"+problem.Slug] = problem.ID
+		}
+	}
+	if len(platformIDs) == 0 {
+		return ids, nil
+	}
+
+	existing, err := s.problemRepo.FindByPlatformIDs(platform, platformIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range existing {
+		ids[problem.PlatformProblemID] = problem.ID
+	}
+
+	var missing []models.Problem
+	var missingIDs []string
+	for _, platformID := range platformIDs {
+		if _, ok := ids[platformID]; ok {
+			continue
+		}
+		info := infos[platformID]
+		missing = append(missing, models.Problem{
+			Platform:          info.Platform,
+			PlatformProblemID: info.PlatformProblemID,
+			Title:             info.Title,
+			Slug:              info.Slug,
+			Difficulty:        info.Difficulty,
+			Rating:            info.Rating,
+			Tags:              pq.StringArray(info.Tags),
+			AcceptanceRate:    info.AcceptanceRate,
+			ProblemURL:        info.ProblemURL,
+		})
+		missingIDs = append(missingIDs, platformID)
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	// Another import may store the same problems meanwhile, so read back whichever row won
+	if err := s.problemRepo.CreateMissing(missing); err != nil {
+		return nil, fmt.Errorf("failed to store problems: %w", err)
+	}
+	created, err := s.problemRepo.FindByPlatformIDs(platform, missingIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range created {
+		ids[problem.PlatformProblemID] = problem.ID
+	}
+	return ids, nil
+}
+
+// syncContestHistory stores the user's results in past contests on a platform, creating the
+// contests that aren't stored yet
+func (s *UserService) syncContestHistory(ctx context.Context, userID string, p scrapper.Platform, username string) error {
+	results, err := p.FetchContestHistory(ctx, username)
+	if err != nil {
+		if errors.Is(err, scrapper.ErrNotSupported) {
+			return nil
+		}
+		return err
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		contest := &models.Contest{
+			Platform:          result.Platform,
+			PlatformContestID: result.PlatformContestID,
+			Name:              result.ContestName,
+			StartTime:         result.StartTime,
+			DurationSeconds:   result.Duration,
+			ContestURL:        result.ContestURL,
+		}
+		if err := s.contestRepo.FindOrCreateContest(contest); err != nil {
+			return fmt.Errorf("failed to store contest '%s': %w", result.ContestName, err)
+		}
+
+		participation := &models.ContestParticipation{
+			UserID:         userUUID,
+			ContestID:      contest.ID,
+			Platform:       result.Platform,
+			Rank:           result.Rank,
+			OldRating:      result.OldRating,
+			NewRating:      result.NewRating,
+			RatingChange:   result.NewRating - result.OldRating,
+			ProblemsSolved: result.ProblemsSolved,
+			TotalProblems:  result.TotalProblems,
+		}
+		if err := s.contestRepo.UpsertParticipation(participation); err != nil {
+			return fmt.Errorf("failed to store result for contest '%s': %w", result.ContestName, err)
+		}
+	}
+	return nil
+}
+
+// RecordSyncResult stores the outcome of a sync in the user's sync history
+func (s *UserService) RecordSyncResult(userID, platform, username, trigger, status string, syncErr error, duration time.Duration) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+
+	syncLog := &models.PlatformSyncLog{
+		UserID:     userUUID,
+		Platform:   platform,
+		Username:   username,
+		Trigger:    trigger,
+		Status:     status,
+		DurationMs: duration.Milliseconds(),
+	}
+	if syncErr != nil {
+		syncLog.Error = syncErr.Error()
+	}
+
+	if err := s.userRepo.CreateSyncLog(syncLog); err != nil {
+		fmt.Printf("Warning: Failed to record %s sync for user %s: %v\n", platform, userID, err)
+	}
+}
+
+// GetSyncStatus returns the sync state of each linked platform and the recent sync history
+func (s *UserService) GetSyncStatus(userID, platform string, limit int) (*dto.SyncStatusResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if err := s.userRepo.LoadProfile(user); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.LoadPlatformStats(user); err != nil {
+		return nil, err
+	}
+
+	latestLogs, err := s.userRepo.FindLatestSyncLogs(userID)
+	if err != nil {
+		return nil, err
+	}
+	latestByPlatform := make(map[string]models.PlatformSyncLog, len(latestLogs))
+	for _, l := range latestLogs {
+		latestByPlatform[l.Platform] = l
+	}
+	statsByPlatform := make(map[string]models.UserPlatformStat, len(user.PlatformStats))
+	for _, stat := range user.PlatformStats {
+		statsByPlatform[stat.Platform] = stat
+	}
+
+	response := &dto.SyncStatusResponse{
+		Platforms: []dto.PlatformSyncStatusResponse{},
+		History:   []dto.PlatformSyncLogResponse{},
+	}
+
+	for _, p := range scrapper.Names() {
+		if platform != "" && p != platform {
+			continue
+		}
+		username := ""
+		if user.Profile != nil {
+			username = user.Profile.PlatformUsername(p)
+		}
+		stat, hasStat := statsByPlatform[p]
+		if username == "" && !hasStat {
+			continue
+		}
+
+		status := dto.PlatformSyncStatusResponse{
+			Platform: p,
+			Username: username,
+		}
+		if hasStat {
+			lastSynced := stat.LastSynced
+			status.LastSyncedAt = &lastSynced
+		}
+		if l, ok := latestByPlatform[p]; ok {
+			attemptAt := l.CreatedAt
+			status.LastStatus = l.Status
+			status.LastError = l.Error
+			status.LastAttemptAt = &attemptAt
+		}
+		response.Platforms = append(response.Platforms, status)
+	}
+
+	logs, err := s.userRepo.FindSyncLogs(userID, platform, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range logs {
+		response.History = append(response.History, dto.PlatformSyncLogResponse{
+			Platform:   l.Platform,
+			Trigger:    l.Trigger,
+			Status:     l.Status,
+			Error:      l.Error,
+			DurationMs: l.DurationMs,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+
+	return response, nil
 }
 
 // savePlatformStats saves or updates platform statistics
@@ -324,7 +631,73 @@ func (s *UserService) savePlatformStats(userID string, platform string, stats *s
 		GlobalRank:         stats.GlobalRank,
 	}
 
-	return s.userRepo.UpsertPlatformStat(platformStat)
+	if err := s.userRepo.UpsertPlatformStat(platformStat); err != nil {
+		return err
+	}
+
+	// Keep history for progress charts
+	snapshot := &models.PlatformStatSnapshot{
+		UserID:             userUUID,
+		Platform:           platform,
+		Rating:             stats.Rating,
+		MaxRating:          stats.MaxRating,
+		ProblemsSolved:     stats.ProblemsSolved,
+		EasyProblemsSolved: stats.EasyProblemsSolved,
+		MedProblemsSolved:  stats.MedProblemsSolved,
+		HardProblemsSolved: stats.HardProblemsSolved,
+		ContestsAttended:   stats.ContestsAttended,
+		GlobalRank:         stats.GlobalRank,
+		RecordedAt:         time.Now(),
+	}
+	if err := s.userRepo.CreateStatSnapshot(snapshot); err != nil {
+		fmt.Printf("Warning: Failed to store %s stat snapshot for user %s: %v\n", platform, userID, err)
+	}
+	return nil
+}
+
+// statHistoryBuckets maps the API interval names to date_trunc units
+var statHistoryBuckets = map[string]string{
+	"daily":   "day",
+	"weekly":  "week",
+	"monthly": "month",
+}
+
+// GetStatHistory returns a user's stats over time, one point per platform and interval
+func (s *UserService) GetStatHistory(userID string, req *dto.StatHistoryRequest) ([]dto.StatHistorySeriesResponse, error) {
+	bucket, ok := statHistoryBuckets[req.Interval]
+	if !ok {
+		return nil, fmt.Errorf("invalid interval: %s", req.Interval)
+	}
+
+	snapshots, err := s.userRepo.FindStatHistory(userID, req.Platform, bucket, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	series := []dto.StatHistorySeriesResponse{}
+	for _, snapshot := range snapshots {
+		// Snapshots come ordered by platform, then time
+		if len(series) == 0 || series[len(series)-1].Platform != snapshot.Platform {
+			series = append(series, dto.StatHistorySeriesResponse{
+				Platform: snapshot.Platform,
+				Points:   []dto.StatHistoryPointResponse{},
+			})
+		}
+		current := &series[len(series)-1]
+		current.Points = append(current.Points, dto.StatHistoryPointResponse{
+			Date:             snapshot.RecordedAt,
+			Rating:           snapshot.Rating,
+			MaxRating:        snapshot.MaxRating,
+			SolvedCount:      snapshot.ProblemsSolved,
+			EasySolved:       snapshot.EasyProblemsSolved,
+			MediumSolved:     snapshot.MedProblemsSolved,
+			HardSolved:       snapshot.HardProblemsSolved,
+			ContestsAttended: snapshot.ContestsAttended,
+			GlobalRank:       snapshot.GlobalRank,
+		})
+	}
+
+	return series, nil
 }
 
 // mapUserToResponse converts User model to UserResponse DTO
@@ -347,6 +720,7 @@ func (s *UserService) mapUserToResponse(user *models.User) *dto.UserResponse {
 			CodeforcesUsername: user.Profile.CodeforcesUsername,
 			CodechefUsername:   user.Profile.CodechefUsername,
 			GFGUsername:        user.Profile.GFGUsername,
+			AtcoderUsername:    user.Profile.AtcoderUsername,
 			TotalSolved:        user.Profile.TotalSolved,
 			EasySolved:         user.Profile.EasySolved,
 			MediumSolved:       user.Profile.MediumSolved,
@@ -0,0 +1,516 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"dojo/internal/repository"
+	"dojo/internal/utils"
+	"dojo/internal/websocket"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// wrongAttemptPenaltyMinutes is the ICPC penalty for each wrong attempt at a problem that
+// was eventually solved
+const wrongAttemptPenaltyMinutes = 20
+
+// maxVirtualContestProblems keeps problem labels within A-Z
+const maxVirtualContestProblems = 26
+
+// RoomBroadcaster pushes server events to the clients connected to a room
+type RoomBroadcaster interface {
+	BroadcastToRoom(roomID uuid.UUID, messageType websocket.MessageType, data interface{}) error
+}
+
+type VirtualContestService struct {
+	virtualContestRepo  *repository.VirtualContestRepository
+	problemRepo         *repository.ProblemRepository
+	contestRepo         *repository.ContestRepository
+	socialRepo          *repository.SocialRepository
+	roomRepo            *repository.RoomRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
+	broadcaster         RoomBroadcaster
+}
+
+func NewVirtualContestService(
+	virtualContestRepo *repository.VirtualContestRepository,
+	problemRepo *repository.ProblemRepository,
+	contestRepo *repository.ContestRepository,
+	socialRepo *repository.SocialRepository,
+	roomRepo *repository.RoomRepository,
+	userRepo *repository.UserRepository,
+	notificationService *NotificationService,
+	broadcaster RoomBroadcaster,
+) *VirtualContestService {
+	return &VirtualContestService{
+		virtualContestRepo:  virtualContestRepo,
+		problemRepo:         problemRepo,
+		contestRepo:         contestRepo,
+		socialRepo:          socialRepo,
+		roomRepo:            roomRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		broadcaster:         broadcaster,
+	}
+}
+
+// CreateVirtualContest creates a virtual contest from a set of problems or a past contest and
+// invites the creator's friends. The timer starts when the creator starts the contest.
+func (s *VirtualContestService) CreateVirtualContest(userID string, req *dto.CreateVirtualContestRequest) (*dto.VirtualContestResponse, error) {
+	creatorID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	creator, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	problems, err := s.resolveProblems(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only friends can be invited
+	invitees := make([]uuid.UUID, 0, len(req.InviteUserIDs))
+	seen := map[uuid.UUID]bool{creatorID: true}
+	for _, inviteeID := range req.InviteUserIDs {
+		if seen[inviteeID] {
+			continue
+		}
+		seen[inviteeID] = true
+
+		areFriends, err := s.socialRepo.AreFriends(userID, inviteeID.String())
+		if err != nil {
+			return nil, err
+		}
+		if !areFriends {
+			return nil, fmt.Errorf("%w: user %s is not your friend", utils.ErrInvalidInput, inviteeID)
+		}
+		invitees = append(invitees, inviteeID)
+	}
+
+	roomCode, err := s.roomRepo.GenerateUniqueRoomCode()
+	if err != nil {
+		return nil, errors.New("failed to generate room code")
+	}
+	room := &models.Room{
+		Name:            req.Name,
+		RoomCode:        roomCode,
+		CreatedBy:       &creatorID,
+		MaxParticipants: len(invitees) + 1,
+		IsActive:        true,
+	}
+
+	now := time.Now()
+	contest := &models.VirtualContest{
+		Name:            req.Name,
+		CreatedBy:       creatorID,
+		SourceContestID: req.SourceContestID,
+		DurationMinutes: req.DurationMinutes,
+		Participants: []models.VirtualContestParticipant{
+			{UserID: creatorID, Status: models.VirtualParticipantJoined, JoinedAt: &now},
+		},
+	}
+	for i, problem := range problems {
+		contest.Problems = append(contest.Problems, models.VirtualContestProblem{ProblemID: problem.ID, Position: i})
+	}
+	for _, inviteeID := range invitees {
+		contest.Participants = append(contest.Participants, models.VirtualContestParticipant{
+			UserID: inviteeID,
+			Status: models.VirtualParticipantInvited,
+		})
+	}
+
+	if err := s.virtualContestRepo.Create(contest, room); err != nil {
+		return nil, err
+	}
+
+	for _, inviteeID := range invitees {
+		if err := s.notificationService.NotifyVirtualContestInvite(contest, inviteeID, creator.Username); err != nil {
+			fmt.Printf("Warning: failed to notify %s about virtual contest %s: %v\n", inviteeID, contest.ID, err)
+		}
+	}
+
+	return s.GetVirtualContest(contest.ID.String(), userID)
+}
+
+// resolveProblems returns the requested problems in order, or the problems of the source contest
+func (s *VirtualContestService) resolveProblems(req *dto.CreateVirtualContestRequest) ([]models.Problem, error) {
+	if req.SourceContestID != nil {
+		return s.findContestProblems(req.SourceContestID.String())
+	}
+	if len(req.ProblemIDs) == 0 {
+		return nil, fmt.Errorf("%w: problem_ids or source_contest_id is required", utils.ErrInvalidInput)
+	}
+
+	found, err := s.problemRepo.FindByIDs(req.ProblemIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]models.Problem, len(found))
+	for _, problem := range found {
+		byID[problem.ID] = problem
+	}
+
+	problems := make([]models.Problem, 0, len(req.ProblemIDs))
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range req.ProblemIDs {
+		problem, ok := byID[id]
+		if !ok {
+			return nil, utils.ErrProblemNotFound
+		}
+		if !seen[id] {
+			seen[id] = true
+			problems = append(problems, problem)
+		}
+	}
+	return problems, nil
+}
+
+// findContestProblems looks up the imported problems of a past Codeforces or AtCoder contest
+func (s *VirtualContestService) findContestProblems(contestID string) ([]models.Problem, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrContestNotFound
+		}
+		return nil, err
+	}
+	if contest.StartTime.After(time.Now()) {
+		return nil, fmt.Errorf("%w: contest %s has not happened yet", utils.ErrInvalidInput, contest.Name)
+	}
+
+	// Problem IDs are prefixed with the contest ID: 1900A on Codeforces, abc300_a on AtCoder
+	var pattern string
+	switch contest.Platform {
+	case "codeforces":
+		pattern = "^" + regexp.QuoteMeta(contest.PlatformContestID) + "[A-Za-z]"
+	case "atcoder":
+		pattern = "^" + regexp.QuoteMeta(contest.PlatformContestID) + "_"
+	default:
+		return nil, fmt.Errorf("%w: virtual contests can't be created from %s contests", utils.ErrInvalidInput, contest.Platform)
+	}
+
+	problems, err := s.problemRepo.FindByPlatformIDPattern(contest.Platform, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) == 0 {
+		return nil, fmt.Errorf("%w: no problems imported for %s, sync %s problems first", utils.ErrInvalidInput, contest.Name, contest.Platform)
+	}
+	if len(problems) > maxVirtualContestProblems {
+		problems = problems[:maxVirtualContestProblems]
+	}
+	return problems, nil
+}
+
+// ListVirtualContests retrieves the virtual contests a user created or was invited to
+func (s *VirtualContestService) ListVirtualContests(userID string) ([]dto.VirtualContestResponse, error) {
+	contests, err := s.virtualContestRepo.FindUserContests(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]dto.VirtualContestResponse, len(contests))
+	for i := range contests {
+		responses[i] = *s.mapVirtualContestToResponse(&contests[i], now)
+	}
+	return responses, nil
+}
+
+// GetVirtualContest retrieves a virtual contest the user was invited to
+func (s *VirtualContestService) GetVirtualContest(id, userID string) (*dto.VirtualContestResponse, error) {
+	contest, err := s.findForParticipant(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapVirtualContestToResponse(contest, time.Now()), nil
+}
+
+// JoinVirtualContest accepts an invitation, adding the user to the contest room
+func (s *VirtualContestService) JoinVirtualContest(id, userID string) (*dto.VirtualContestResponse, error) {
+	contest, err := s.findForParticipant(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if virtualContestStatus(contest, now) == dto.VirtualContestFinished {
+		return nil, fmt.Errorf("%w: virtual contest has finished", utils.ErrInvalidInput)
+	}
+
+	if err := s.virtualContestRepo.JoinParticipant(contest, userID, now); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrAlreadyInRoom
+		}
+		return nil, err
+	}
+
+	return s.GetVirtualContest(id, userID)
+}
+
+// StartVirtualContest starts the shared timer. Only the creator can start a contest.
+func (s *VirtualContestService) StartVirtualContest(id, userID string) (*dto.VirtualContestResponse, error) {
+	contest, err := s.findForParticipant(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if contest.CreatedBy.String() != userID {
+		return nil, utils.ErrUnauthorized
+	}
+
+	started, err := s.virtualContestRepo.Start(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, utils.ErrVirtualContestStarted
+	}
+
+	response, err := s.GetVirtualContest(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.broadcaster.BroadcastToRoom(contest.RoomID, websocket.MessageTypeVirtualContestStarted, response); err != nil {
+		fmt.Printf("Warning: failed to broadcast start of virtual contest %s: %v\n", id, err)
+	}
+	return response, nil
+}
+
+// GetLeaderboard retrieves the standings of a virtual contest
+func (s *VirtualContestService) GetLeaderboard(id, userID string) (*dto.LeaderboardResponse, error) {
+	contest, err := s.findForParticipant(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildLeaderboard(contest)
+}
+
+// RecordAttempt records a solve or a wrong attempt in every running virtual contest the user
+// joined that includes the problem, and pushes the new standings to the contest rooms
+func (s *VirtualContestService) RecordAttempt(userID, problemID string, solved bool) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	problemUUID, err := uuid.Parse(problemID)
+	if err != nil {
+		return fmt.Errorf("invalid problem ID: %w", err)
+	}
+
+	now := time.Now()
+	contests, err := s.virtualContestRepo.FindRunningWithProblem(userID, problemID, now)
+	if err != nil {
+		return err
+	}
+
+	for _, running := range contests {
+		changed, err := s.virtualContestRepo.RecordAttempt(&models.VirtualContestSubmission{
+			VirtualContestID: running.ID,
+			UserID:           userUUID,
+			ProblemID:        problemUUID,
+		}, solved, now)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		contest, err := s.virtualContestRepo.FindByID(running.ID.String())
+		if err != nil {
+			return err
+		}
+		leaderboard, err := s.buildLeaderboard(contest)
+		if err != nil {
+			return err
+		}
+		if err := s.broadcaster.BroadcastToRoom(contest.RoomID, websocket.MessageTypeLeaderboardUpdate, leaderboard); err != nil {
+			fmt.Printf("Warning: failed to broadcast leaderboard of virtual contest %s: %v\n", contest.ID, err)
+		}
+	}
+	return nil
+}
+
+// findForParticipant loads a virtual contest, checking that the user was invited to it
+func (s *VirtualContestService) findForParticipant(id, userID string) (*models.VirtualContest, error) {
+	contest, err := s.virtualContestRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrVirtualContestNotFound
+		}
+		return nil, err
+	}
+
+	for _, participant := range contest.Participants {
+		if participant.UserID.String() == userID {
+			return contest, nil
+		}
+	}
+	return nil, utils.ErrNotInvited
+}
+
+// buildLeaderboard loads the submissions of a virtual contest and ranks its participants
+func (s *VirtualContestService) buildLeaderboard(contest *models.VirtualContest) (*dto.LeaderboardResponse, error) {
+	submissions, err := s.virtualContestRepo.FindSubmissions(contest.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	return rankLeaderboard(contest, submissions, time.Now()), nil
+}
+
+// rankLeaderboard ranks the joined participants by problems solved, then by penalty: minutes
+// from the start to each solve plus wrongAttemptPenaltyMinutes per wrong attempt before it
+func rankLeaderboard(contest *models.VirtualContest, submissions []models.VirtualContestSubmission, now time.Time) *dto.LeaderboardResponse {
+	leaderboard := &dto.LeaderboardResponse{
+		VirtualContestID: contest.ID,
+		Status:           virtualContestStatus(contest, now),
+		Rows:             []dto.LeaderboardRow{},
+		UpdatedAt:        now,
+	}
+
+	// Like the contest itself, rows don't reveal the problems before the timer starts
+	problems := contest.Problems
+	if leaderboard.Status == dto.VirtualContestPending {
+		problems = nil
+	}
+
+	type attemptKey struct{ userID, problemID uuid.UUID }
+	attempts := make(map[attemptKey]models.VirtualContestSubmission, len(submissions))
+	for _, submission := range submissions {
+		attempts[attemptKey{submission.UserID, submission.ProblemID}] = submission
+	}
+
+	for _, participant := range contest.Participants {
+		if participant.Status != models.VirtualParticipantJoined {
+			continue
+		}
+
+		row := dto.LeaderboardRow{
+			User: dto.ContestantResponse{
+				ID:        participant.User.ID,
+				Username:  participant.User.Username,
+				AvatarURL: participant.User.AvatarURL,
+			},
+			Problems: make([]dto.LeaderboardProblemResult, len(problems)),
+		}
+		for i, problem := range problems {
+			result := dto.LeaderboardProblemResult{Label: problemLabel(problem.Position), ProblemID: problem.ProblemID}
+			if submission, ok := attempts[attemptKey{participant.UserID, problem.ProblemID}]; ok {
+				result.WrongAttempts = submission.WrongAttempts
+				if submission.SolvedAt != nil && contest.StartedAt != nil {
+					result.Solved = true
+					result.SolvedAtMinutes = int(submission.SolvedAt.Sub(*contest.StartedAt).Minutes())
+					row.Solved++
+					row.Penalty += result.SolvedAtMinutes + submission.WrongAttempts*wrongAttemptPenaltyMinutes
+				}
+			}
+			row.Problems[i] = result
+		}
+		leaderboard.Rows = append(leaderboard.Rows, row)
+	}
+
+	sort.SliceStable(leaderboard.Rows, func(i, j int) bool {
+		a, b := leaderboard.Rows[i], leaderboard.Rows[j]
+		if a.Solved != b.Solved {
+			return a.Solved > b.Solved
+		}
+		if a.Penalty != b.Penalty {
+			return a.Penalty < b.Penalty
+		}
+		return a.User.Username < b.User.Username
+	})
+	// Ties share a rank
+	for i := range leaderboard.Rows {
+		row := &leaderboard.Rows[i]
+		row.Rank = i + 1
+		if i > 0 {
+			prev := leaderboard.Rows[i-1]
+			if prev.Solved == row.Solved && prev.Penalty == row.Penalty {
+				row.Rank = prev.Rank
+			}
+		}
+	}
+
+	return leaderboard
+}
+
+// virtualContestStatus returns whether a virtual contest is pending, running or finished
+func virtualContestStatus(contest *models.VirtualContest, now time.Time) string {
+	endsAt := contest.EndsAt()
+	switch {
+	case endsAt == nil:
+		return dto.VirtualContestPending
+	case now.Before(*endsAt):
+		return dto.VirtualContestRunning
+	default:
+		return dto.VirtualContestFinished
+	}
+}
+
+// problemLabel converts a problem position to its label (0 -> A)
+func problemLabel(position int) string {
+	return string(rune('A' + position))
+}
+
+// mapVirtualContestToResponse converts VirtualContest model to VirtualContestResponse DTO.
+// Problems are hidden until the contest starts.
+func (s *VirtualContestService) mapVirtualContestToResponse(contest *models.VirtualContest, now time.Time) *dto.VirtualContestResponse {
+	response := &dto.VirtualContestResponse{
+		ID:              contest.ID,
+		Name:            contest.Name,
+		CreatedBy:       contest.CreatedBy,
+		SourceContestID: contest.SourceContestID,
+		RoomID:          contest.RoomID,
+		DurationMinutes: contest.DurationMinutes,
+		Status:          virtualContestStatus(contest, now),
+		StartedAt:       contest.StartedAt,
+		EndsAt:          contest.EndsAt(),
+		Participants:    make([]dto.VirtualContestParticipantResponse, len(contest.Participants)),
+		CreatedAt:       contest.CreatedAt,
+	}
+
+	if response.Status == dto.VirtualContestRunning {
+		response.SecondsRemaining = int64(response.EndsAt.Sub(now).Seconds())
+	}
+
+	if response.Status != dto.VirtualContestPending {
+		for _, problem := range contest.Problems {
+			response.Problems = append(response.Problems, dto.VirtualContestProblemResponse{
+				Label:      problemLabel(problem.Position),
+				ProblemID:  problem.ProblemID,
+				Platform:   problem.Problem.Platform,
+				Title:      problem.Problem.Title,
+				Difficulty: problem.Problem.Difficulty,
+				Rating:     problem.Problem.Rating,
+				ProblemURL: problem.Problem.ProblemURL,
+			})
+		}
+	}
+
+	for i, participant := range contest.Participants {
+		response.Participants[i] = dto.VirtualContestParticipantResponse{
+			User: dto.ContestantResponse{
+				ID:        participant.User.ID,
+				Username:  participant.User.Username,
+				AvatarURL: participant.User.AvatarURL,
+			},
+			Status:   participant.Status,
+			JoinedAt: participant.JoinedAt,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"dojo/internal/dto"
+	"dojo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRankLeaderboard(t *testing.T) {
+	startedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time { return ptr(startedAt.Add(time.Duration(minutes) * time.Minute)) }
+
+	problemA, problemB := uuid.New(), uuid.New()
+	users := map[string]uuid.UUID{"alice": uuid.New(), "bob": uuid.New(), "carol": uuid.New(), "dave": uuid.New()}
+	joined := func(username string) models.VirtualContestParticipant {
+		return models.VirtualContestParticipant{
+			UserID: users[username],
+			Status: models.VirtualParticipantJoined,
+			User:   models.User{ID: users[username], Username: username},
+		}
+	}
+	contest := &models.VirtualContest{
+		ID:              uuid.New(),
+		DurationMinutes: 120,
+		StartedAt:       &startedAt,
+		Problems: []models.VirtualContestProblem{
+			{ProblemID: problemA, Position: 0},
+			{ProblemID: problemB, Position: 1},
+		},
+		Participants: []models.VirtualContestParticipant{
+			joined("alice"),
+			joined("bob"),
+			joined("carol"),
+			{UserID: users["dave"], Status: "invited", User: models.User{ID: users["dave"], Username: "dave"}},
+		},
+	}
+	submission := func(username string, problemID uuid.UUID, wrongAttempts int, solvedAt *time.Time) models.VirtualContestSubmission {
+		return models.VirtualContestSubmission{UserID: users[username], ProblemID: problemID, WrongAttempts: wrongAttempts, SolvedAt: solvedAt}
+	}
+
+	type wantRow struct {
+		username string
+		rank     int
+		solved   int
+		penalty  int
+	}
+	tests := []struct {
+		name        string
+		submissions []models.VirtualContestSubmission
+		want        []wantRow
+	}{
+		{
+			name: "no submissions tie everyone at rank 1",
+			want: []wantRow{{"alice", 1, 0, 0}, {"bob", 1, 0, 0}, {"carol", 1, 0, 0}},
+		},
+		{
+			name: "penalty is minutes to solve plus 20 per wrong attempt",
+			submissions: []models.VirtualContestSubmission{
+				submission("alice", problemA, 2, at(10)),
+				submission("alice", problemB, 0, at(95)),
+			},
+			want: []wantRow{{"alice", 1, 2, 10 + 2*20 + 95}, {"bob", 2, 0, 0}, {"carol", 2, 0, 0}},
+		},
+		{
+			name: "wrong attempts on unsolved problems cost nothing",
+			submissions: []models.VirtualContestSubmission{
+				submission("alice", problemA, 0, at(30)),
+				submission("bob", problemA, 0, at(30)),
+				submission("bob", problemB, 5, nil),
+			},
+			want: []wantRow{{"alice", 1, 1, 30}, {"bob", 1, 1, 30}, {"carol", 3, 0, 0}},
+		},
+		{
+			name: "more solves beat a lower penalty",
+			submissions: []models.VirtualContestSubmission{
+				submission("carol", problemA, 3, at(100)),
+				submission("carol", problemB, 0, at(110)),
+				submission("bob", problemA, 0, at(1)),
+			},
+			want: []wantRow{{"carol", 1, 2, 270}, {"bob", 2, 1, 1}, {"alice", 3, 0, 0}},
+		},
+		{
+			name: "equal solves go to the lower penalty",
+			submissions: []models.VirtualContestSubmission{
+				submission("alice", problemA, 1, at(5)),
+				submission("bob", problemA, 0, at(24)),
+				submission("carol", problemB, 0, at(26)),
+			},
+			want: []wantRow{{"bob", 1, 1, 24}, {"alice", 2, 1, 25}, {"carol", 3, 1, 26}},
+		},
+		{
+			name: "partial minutes are dropped",
+			submissions: []models.VirtualContestSubmission{
+				submission("alice", problemA, 0, ptr(startedAt.Add(59*time.Second))),
+			},
+			want: []wantRow{{"alice", 1, 1, 0}, {"bob", 2, 0, 0}, {"carol", 2, 0, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaderboard := rankLeaderboard(contest, tt.submissions, startedAt.Add(time.Hour))
+
+			if leaderboard.Status != dto.VirtualContestRunning {
+				t.Errorf("Status = %q, want %q", leaderboard.Status, dto.VirtualContestRunning)
+			}
+			if len(leaderboard.Rows) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(leaderboard.Rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				row := leaderboard.Rows[i]
+				if row.User.Username != want.username || row.Rank != want.rank || row.Solved != want.solved || row.Penalty != want.penalty {
+					t.Errorf("row %d = %s rank %d, %d solved, penalty %d; want %s rank %d, %d solved, penalty %d",
+						i, row.User.Username, row.Rank, row.Solved, row.Penalty, want.username, want.rank, want.solved, want.penalty)
+				}
+			}
+		})
+	}
+}
+
+func TestRankLeaderboardProblemResults(t *testing.T) {
+	startedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID, problemA, problemB := uuid.New(), uuid.New(), uuid.New()
+	contest := &models.VirtualContest{
+		DurationMinutes: 60,
+		StartedAt:       &startedAt,
+		Problems:        []models.VirtualContestProblem{{ProblemID: problemA, Position: 0}, {ProblemID: problemB, Position: 1}},
+		Participants: []models.VirtualContestParticipant{
+			{UserID: userID, Status: models.VirtualParticipantJoined, User: models.User{ID: userID, Username: "alice"}},
+		},
+	}
+	submissions := []models.VirtualContestSubmission{
+		{UserID: userID, ProblemID: problemA, WrongAttempts: 1, SolvedAt: ptr(startedAt.Add(42 * time.Minute))},
+		{UserID: userID, ProblemID: problemB, WrongAttempts: 2},
+	}
+
+	leaderboard := rankLeaderboard(contest, submissions, startedAt.Add(2*time.Hour))
+	if leaderboard.Status != dto.VirtualContestFinished {
+		t.Errorf("Status = %q, want %q", leaderboard.Status, dto.VirtualContestFinished)
+	}
+
+	want := []dto.LeaderboardProblemResult{
+		{Label: "A", ProblemID: problemA, Solved: true, SolvedAtMinutes: 42, WrongAttempts: 1},
+		{Label: "B", ProblemID: problemB, WrongAttempts: 2},
+	}
+	got := leaderboard.Rows[0].Problems
+	if len(got) != len(want) {
+		t.Fatalf("got %d problem results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("problem %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRankLeaderboardBeforeStart(t *testing.T) {
+	userID, problemID := uuid.New(), uuid.New()
+	contest := &models.VirtualContest{
+		DurationMinutes: 60,
+		Problems:        []models.VirtualContestProblem{{ProblemID: problemID}},
+		Participants: []models.VirtualContestParticipant{
+			{UserID: userID, Status: models.VirtualParticipantJoined, User: models.User{ID: userID, Username: "alice"}},
+		},
+	}
+	solvedAt := time.Now()
+	submissions := []models.VirtualContestSubmission{{UserID: userID, ProblemID: problemID, SolvedAt: &solvedAt}}
+
+	leaderboard := rankLeaderboard(contest, submissions, time.Now())
+	if leaderboard.Status != dto.VirtualContestPending {
+		t.Errorf("Status = %q, want %q", leaderboard.Status, dto.VirtualContestPending)
+	}
+	if row := leaderboard.Rows[0]; row.Solved != 0 || row.Penalty != 0 || row.Problems == nil || len(row.Problems) != 0 {
+		t.Errorf("row = %+v, want nothing solved and no problems before the start", row)
+	}
+}
@@ -23,9 +23,13 @@ var (
 	ErrCannotSendToSelf           = errors.New("cannot send friend request to yourself")
 	ErrUserBlocked                = errors.New("user is blocked")
 
+	// Notification errors
+	ErrNotificationNotFound = errors.New("notification not found")
+
 	// Problem errors
 	ErrProblemNotFound = errors.New("problem not found")
 	ErrNoteNotFound    = errors.New("note not found")
+	ErrReviewNotFound  = errors.New("review not found")
 
 	// Sheet errors
 	ErrSheetNotFound         = errors.New("sheet not found")
@@ -36,6 +40,15 @@ var (
 	ErrContestNotFound  = errors.New("contest not found")
 	ErrReminderNotFound = errors.New("reminder not found")
 
+	// Virtual contest errors
+	ErrVirtualContestNotFound = errors.New("virtual contest not found")
+	ErrVirtualContestStarted  = errors.New("virtual contest already started")
+	ErrNotInvited             = errors.New("user is not invited to this virtual contest")
+
+	// Private contest errors
+	ErrPrivateContestNotFound = errors.New("private contest not found")
+	ErrAlreadyJoined          = errors.New("already joined this contest")
+
 	// Room errors
 	ErrRoomNotFound  = errors.New("room not found")
 	ErrRoomFull      = errors.New("room is full")
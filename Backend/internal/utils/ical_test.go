@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Codeforces Round 900", "Codeforces Round 900"},
+		{"separators", "Div. 1; Div. 2, rated", `Div. 1\; Div. 2\, rated`},
+		{"backslash first", `a\;b`, `a\\\;b`},
+		{"newlines", "line 1\nline 2\r\nline 3\rline 4", `line 1\nline 2\nline 3\nline 4`},
+		{"colon is allowed", "Starts at 12:00", "Starts at 12:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeICalText(tt.in); got != tt.want {
+				t.Errorf("escapeICalText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteICalLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"short", "SUMMARY:Codeforces Round 900"},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67)},
+		{"long ascii", "DESCRIPTION:" + strings.Repeat("abcdefghij", 30)},
+		{"two-byte runes", "SUMMARY:" + strings.Repeat("é", 100)},
+		{"three-byte runes", "SUMMARY:" + strings.Repeat("日本", 50)},
+		{"four-byte runes", "SUMMARY:x" + strings.Repeat("🏆", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeICalLine(&b, tt.line)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q is not terminated by CRLF", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			var unfolded strings.Builder
+			for i, line := range lines {
+				if len(line) > 75 {
+					t.Errorf("line %d is %d octets long, want at most 75", i, len(line))
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Fatalf("continuation line %d %q doesn't start with a space", i, line)
+					}
+					line = line[1:]
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d %q splits a UTF-8 character", i, line)
+				}
+				unfolded.WriteString(line)
+			}
+			if unfolded.String() != tt.line {
+				t.Errorf("unfolded line = %q, want %q", unfolded.String(), tt.line)
+			}
+			if len(tt.line) <= 75 && len(lines) != 1 {
+				t.Errorf("a %d-octet line was folded into %d lines", len(tt.line), len(lines))
+			}
+		})
+	}
+}
+
+func TestBuildICalendar(t *testing.T) {
+	start := time.Date(2024, 3, 1, 14, 35, 0, 0, time.FixedZone("IST", 5*60*60+30*60))
+	events := []ICalEvent{
+		{
+			UID:          "contest-1@dojo",
+			Summary:      "Codeforces Round 900 (Div. 1, Div. 2)",
+			Description:  "Rated; 2 hours",
+			URL:          "https://codeforces.com/contest/1900",
+			Start:        start,
+			End:          start.Add(2 * time.Hour),
+			AlarmMinutes: 30,
+		},
+		{
+			UID:     "contest-2@dojo",
+			Summary: "AtCoder Beginner Contest 340",
+			Start:   start.Add(24 * time.Hour),
+			End:     start.Add(26 * time.Hour),
+		},
+	}
+
+	calendar := BuildICalendar("Dojo, contests", events)
+
+	if !strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(calendar, "END:VCALENDAR\r\n") {
+		t.Fatalf("calendar isn't wrapped in VCALENDAR:\n%s", calendar)
+	}
+	if strings.Contains(strings.ReplaceAll(calendar, "\r\n", ""), "\n") {
+		t.Error("calendar has a line not terminated by CRLF")
+	}
+
+	for _, want := range []string{
+		"X-WR-CALNAME:Dojo\\, contests\r\n",
+		"UID:contest-1@dojo\r\n",
+		"DTSTART:20240301T090500Z\r\n",
+		"DTEND:20240301T110500Z\r\n",
+		"SUMMARY:Codeforces Round 900 (Div. 1\\, Div. 2)\r\n",
+		"DESCRIPTION:Rated\\; 2 hours\r\n",
+		"URL:https://codeforces.com/contest/1900\r\n",
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nDESCRIPTION:Codeforces Round 900 (Div. 1\\, Div. 2)\r\nTRIGGER:-PT30M\r\nEND:VALARM\r\n",
+		"DTSTART:20240302T090500Z\r\n",
+	} {
+		if !strings.Contains(calendar, want) {
+			t.Errorf("calendar is missing %q:\n%s", want, calendar)
+		}
+	}
+
+	if got := strings.Count(calendar, "BEGIN:VEVENT\r\n"); got != 2 {
+		t.Errorf("got %d events, want 2", got)
+	}
+	if got := strings.Count(calendar, "BEGIN:VALARM"); got != 1 {
+		t.Errorf("got %d alarms, want 1 (only the first event has one)", got)
+	}
+	if got := strings.Count(calendar, "DESCRIPTION:"); got != 2 {
+		t.Errorf("got %d descriptions, want 2 (the second event has none)", got)
+	}
+}
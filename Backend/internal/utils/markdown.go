@@ -0,0 +1,366 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+// markdownRenderer renders CommonMark with GitHub tables and strikethrough. Raw HTML is left
+// out and dangerous link targets are dropped while rendering.
+var markdownRenderer = goldmark.New(goldmark.WithExtensions(extension.Table, extension.Strikethrough))
+
+// markdownPolicy is the allowlist rendered markdown is filtered through, so nothing the
+// renderer lets through can run script
+var markdownPolicy = func() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return policy
+}()
+
+// NormalizeMarkdown normalizes line endings, drops control characters other than tabs and
+// newlines and trims surrounding whitespace
+func NormalizeMarkdown(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, content)
+	return strings.TrimSpace(content)
+}
+
+// RenderMarkdown renders user-written markdown to HTML that is safe to show to other users
+func RenderMarkdown(content string) string {
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(content), &buf); err != nil {
+		return ""
+	}
+	return markdownPolicy.Sanitize(buf.String())
+}
+
+var (
+	// whitespaceRunPattern matches runs of whitespace, which HTML renders as a single space
+	whitespaceRunPattern = regexp.MustCompile(`\s+`)
+	// backtickRunPattern matches runs of backticks, which a code span or fence must outnumber
+	backtickRunPattern = regexp.MustCompile("`+")
+	// orderedMarkerPattern matches text that would start an ordered list item
+	orderedMarkerPattern = regexp.MustCompile(`^(\d+)([.)])`)
+	// markdownEscaper backslash-escapes the characters markdown or HTML would interpret in text
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+		"&", `\&`, "!", `\!`, "|", `\|`, "~", `\~`, "#", `\#`,
+	)
+	// urlEscaper percent-encodes the characters that would end a markdown link target
+	urlEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+)
+
+// markdownWriter builds markdown from HTML tokens, keeping at most one blank line between
+// blocks and no spaces at the end of lines
+type markdownWriter struct {
+	b         []byte
+	emphasis  []int // Per open emphasis: the length of the output right after its marker
+	itemStart bool  // Right after a list marker, where a paragraph must not start a new block
+}
+
+func (w *markdownWriter) write(s string) {
+	w.b = append(w.b, s...)
+}
+
+func (w *markdownWriter) last() byte {
+	if len(w.b) == 0 {
+		return 0
+	}
+	return w.b[len(w.b)-1]
+}
+
+// trimSpaces removes spaces at the end of the output and reports whether there were any
+func (w *markdownWriter) trimSpaces() bool {
+	n := len(w.b)
+	for n > 0 && w.b[n-1] == ' ' {
+		n--
+	}
+	trimmed := n < len(w.b)
+	w.b = w.b[:n]
+	return trimmed
+}
+
+// breakLine ends the current line
+func (w *markdownWriter) breakLine() {
+	w.trimSpaces()
+	if len(w.b) > 0 && w.last() != '\n' {
+		w.write("\n")
+	}
+}
+
+// breakBlock ends the current block with a blank line
+func (w *markdownWriter) breakBlock() {
+	w.breakLine()
+	if len(w.b) > 0 && !strings.HasSuffix(string(w.b[max(len(w.b)-2, 0):]), "\n\n") {
+		w.write("\n")
+	}
+}
+
+// text writes HTML text with whitespace collapsed and markdown characters escaped
+func (w *markdownWriter) text(s string) {
+	s = whitespaceRunPattern.ReplaceAllString(strings.ReplaceAll(s, "\u00a0", " "), " ")
+	lineStart := w.last() == 0 || w.last() == '\n'
+	if lineStart || w.last() == ' ' {
+		s = strings.TrimLeft(s, " ")
+	}
+	if s == "" {
+		return
+	}
+
+	escaped := markdownEscaper.Replace(s)
+	if lineStart {
+		// Text that would start a list item, quote or heading underline
+		if strings.ContainsRune("-+=", rune(escaped[0])) {
+			escaped = `\` + escaped
+		}
+		escaped = orderedMarkerPattern.ReplaceAllString(escaped, `$1\$2`)
+	}
+	w.write(escaped)
+	w.itemStart = false
+}
+
+// openEmphasis writes an opening emphasis marker
+func (w *markdownWriter) openEmphasis(marker string) {
+	w.write(marker)
+	w.emphasis = append(w.emphasis, len(w.b))
+}
+
+// closeEmphasis writes a closing emphasis marker, moving a trailing space after it. Emphasis
+// around nothing is removed.
+func (w *markdownWriter) closeEmphasis(marker string) {
+	if len(w.emphasis) == 0 {
+		return
+	}
+	opened := w.emphasis[len(w.emphasis)-1]
+	w.emphasis = w.emphasis[:len(w.emphasis)-1]
+
+	trimmed := w.trimSpaces()
+	if len(w.b) <= opened {
+		w.b = w.b[:opened-len(marker)]
+	} else {
+		w.write(marker)
+	}
+	if trimmed {
+		w.write(" ")
+	}
+}
+
+// code writes an inline code span, delimited by more backticks than the code contains
+func (w *markdownWriter) code(code string) {
+	if strings.TrimSpace(code) == "" {
+		return
+	}
+	fence := strings.Repeat("`", longestBacktickRun(code)+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	w.write(fence + code + fence)
+	w.itemStart = false
+}
+
+// codeBlock writes a fenced code block, fenced by more backticks than the code contains
+func (w *markdownWriter) codeBlock(code string) {
+	code = strings.TrimRight(code, " \n")
+	fence := strings.Repeat("`", max(longestBacktickRun(code)+1, 3))
+	w.breakBlock()
+	w.write(fence + "\n" + code + "\n" + fence)
+	w.breakBlock()
+}
+
+// longestBacktickRun returns the length of the longest run of backticks in s
+func longestBacktickRun(s string) int {
+	longest := 0
+	for _, run := range backtickRunPattern.FindAllString(s, -1) {
+		longest = max(longest, len(run))
+	}
+	return longest
+}
+
+// safeURL reports whether a link or image target can be kept
+func safeURL(u string) bool {
+	lower := strings.ToLower(strings.TrimSpace(u))
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://")
+}
+
+// HTMLToMarkdown converts HTML from a platform's problem statement to markdown. Paragraphs,
+// emphasis, code, lists, links and images are kept; other tags are dropped with their text
+// kept. Text is escaped, so it shows literally and can't form HTML or links.
+func HTMLToMarkdown(content string) string {
+	var w markdownWriter
+	var lists []int    // Per open list: the next item number, 0 for unordered lists
+	var links []string // Targets of the open links, "" for dropped ones
+	var code *strings.Builder
+	codeTag := "" // "code" or "pre" while code is being collected
+	skip := 0     // Inside <script> or <style>, whose text isn't content
+
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		token := z.Token()
+		if skip > 0 && !(tt == html.EndTagToken && (token.Data == "script" || token.Data == "style")) {
+			continue
+		}
+
+		// Code is collected raw and written out when it closes
+		if code != nil {
+			switch {
+			case tt == html.TextToken:
+				text := strings.ReplaceAll(token.Data, "\u00a0", " ")
+				if codeTag == "pre" {
+					// A newline right after <pre> is not part of its content
+					if code.Len() == 0 {
+						text = strings.TrimPrefix(text, "\n")
+					}
+				} else {
+					text = whitespaceRunPattern.ReplaceAllString(text, " ")
+				}
+				code.WriteString(text)
+			case tt == html.EndTagToken && token.Data == codeTag:
+				if codeTag == "pre" {
+					w.codeBlock(code.String())
+				} else {
+					w.code(code.String())
+				}
+				code = nil
+			case token.Data == "br":
+				if codeTag == "pre" {
+					code.WriteString("\n")
+				} else {
+					code.WriteString(" ")
+				}
+			case token.Data == "sup" && tt == html.StartTagToken:
+				code.WriteString("^")
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			w.text(token.Data)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			attrs := make(map[string]string, len(token.Attr))
+			for _, attr := range token.Attr {
+				attrs[attr.Key] = attr.Val
+			}
+
+			switch token.Data {
+			case "script", "style":
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case "p", "div":
+				if !w.itemStart {
+					w.breakBlock()
+				}
+			case "br":
+				w.breakLine()
+			case "strong", "b":
+				w.openEmphasis("**")
+			case "em", "i":
+				w.openEmphasis("*")
+			case "code", "pre":
+				if tt == html.StartTagToken {
+					code = &strings.Builder{}
+					codeTag = token.Data
+				}
+			case "sup":
+				w.write("^")
+			case "ul", "ol":
+				if len(lists) == 0 {
+					w.breakBlock()
+				} else {
+					w.breakLine()
+				}
+				next := 0
+				if token.Data == "ol" {
+					next = 1
+				}
+				lists = append(lists, next)
+			case "li":
+				marker := "- "
+				if len(lists) > 0 && lists[len(lists)-1] > 0 {
+					marker = fmt.Sprintf("%d. ", lists[len(lists)-1])
+					lists[len(lists)-1]++
+				}
+				w.breakLine()
+				w.write(strings.Repeat("  ", max(len(lists)-1, 0)) + marker)
+				w.itemStart = true
+			case "a":
+				href := attrs["href"]
+				if !safeURL(href) {
+					href = ""
+				}
+				links = append(links, href)
+				if href != "" {
+					w.write("[")
+				}
+			case "img":
+				if src := attrs["src"]; safeURL(src) {
+					alt := markdownEscaper.Replace(strings.TrimSpace(attrs["alt"]))
+					w.write(fmt.Sprintf("![%s](%s)", alt, urlEscaper.Replace(strings.TrimSpace(src))))
+				}
+			}
+
+		case html.EndTagToken:
+			switch token.Data {
+			case "script", "style":
+				if skip > 0 {
+					skip--
+				}
+			case "p", "div":
+				if len(lists) > 0 {
+					w.breakLine()
+				} else {
+					w.breakBlock()
+				}
+			case "strong", "b":
+				w.closeEmphasis("**")
+			case "em", "i":
+				w.closeEmphasis("*")
+			case "ul", "ol":
+				if len(lists) > 0 {
+					lists = lists[:len(lists)-1]
+				}
+				if len(lists) == 0 {
+					w.breakBlock()
+				} else {
+					w.breakLine()
+				}
+			case "a":
+				if len(links) > 0 {
+					if href := links[len(links)-1]; href != "" {
+						w.write("](" + urlEscaper.Replace(strings.TrimSpace(href)) + ")")
+					}
+					links = links[:len(links)-1]
+				}
+			}
+		}
+	}
+
+	if code != nil {
+		// Unclosed code at the end of the statement
+		if codeTag == "pre" {
+			w.codeBlock(code.String())
+		} else {
+			w.code(code.String())
+		}
+	}
+	return NormalizeMarkdown(string(w.b))
+}
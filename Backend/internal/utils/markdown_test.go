@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"golang.org/x/net/html"
+)
+
+func TestRenderMarkdownUnsafe(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"tag split over lines", "<img src=x\nonerror=alert(1)>"},
+		{"script block", "<script>alert(1)</script>"},
+		{"autolink", "<javascript:alert(1)>"},
+		{"inline link", "[x](javascript:alert(1))"},
+		{"reference definition", "[x]\n\n[x]: javascript:alert(1)"},
+		{"entity-encoded target", "[x](&#106;avascript:alert(1))"},
+		{"data target", "[x](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)"},
+		// Not a fence: info strings of backtick fences can't contain backticks
+		{"fake fence", "```x`y\n<img src=x onerror=alert(1)>"},
+		// Indented four spaces this is a code block, and the line after it is not in a fence
+		{"indented fence", "    ```\n<img src=x onerror=alert(1)>"},
+		{"event handler attribute", `<a href="https://example.com" onclick="alert(1)">x</a>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSafeHTML(t, RenderMarkdown(tt.content))
+		})
+	}
+}
+
+// assertSafeHTML fails if the HTML has a script or image tag, an event handler attribute or
+// a link target with a script-capable scheme
+func assertSafeHTML(t *testing.T, rendered string) {
+	t.Helper()
+	z := html.NewTokenizer(strings.NewReader(rendered))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+		token := z.Token()
+		if token.Data == "script" || token.Data == "img" || token.Data == "iframe" {
+			t.Errorf("rendered HTML has a <%s> tag: %s", token.Data, rendered)
+		}
+		for _, attr := range token.Attr {
+			value := strings.ToLower(strings.TrimSpace(attr.Val))
+			if strings.HasPrefix(attr.Key, "on") ||
+				((attr.Key == "href" || attr.Key == "src") && !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://")) {
+				t.Errorf("rendered HTML has unsafe attribute %s=%q: %s", attr.Key, attr.Val, rendered)
+			}
+		}
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"emphasis", "**bold** and *italic*", []string{"<strong>bold</strong>", "<em>italic</em>"}},
+		{"inline code", "`a<b>c`", []string{"<code>a&lt;b&gt;c</code>"}},
+		{"fenced code", "```cpp\nif (a < b) {}\n```", []string{`<code class="language-cpp">if (a &lt; b) {}`}},
+		{"safe link", "[docs](https://example.com)", []string{`href="https://example.com"`}},
+		{"table", "| a | b |\n|---|---|\n| 1 | 2 |", []string{"<table>", "<td>1</td>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := RenderMarkdown(tt.content)
+			for _, want := range tt.want {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("expected %q in %s", want, rendered)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeMarkdown(t *testing.T) {
+	if got := NormalizeMarkdown("  line\r\nnext\x00\x07\tend  \n"); got != "line\nnext\tend" {
+		t.Errorf("unexpected %q", got)
+	}
+}
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs and emphasis",
+			html: "<p>Return <em>the <strong>sum</strong></em>.</p><p>&nbsp;</p><p>Second</p>",
+			want: "Return *the **sum***.\n\nSecond",
+		},
+		{
+			name: "emphasis ending in a space",
+			html: "<strong>Follow-up:&nbsp;</strong>Can you?",
+			want: "**Follow-up:** Can you?",
+		},
+		{
+			name: "empty emphasis",
+			html: "<p>a<strong> </strong>b</p>",
+			want: "a b",
+		},
+		{
+			name: "angle brackets in text",
+			html: "<p>Return a vector&lt;int&gt; of size n &amp; more.</p>",
+			want: `Return a vector\<int\> of size n \& more.`,
+		},
+		{
+			name: "markdown characters in text",
+			html: "<p>a*b_c [x](y) `z` #1 | ~ !</p>",
+			want: "a\\*b\\_c \\[x\\](y) \\`z\\` \\#1 \\| \\~ \\!",
+		},
+		{
+			name: "list markers at the start of a line",
+			html: "<p>- not a list</p><p>1. not a list</p>",
+			want: "\\- not a list\n\n1\\. not a list",
+		},
+		{
+			name: "inline code kept literally",
+			html: "<code>vector&lt;int&gt; a*b</code> and <code>10<sup>9</sup></code>",
+			want: "`vector<int> a*b` and `10^9`",
+		},
+		{
+			name: "inline code with backticks",
+			html: "<code>a`b</code>",
+			want: "``a`b``",
+		},
+		{
+			name: "lists",
+			html: "<ul>\n\t<li><code>1 &lt;= n</code></li>\n\t<li><p>Second</p></li>\n</ul><ol><li>One</li><li>Two</li></ol>",
+			want: "- `1 <= n`\n- Second\n\n1. One\n2. Two",
+		},
+		{
+			name: "pre block",
+			html: "<pre>\n<strong>Input:</strong> s = &quot;a&lt;b&quot;\n<strong>Output:</strong> 1\n</pre>",
+			want: "```\nInput: s = \"a<b\"\nOutput: 1\n```",
+		},
+		{
+			name: "pre block containing a fence",
+			html: "<pre>\n```\ncode\n```\n</pre><p>after</p>",
+			want: "````\n```\ncode\n```\n````\n\nafter",
+		},
+		{
+			name: "safe link and image",
+			html: `<a href="https://leetcode.com/problems/two-sum/">Two [Sum]</a> <img alt="graph" src="https://assets.leetcode.com/a b.png">`,
+			want: "[Two \\[Sum\\]](https://leetcode.com/problems/two-sum/) ![graph](https://assets.leetcode.com/a%20b.png)",
+		},
+		{
+			name: "unsafe link",
+			html: `<a href="javascript:alert(1)">x</a>`,
+			want: "x",
+		},
+		{
+			name: "script",
+			html: "<p>a</p><script>alert(1)</script><style>p{}</style><p>b</p>",
+			want: "a\n\nb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMarkdown(tt.html); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+// unsafeRenderer renders raw HTML and any link target
+var unsafeRenderer = goldmark.New(goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()))
+
+func TestHTMLToMarkdownUnsafe(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+	}{
+		{"escaped autolink", "<p>&lt;javascript:alert(1)&gt;</p>"},
+		{"escaped entity-encoded link", "<p>[x](&amp;#106;avascript:alert(1))</p>"},
+		{"escaped tag", "<p>&lt;img src=x onerror=alert(1)&gt;</p>"},
+		{"escaped reference definition", "<p>[x]</p><p>[x]: javascript:alert(1)</p>"},
+		{"link target", `<a href="javascript:alert(1)">x</a><a href="https://a.com/x)(javascript:alert(1)">y</a>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Rendered without RenderMarkdown's filtering, as any markdown renderer might
+			var buf bytes.Buffer
+			if err := unsafeRenderer.Convert([]byte(HTMLToMarkdown(tt.html)), &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertSafeHTML(t, buf.String())
+		})
+	}
+}
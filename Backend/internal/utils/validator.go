@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,18 +11,23 @@ import (
 // Validator is the global validator instance
 var Validator *validator.Validate
 
-// InitValidator initializes the validator
-func InitValidator() {
+// InitValidator initializes the validator. isPlatform reports whether a platform is supported,
+// for the "platform" tag; platforms are registered in the scrapper package, which imports this
+// one, so the caller passes the lookup in.
+func InitValidator(isPlatform func(name string) bool) {
 	Validator = validator.New()
 
 	// Register custom validators here if needed
 	// Example: Validator.RegisterValidation("custom_tag", customValidatorFunc)
+	Validator.RegisterValidation("platform", func(fl validator.FieldLevel) bool {
+		return isPlatform(fl.Field().String())
+	})
 }
 
-// ValidateStruct validates a struct using the validator
+// ValidateStruct validates a struct using the validator. InitValidator must be called first.
 func ValidateStruct(s interface{}) error {
 	if Validator == nil {
-		InitValidator()
+		return errors.New("validator is not initialized")
 	}
 
 	err := Validator.Struct(s)
@@ -55,6 +61,11 @@ func FormatValidationError(err error) error {
 func formatFieldError(e validator.FieldError) string {
 	field := strings.ToLower(e.Field())
 
+	// Also covers alternatives like "platform|eq=dojo"
+	if strings.HasPrefix(e.Tag(), "platform") {
+		return fmt.Sprintf("%s must be a supported platform", field)
+	}
+
 	switch e.Tag() {
 	case "required":
 		return fmt.Sprintf("%s is required", field)
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlatformValidation(t *testing.T) {
+	InitValidator(func(name string) bool { return name == "codeforces" || name == "leetcode" })
+
+	type request struct {
+		Platform  string   `validate:"omitempty,platform"`
+		Platforms []string `validate:"omitempty,dive,platform|eq=dojo"`
+	}
+
+	tests := []struct {
+		name    string
+		req     request
+		wantErr string
+	}{
+		{name: "empty", req: request{}},
+		{name: "registered platform", req: request{Platform: "codeforces"}},
+		{name: "unknown platform", req: request{Platform: "topcoder"}, wantErr: "platform must be a supported platform"},
+		{name: "case matters", req: request{Platform: "Codeforces"}, wantErr: "platform must be a supported platform"},
+		{name: "platforms with dojo", req: request{Platforms: []string{"leetcode", "dojo"}}},
+		{name: "unknown in platforms", req: request{Platforms: []string{"leetcode", "hackerrank"}}, wantErr: "platforms[1] must be a supported platform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStruct(&tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
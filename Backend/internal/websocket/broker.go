@@ -0,0 +1,107 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Envelope wraps a room message with the ID of the hub instance that published it,
+// so a hub can skip its own messages when they come back from the broker
+type Envelope struct {
+	Origin  string   `json:"origin"`
+	Message *Message `json:"message"`
+}
+
+// Broker fans room messages and presence out to every hub instance
+type Broker interface {
+	// Publish sends a message to all instances subscribed to its room
+	Publish(ctx context.Context, env *Envelope) error
+	// Subscribe starts receiving messages for a room on Messages()
+	Subscribe(ctx context.Context, roomID uuid.UUID) error
+	// Unsubscribe stops receiving messages for a room
+	Unsubscribe(ctx context.Context, roomID uuid.UUID) error
+	// Messages delivers envelopes published by any instance
+	Messages() <-chan *Envelope
+
+	// AddPresence records a connected client in a room
+	AddPresence(ctx context.Context, roomID, clientID uuid.UUID, user UserInfo) error
+	// RemovePresence removes a connected client from a room
+	RemovePresence(ctx context.Context, roomID, clientID uuid.UUID) error
+	// RoomPresence lists the users connected to a room on every instance
+	RoomPresence(ctx context.Context, roomID uuid.UUID) ([]UserInfo, error)
+
+	Close() error
+}
+
+// MemoryBroker keeps presence in process memory. Every client is connected to
+// the same hub, so publishing is a no-op. Used for single-instance deployments.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	presence map[uuid.UUID]map[uuid.UUID]UserInfo
+	messages chan *Envelope
+}
+
+// NewMemoryBroker creates a new in-memory broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		presence: make(map[uuid.UUID]map[uuid.UUID]UserInfo),
+		messages: make(chan *Envelope),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, env *Envelope) error {
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, roomID uuid.UUID) error {
+	return nil
+}
+
+func (b *MemoryBroker) Unsubscribe(ctx context.Context, roomID uuid.UUID) error {
+	return nil
+}
+
+func (b *MemoryBroker) Messages() <-chan *Envelope {
+	return b.messages
+}
+
+func (b *MemoryBroker) AddPresence(ctx context.Context, roomID, clientID uuid.UUID, user UserInfo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.presence[roomID]; !exists {
+		b.presence[roomID] = make(map[uuid.UUID]UserInfo)
+	}
+	b.presence[roomID][clientID] = user
+	return nil
+}
+
+func (b *MemoryBroker) RemovePresence(ctx context.Context, roomID, clientID uuid.UUID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if room, exists := b.presence[roomID]; exists {
+		delete(room, clientID)
+		if len(room) == 0 {
+			delete(b.presence, roomID)
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBroker) RoomPresence(ctx context.Context, roomID uuid.UUID) ([]UserInfo, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	users := make([]UserInfo, 0, len(b.presence[roomID]))
+	for _, user := range b.presence[roomID] {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
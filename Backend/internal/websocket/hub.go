@@ -1,12 +1,24 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// brokerTimeout bounds every broker call made from the hub loop
+const brokerTimeout = 3 * time.Second
+
+// broadcastBufferSize is how many broadcasts can wait for the hub loop
+const broadcastBufferSize = 256
+
+// ErrBroadcastQueueFull is returned by BroadcastToRoom when the hub can't keep up
+var ErrBroadcastQueueFull = errors.New("websocket broadcast queue is full")
+
 // Hub maintains the set of active clients and broadcasts messages
 type Hub struct {
 	// Registered clients by room
@@ -20,15 +32,30 @@ type Hub struct {
 
 	// Broadcast messages to clients in a room
 	Broadcast chan *Message
+
+	// Broker shares messages and presence with hubs on other instances
+	broker Broker
+
+	// Unique ID of this hub, used to skip its own messages coming back from the broker
+	instanceID string
 }
 
-// NewHub creates a new Hub
-func NewHub() *Hub {
+// NewHub creates a new Hub. instanceID must match the one given to the broker;
+// a nil broker keeps everything in process memory.
+func NewHub(broker Broker, instanceID string) *Hub {
+	if broker == nil {
+		broker = NewMemoryBroker()
+	}
+	if instanceID == "" {
+		instanceID = uuid.New().String()
+	}
 	return &Hub{
 		Rooms:      make(map[uuid.UUID]map[*Client]bool),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
-		Broadcast:  make(chan *Message),
+		Broadcast:  make(chan *Message, broadcastBufferSize),
+		broker:     broker,
+		instanceID: instanceID,
 	}
 }
 
@@ -44,19 +71,36 @@ func (h *Hub) Run() {
 
 		case message := <-h.Broadcast:
 			h.broadcastMessage(message)
+
+		case env, ok := <-h.broker.Messages():
+			if !ok {
+				log.Println("WebSocket broker closed")
+				return
+			}
+			h.handleRemoteMessage(env)
 		}
 	}
 }
 
 // registerClient adds a client to a room
 func (h *Hub) registerClient(client *Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
 	// Create room if doesn't exist
 	if _, exists := h.Rooms[client.RoomID]; !exists {
 		h.Rooms[client.RoomID] = make(map[*Client]bool)
+		if err := h.broker.Subscribe(ctx, client.RoomID); err != nil {
+			log.Printf("Warning: Failed to subscribe to room %s: %v", client.RoomID, err)
+		}
 	}
 
 	h.Rooms[client.RoomID][client] = true
 
+	if err := h.broker.AddPresence(ctx, client.RoomID, client.ID, clientUserInfo(client)); err != nil {
+		log.Printf("Warning: Failed to record presence in room %s: %v", client.RoomID, err)
+	}
+
 	log.Printf("Client registered: User %s joined room %s", client.Username, client.RoomID)
 
 	// Notify other users in room
@@ -65,32 +109,100 @@ func (h *Hub) registerClient(client *Client) {
 
 // unregisterClient removes a client from a room
 func (h *Hub) unregisterClient(client *Client) {
-	if room, exists := h.Rooms[client.RoomID]; exists {
-		if _, ok := room[client]; ok {
-			delete(room, client)
-			close(client.Send)
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
 
-			// Remove room if empty
-			if len(room) == 0 {
-				delete(h.Rooms, client.RoomID)
-			}
+	// Presence is cleared even if the client was already dropped for a full send buffer
+	if err := h.broker.RemovePresence(ctx, client.RoomID, client.ID); err != nil {
+		log.Printf("Warning: Failed to remove presence in room %s: %v", client.RoomID, err)
+	}
 
-			log.Printf("Client unregistered: User %s left room %s", client.Username, client.RoomID)
+	room, exists := h.Rooms[client.RoomID]
+	if !exists {
+		return
+	}
+
+	_, registered := room[client]
+	if registered {
+		delete(room, client)
+		close(client.Send)
+	}
 
-			// Notify other users
-			h.notifyUserLeft(client)
+	// Remove room if empty
+	if len(room) == 0 {
+		delete(h.Rooms, client.RoomID)
+		if err := h.broker.Unsubscribe(ctx, client.RoomID); err != nil {
+			log.Printf("Warning: Failed to unsubscribe from room %s: %v", client.RoomID, err)
 		}
 	}
+
+	if registered {
+		log.Printf("Client unregistered: User %s left room %s", client.Username, client.RoomID)
+
+		// Notify other users
+		h.notifyUserLeft(client)
+	}
 }
 
-// broadcastMessage sends a message to all clients in the same room
+// broadcastMessage sends a message to all clients in the same room, on every instance
 func (h *Hub) broadcastMessage(message *Message) {
+	h.deliverLocal(message, nil)
+	h.publish(message)
+}
+
+// BroadcastToRoom sends a server event to every client in a room, on every instance. It is
+// called from request handlers, so it never waits for the hub loop: when the queue is full the
+// event is dropped and ErrBroadcastQueueFull returned.
+func (h *Hub) BroadcastToRoom(roomID uuid.UUID, messageType MessageType, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message := &Message{
+		Type:      messageType,
+		RoomID:    roomID,
+		Data:      payload,
+		Timestamp: time.Now(),
+	}
+	select {
+	case h.Broadcast <- message:
+		return nil
+	default:
+		return ErrBroadcastQueueFull
+	}
+}
+
+// handleRemoteMessage delivers a message published by another instance to local clients
+func (h *Hub) handleRemoteMessage(env *Envelope) {
+	// Our own messages were already delivered locally
+	if env.Origin == h.instanceID {
+		return
+	}
+	h.deliverLocal(env.Message, nil)
+}
+
+// publish hands a message to the broker for the other instances
+func (h *Hub) publish(message *Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	if err := h.broker.Publish(ctx, &Envelope{Origin: h.instanceID, Message: message}); err != nil {
+		log.Printf("Warning: Failed to publish message to room %s: %v", message.RoomID, err)
+	}
+}
+
+// deliverLocal sends a message to this instance's clients in the room, except skip
+func (h *Hub) deliverLocal(message *Message, skip *Client) {
 	room, exists := h.Rooms[message.RoomID]
 	if !exists {
 		return
 	}
 
 	for client := range room {
+		if client == skip {
+			continue
+		}
 		select {
 		case client.Send <- message:
 		default:
@@ -103,8 +215,7 @@ func (h *Hub) broadcastMessage(message *Message) {
 
 // notifyUserJoined sends user joined notification
 func (h *Hub) notifyUserJoined(newClient *Client) {
-	room, exists := h.Rooms[newClient.RoomID]
-	if !exists {
+	if _, exists := h.Rooms[newClient.RoomID]; !exists {
 		return
 	}
 
@@ -119,13 +230,7 @@ func (h *Hub) notifyUserJoined(newClient *Client) {
 	newClient.Send <- userListMsg
 
 	// Notify others about new user
-	userInfo := UserInfo{
-		UserID:   newClient.UserID,
-		Username: newClient.Username,
-		Color:    newClient.Color,
-		IsOnline: true,
-	}
-	userData, _ := json.Marshal(userInfo)
+	userData, _ := json.Marshal(clientUserInfo(newClient))
 	joinMsg := &Message{
 		Type:     MessageTypeUserJoined,
 		RoomID:   newClient.RoomID,
@@ -134,20 +239,12 @@ func (h *Hub) notifyUserJoined(newClient *Client) {
 		Data:     userData,
 	}
 
-	for client := range room {
-		if client.ID != newClient.ID {
-			client.Send <- joinMsg
-		}
-	}
+	h.deliverLocal(joinMsg, newClient)
+	h.publish(joinMsg)
 }
 
 // notifyUserLeft sends user left notification
 func (h *Hub) notifyUserLeft(leftClient *Client) {
-	room, exists := h.Rooms[leftClient.RoomID]
-	if !exists {
-		return
-	}
-
 	userInfo := UserInfo{
 		UserID:   leftClient.UserID,
 		Username: leftClient.Username,
@@ -162,27 +259,41 @@ func (h *Hub) notifyUserLeft(leftClient *Client) {
 		Data:     userData,
 	}
 
-	for client := range room {
-		client.Send <- leaveMsg
-	}
+	h.deliverLocal(leaveMsg, nil)
+	h.publish(leaveMsg)
 }
 
-// getRoomUsers returns list of users in a room
+// getRoomUsers returns list of users in a room across all instances,
+// falling back to local clients if the broker is unavailable
 func (h *Hub) getRoomUsers(roomID uuid.UUID) []UserInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	users, err := h.broker.RoomPresence(ctx, roomID)
+	if err == nil {
+		return users
+	}
+	log.Printf("Warning: Failed to fetch presence for room %s: %v", roomID, err)
+
 	room, exists := h.Rooms[roomID]
 	if !exists {
 		return []UserInfo{}
 	}
 
-	users := make([]UserInfo, 0, len(room))
+	users = make([]UserInfo, 0, len(room))
 	for client := range room {
-		users = append(users, UserInfo{
-			UserID:   client.UserID,
-			Username: client.Username,
-			Color:    client.Color,
-			IsOnline: true,
-		})
+		users = append(users, clientUserInfo(client))
 	}
 
 	return users
 }
+
+// clientUserInfo describes a connected client
+func clientUserInfo(client *Client) UserInfo {
+	return UserInfo{
+		UserID:   client.UserID,
+		Username: client.Username,
+		Color:    client.Color,
+		IsOnline: true,
+	}
+}
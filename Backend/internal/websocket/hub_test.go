@@ -0,0 +1,38 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBroadcastToRoomDoesNotBlock(t *testing.T) {
+	// The hub loop isn't running, so nothing drains the queue
+	hub := NewHub(nil, "test")
+	roomID := uuid.New()
+
+	done := make(chan error)
+	go func() {
+		for i := 0; i < broadcastBufferSize; i++ {
+			if err := hub.BroadcastToRoom(roomID, MessageTypeLeaderboardUpdate, i); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- hub.BroadcastToRoom(roomID, MessageTypeLeaderboardUpdate, "overflow")
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrBroadcastQueueFull) {
+			t.Fatalf("got %v, want ErrBroadcastQueueFull once the queue is full", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("BroadcastToRoom blocked")
+	}
+	if len(hub.Broadcast) != broadcastBufferSize {
+		t.Errorf("queued %d broadcasts, want %d", len(hub.Broadcast), broadcastBufferSize)
+	}
+}
@@ -40,18 +40,23 @@ const (
 	MessageTypeUserLeft   MessageType = "user_left"
 	MessageTypeUserList   MessageType = "user_list"
 
+	// Virtual contest messages (sent by the server only)
+	MessageTypeVirtualContestStarted MessageType = "virtual_contest_started"
+	MessageTypeLeaderboardUpdate     MessageType = "leaderboard_update"
+
 	// Error messages
 	MessageTypeError MessageType = "error"
 )
 
-// message struct represents a websocket message
+// message struct represents a websocket message.
+// Fields other than Type are encoded under their Go names (RoomID, UserID, ...), which the client relies on.
 type Message struct {
-	Type      MessageType     `json:"type"`
-	RoomID    uuid.UUID       `json: "room_id"`
-	UserID    uuid.UUID       `json: "user_id"`
-	Username  string          `json: "username"`
-	Data      json.RawMessage `json: "data"`
-	Timestamp time.Time       `json: "timestamp"`
+	Type      MessageType `json:"type"`
+	RoomID    uuid.UUID
+	UserID    uuid.UUID
+	Username  string
+	Data      json.RawMessage
+	Timestamp time.Time
 }
 
 // CodeUpdateData represents the code editor update data
@@ -0,0 +1,191 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	redisRoomChannelPrefix = "dojo:ws:room:"
+	redisPresencePrefix    = "dojo:ws:presence:"
+	redisInstancePrefix    = "dojo:ws:instance:"
+
+	// An instance whose heartbeat key expired is considered dead and its presence is dropped
+	instanceHeartbeatTTL      = 30 * time.Second
+	instanceHeartbeatInterval = 10 * time.Second
+
+	// Presence hashes of abandoned rooms eventually expire
+	presenceTTL = 24 * time.Hour
+)
+
+// presenceEntry is stored per client in a room's presence hash
+type presenceEntry struct {
+	Instance string   `json:"instance"`
+	User     UserInfo `json:"user"`
+}
+
+// RedisBroker fans out room messages over Redis pub/sub and keeps presence in Redis hashes,
+// so clients connected to different API instances share the same rooms
+type RedisBroker struct {
+	client     *goredis.Client
+	pubsub     *goredis.PubSub
+	instanceID string
+	messages   chan *Envelope
+	stopChan   chan struct{}
+}
+
+// NewRedisBroker creates a broker for the hub instance identified by instanceID
+func NewRedisBroker(client *goredis.Client, instanceID string) *RedisBroker {
+	b := &RedisBroker{
+		client:     client,
+		pubsub:     client.Subscribe(context.Background()),
+		instanceID: instanceID,
+		messages:   make(chan *Envelope, 256),
+		stopChan:   make(chan struct{}),
+	}
+
+	go b.receive()
+	go b.heartbeat()
+
+	return b
+}
+
+// receive decodes pub/sub messages into envelopes
+func (b *RedisBroker) receive() {
+	for msg := range b.pubsub.Channel() {
+		var env Envelope
+		if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+			log.Printf("Error unmarshaling broker message: %v", err)
+			continue
+		}
+		if env.Message == nil {
+			continue
+		}
+		b.messages <- &env
+	}
+	close(b.messages)
+}
+
+// heartbeat keeps this instance's key alive so its presence entries stay valid
+func (b *RedisBroker) heartbeat() {
+	ticker := time.NewTicker(instanceHeartbeatInterval)
+	defer ticker.Stop()
+
+	b.refreshHeartbeat()
+	for {
+		select {
+		case <-ticker.C:
+			b.refreshHeartbeat()
+		case <-b.stopChan:
+			return
+		}
+	}
+}
+
+func (b *RedisBroker) refreshHeartbeat() {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := b.client.Set(ctx, redisInstancePrefix+b.instanceID, 1, instanceHeartbeatTTL).Err(); err != nil {
+		log.Printf("Warning: Failed to refresh websocket instance heartbeat: %v", err)
+	}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, env *Envelope) error {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, redisRoomChannelPrefix+env.Message.RoomID.String(), payload).Err()
+}
+
+func (b *RedisBroker) Subscribe(ctx context.Context, roomID uuid.UUID) error {
+	return b.pubsub.Subscribe(ctx, redisRoomChannelPrefix+roomID.String())
+}
+
+func (b *RedisBroker) Unsubscribe(ctx context.Context, roomID uuid.UUID) error {
+	return b.pubsub.Unsubscribe(ctx, redisRoomChannelPrefix+roomID.String())
+}
+
+func (b *RedisBroker) Messages() <-chan *Envelope {
+	return b.messages
+}
+
+func (b *RedisBroker) AddPresence(ctx context.Context, roomID, clientID uuid.UUID, user UserInfo) error {
+	entry, err := json.Marshal(presenceEntry{Instance: b.instanceID, User: user})
+	if err != nil {
+		return err
+	}
+
+	key := redisPresencePrefix + roomID.String()
+	pipe := b.client.TxPipeline()
+	pipe.HSet(ctx, key, clientID.String(), entry)
+	pipe.Expire(ctx, key, presenceTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (b *RedisBroker) RemovePresence(ctx context.Context, roomID, clientID uuid.UUID) error {
+	return b.client.HDel(ctx, redisPresencePrefix+roomID.String(), clientID.String()).Err()
+}
+
+// RoomPresence lists users in a room, dropping entries left behind by dead instances
+func (b *RedisBroker) RoomPresence(ctx context.Context, roomID uuid.UUID) ([]UserInfo, error) {
+	key := redisPresencePrefix + roomID.String()
+	fields, err := b.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	alive := map[string]bool{b.instanceID: true}
+	users := make([]UserInfo, 0, len(fields))
+	var stale []string
+
+	for clientID, raw := range fields {
+		var entry presenceEntry
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			stale = append(stale, clientID)
+			continue
+		}
+
+		isAlive, checked := alive[entry.Instance]
+		if !checked {
+			exists, err := b.client.Exists(ctx, redisInstancePrefix+entry.Instance).Result()
+			if err != nil {
+				return nil, err
+			}
+			isAlive = exists > 0
+			alive[entry.Instance] = isAlive
+		}
+
+		if !isAlive {
+			stale = append(stale, clientID)
+			continue
+		}
+		users = append(users, entry.User)
+	}
+
+	if len(stale) > 0 {
+		if err := b.client.HDel(ctx, key, stale...).Err(); err != nil {
+			log.Printf("Warning: Failed to remove stale presence (%s): %v", strings.Join(stale, ","), err)
+		}
+	}
+
+	return users, nil
+}
+
+// Close stops the heartbeat and pub/sub subscription; the Redis client itself is left open
+func (b *RedisBroker) Close() error {
+	close(b.stopChan)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	b.client.Del(ctx, redisInstancePrefix+b.instanceID)
+
+	return b.pubsub.Close()
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"fmt"
+	"log"
+)
+
+// searchIndexes set up full-text and trigram search, which AutoMigrate cannot express with
+// struct tags. Every statement is idempotent.
+var searchIndexes = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	// array_to_string is only STABLE, so generated columns need an IMMUTABLE wrapper
+	`CREATE OR REPLACE FUNCTION immutable_array_to_string(text[]) RETURNS text
+		LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$ SELECT array_to_string($1, ' ') $$`,
+	// Problem search: title ranks above tags, tags above description
+	`ALTER TABLE problems ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(immutable_array_to_string(tags), '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'C')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_problems_search_vector ON problems USING GIN (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_problems_title_trgm ON problems USING GIN (title gin_trgm_ops)`,
+	// Full-text search over personal notes
+	`CREATE INDEX IF NOT EXISTS idx_user_notes_content_search ON user_notes USING GIN (to_tsvector('english', content))`,
+}
+
+// CreateSearchIndexes creates the search columns and indexes. It must run after AutoMigrate.
+func CreateSearchIndexes() error {
+	for _, stmt := range searchIndexes {
+		if err := DB.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+	log.Println("✅ Search indexes created")
+	return nil
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// legacyMigration prepares a table created by an earlier version for AutoMigrate. Columns and
+// unique indexes that existing rows would violate are added nullable, backfilled and
+// deduplicated first, so AutoMigrate finds them in place. Every statement is idempotent.
+type legacyMigration struct {
+	table      string
+	statements []string
+}
+
+var legacyMigrations = []legacyMigration{
+	{
+		// Contests are keyed by platform contest ID; older rows only had their URL
+		table: "contests",
+		statements: []string{
+			`ALTER TABLE contests ADD COLUMN IF NOT EXISTS platform_contest_id varchar(255)`,
+			// The platform ID is the last segment of every platform's contest URL
+			`UPDATE contests SET platform_contest_id = COALESCE(NULLIF(substring(rtrim(contest_url, '/') from '[^/]+$'), ''), id::text)
+				WHERE platform_contest_id IS NULL OR platform_contest_id = ''`,
+			// Duplicates keep their reminders and results under a suffixed ID; the most
+			// recently updated row keeps the real one
+			`UPDATE contests SET platform_contest_id = contests.platform_contest_id || '-' || contests.id::text
+				FROM (
+					SELECT id, row_number() OVER (PARTITION BY platform, platform_contest_id ORDER BY created_at DESC, id) AS n
+					FROM contests
+				) ranked
+				WHERE contests.id = ranked.id AND ranked.n > 1`,
+			`ALTER TABLE contests ALTER COLUMN platform_contest_id SET NOT NULL`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_contests_platform_contest ON contests (platform, platform_contest_id)`,
+		},
+	},
+	{
+		// Problems are keyed by platform problem ID; imports could store one twice
+		table: "problems",
+		statements: []string{
+			// Like contests, duplicates keep their notes and progress under a suffixed ID; the
+			// oldest row keeps the real one
+			`UPDATE problems SET platform_problem_id = problems.platform_problem_id || '-' || problems.id::text
+				FROM (
+					SELECT id, row_number() OVER (PARTITION BY platform, platform_problem_id ORDER BY created_at, id) AS n
+					FROM problems
+				) ranked
+				WHERE problems.id = ranked.id AND ranked.n > 1`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_problems_platform_problem ON problems (platform, platform_problem_id)`,
+		},
+	},
+	{
+		// Progress is one row per user and problem; concurrent writes could create more
+		table: "user_problem_progress",
+		statements: []string{
+			// The row that got furthest survives; the rest only repeat it
+			`DELETE FROM user_problem_progress
+				WHERE id IN (
+					SELECT id FROM (
+						SELECT id, row_number() OVER (
+							PARTITION BY user_id, problem_id ORDER BY is_solved DESC, attempts DESC, updated_at DESC, id
+						) AS n
+						FROM user_problem_progress
+					) ranked
+					WHERE ranked.n > 1
+				)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_user_problem_progress_user_problem ON user_problem_progress (user_id, problem_id)`,
+		},
+	},
+}
+
+// MigrateLegacyData runs the legacy migrations on the tables that exist. It must run before
+// AutoMigrate.
+func MigrateLegacyData() error {
+	if err := migrateLegacyData(DB); err != nil {
+		return err
+	}
+	log.Println("✅ Legacy data migrated")
+	return nil
+}
+
+func migrateLegacyData(db *gorm.DB) error {
+	for _, m := range legacyMigrations {
+		var exists bool
+		if err := db.Raw("SELECT to_regclass(?) IS NOT NULL", m.table).Scan(&exists).Error; err != nil {
+			return fmt.Errorf("failed to check table %s: %w", m.table, err)
+		}
+		if !exists {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, stmt := range m.statements {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate table %s: %w", m.table, err)
+		}
+	}
+	return nil
+}
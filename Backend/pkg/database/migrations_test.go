@@ -0,0 +1,106 @@
+package database
+
+import (
+	"dojo/internal/models"
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB returns a transaction on the database in DOJO_TEST_DATABASE_URL with an empty schema
+// first on the search path, rolled back when the test ends
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DOJO_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DOJO_TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get test database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		t.Fatalf("failed to begin transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	for _, stmt := range []string{
+		`CREATE SCHEMA dojo_migration_test`,
+		`SET LOCAL search_path TO dojo_migration_test, public`,
+	} {
+		if err := tx.Exec(stmt).Error; err != nil {
+			t.Fatalf("failed to create test schema: %v", err)
+		}
+	}
+	return tx
+}
+
+func TestMigrateLegacyContests(t *testing.T) {
+	db := testDB(t)
+
+	// The contests table as created before platform contest IDs were required
+	err := db.Exec(`CREATE TABLE contests (
+		id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+		platform varchar(50) NOT NULL,
+		platform_contest_id varchar(255),
+		name varchar(500) NOT NULL,
+		start_time timestamptz NOT NULL,
+		duration_seconds bigint NOT NULL,
+		contest_url text,
+		description text,
+		created_at timestamptz
+	)`).Error
+	if err != nil {
+		t.Fatalf("failed to create legacy contests table: %v", err)
+	}
+	err = db.Exec(`INSERT INTO contests (id, platform, platform_contest_id, name, start_time, duration_seconds, contest_url, created_at) VALUES
+		('00000000-0000-0000-0000-000000000001', 'codeforces', NULL, 'Round 1900', now(), 7200, 'https://codeforces.com/contest/1900/', now() - interval '2 days'),
+		('00000000-0000-0000-0000-000000000002', 'codeforces', '1900', 'Round 1900', now(), 7200, 'https://codeforces.com/contest/1900', now() - interval '1 day'),
+		('00000000-0000-0000-0000-000000000003', 'atcoder', '', 'ABC 300', now(), 6000, 'https://atcoder.jp/contests/abc300', now()),
+		('00000000-0000-0000-0000-000000000004', 'leetcode', NULL, 'Weekly 400', now(), 5400, '', now())`).Error
+	if err != nil {
+		t.Fatalf("failed to insert legacy contests: %v", err)
+	}
+
+	if err := migrateLegacyData(db); err != nil {
+		t.Fatalf("migrateLegacyData: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Contest{}); err != nil {
+		t.Fatalf("AutoMigrate after the legacy migration: %v", err)
+	}
+
+	var contests []models.Contest
+	if err := db.Order("id").Find(&contests).Error; err != nil {
+		t.Fatalf("failed to load contests: %v", err)
+	}
+	want := []string{
+		"1900-00000000-0000-0000-0000-000000000001", // older duplicate
+		"1900",
+		"abc300",
+		"00000000-0000-0000-0000-000000000004", // no URL to take the ID from
+	}
+	if len(contests) != len(want) {
+		t.Fatalf("got %d contests, want %d", len(contests), len(want))
+	}
+	for i, contest := range contests {
+		if contest.PlatformContestID != want[i] {
+			t.Errorf("contest %s platform_contest_id = %q, want %q", contest.ID, contest.PlatformContestID, want[i])
+		}
+	}
+
+	// Running it again on the migrated table changes nothing
+	if err := migrateLegacyData(db); err != nil {
+		t.Fatalf("second migrateLegacyData: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Storage implements fiber.Storage on top of a Redis client, so middleware
+// state such as rate limit counters is shared between API instances.
+type Storage struct {
+	client *goredis.Client
+	prefix string
+}
+
+// NewStorage creates a Storage whose keys are namespaced with prefix
+func NewStorage(client *goredis.Client, prefix string) *Storage {
+	return &Storage{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get returns the value for key, or nil if it does not exist
+func (s *Storage) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+	val, err := s.client.Get(context.Background(), s.prefix+key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	return val, err
+}
+
+// Set stores val under key; an exp of 0 means no expiration
+func (s *Storage) Set(key string, val []byte, exp time.Duration) error {
+	if key == "" || len(val) == 0 {
+		return nil
+	}
+	return s.client.Set(context.Background(), s.prefix+key, val, exp).Err()
+}
+
+// Delete removes key
+func (s *Storage) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.client.Del(context.Background(), s.prefix+key).Err()
+}
+
+// Reset removes every key under this storage's prefix
+func (s *Storage) Reset() error {
+	ctx := context.Background()
+	iter := s.client.Scan(ctx, 0, s.prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := s.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// Close is a no-op; the shared client is closed by redis.Close
+func (s *Storage) Close() error {
+	return nil
+}